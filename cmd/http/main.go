@@ -4,12 +4,19 @@ import (
 	"context"
 	"fmt"
 	ahttp "go-restaurant/internal/auth/adapter/handler/http"
+	"go-restaurant/internal/auth/adapter/oidc"
 	"go-restaurant/internal/auth/adapter/paseto"
+	arepository "go-restaurant/internal/auth/adapter/storage/postgres"
+	adomain "go-restaurant/internal/auth/domain"
 	"go-restaurant/internal/common/adapter/handler/http"
 	"go-restaurant/internal/common/adapter/storage/postgres"
 
 	aservice "go-restaurant/internal/auth/service"
 
+	audithttp "go-restaurant/internal/audit/adapter/handler/http"
+	auditrepository "go-restaurant/internal/audit/adapter/storage/postgres"
+	auditservice "go-restaurant/internal/audit/service"
+
 	ohttp "go-restaurant/internal/order/adapter/handler/http"
 	orepository "go-restaurant/internal/order/adapter/storage/postgres"
 	oservice "go-restaurant/internal/order/service"
@@ -22,18 +29,47 @@ import (
 	crepository "go-restaurant/internal/category/adapter/storage/postgres"
 	cservice "go-restaurant/internal/category/service"
 
+	chhttp "go-restaurant/internal/channel/adapter/handler/http"
+	chrepository "go-restaurant/internal/channel/adapter/storage/postgres"
+	chservice "go-restaurant/internal/channel/service"
+
+	dphttp "go-restaurant/internal/daypart/adapter/handler/http"
+	dprepository "go-restaurant/internal/daypart/adapter/storage/postgres"
+	dpservice "go-restaurant/internal/daypart/service"
+
 	phttp "go-restaurant/internal/product/adapter/handler/http"
 	prepository "go-restaurant/internal/product/adapter/storage/postgres"
 	pservice "go-restaurant/internal/product/service"
 
+	imghttp "go-restaurant/internal/image/adapter/handler/http"
+	imgservice "go-restaurant/internal/image/service"
+
+	rhttp "go-restaurant/internal/report/adapter/handler/http"
+	rrepository "go-restaurant/internal/report/adapter/storage/postgres"
+	rservice "go-restaurant/internal/report/service"
+
+	rolehttp "go-restaurant/internal/role/adapter/handler/http"
+	rolerepository "go-restaurant/internal/role/adapter/storage/postgres"
+	roleservice "go-restaurant/internal/role/service"
+
+	thttp "go-restaurant/internal/terminal/adapter/handler/http"
+	trepository "go-restaurant/internal/terminal/adapter/storage/postgres"
+	tservice "go-restaurant/internal/terminal/service"
+
 	"go-restaurant/internal/common/adapter/config"
 	"go-restaurant/internal/common/adapter/logger"
+	"go-restaurant/internal/common/adapter/mailer"
+	"go-restaurant/internal/common/adapter/storage/file"
 	"go-restaurant/internal/common/adapter/storage/redis"
+	cmdomain "go-restaurant/internal/common/domain"
 	uhttp "go-restaurant/internal/user/adapter/handler/http"
 	urepository "go-restaurant/internal/user/adapter/storage/postgres"
+	udomain "go-restaurant/internal/user/domain"
 	uservice "go-restaurant/internal/user/service"
 	"log/slog"
 	"os"
+	"time"
+	_ "time/tzdata"
 
 	_ "github.com/bagashiz/go-pos/docs"
 )
@@ -56,7 +92,7 @@ import (
 // @securityDefinitions.apikey	BearerAuth
 // @in							header
 // @name						Authorization
-// @description				Type "Bearer" followed by a space and the access token.
+// @description				Type "Bearer" followed by a space and the access token, or "ApiKey" followed by a space and the API key.
 func main() {
 	// Load environment variables
 	config, err := config.New()
@@ -100,53 +136,202 @@ func main() {
 
 	slog.Info("Successfully connected to the cache server")
 
+	// Init mailer
+	mail, err := mailer.New(config.Mail, config.App.Env)
+	if err != nil {
+		slog.Error("Error initializing mailer", "error", err)
+		os.Exit(1)
+	}
+
+	// Init file storage
+	files, err := file.New(config.Storage)
+	if err != nil {
+		slog.Error("Error initializing file storage", "error", err)
+		os.Exit(1)
+	}
+
+	// Init store settings
+	store, err := cmdomain.NewStore(config.Store.Timezone, config.Store.DayCutoff, config.Store.DiscountLimit)
+	if err != nil {
+		slog.Error("Error loading store settings", "error", err)
+		os.Exit(1)
+	}
+
 	// Init token service
-	token, err := paseto.New(config.Token)
+	token, err := paseto.New(ctx, config.Token, cache)
 	if err != nil {
 		slog.Error("Error initializing token service", "error", err)
 		os.Exit(1)
 	}
 
+	refreshDuration, err := time.ParseDuration(config.Token.RefreshDuration)
+	if err != nil {
+		slog.Error("Error parsing refresh token duration", "error", err)
+		os.Exit(1)
+	}
+
 	// Dependency injection
-	// User
-	userRepo := urepository.NewUserRepository(db)
-	userService := uservice.NewUserService(userRepo, cache)
-	userHandler := uhttp.NewUserHandler(userService)
+	// Audit
+	auditRepo := auditrepository.NewAuditRepository(db)
+	auditService := auditservice.NewAuditService(auditRepo)
+	auditHandler := audithttp.NewAuditHandler(auditService)
+
+	// Terminal
+	terminalRepo := trepository.NewTerminalRepository(db)
+	terminalService := tservice.NewTerminalService(terminalRepo, cache)
+	terminalHandler := thttp.NewTerminalHandler(terminalService)
 
 	// Auth
-	authService := aservice.NewAuthService(userRepo, token)
+	userRepo := urepository.NewUserRepository(db)
+	apiKeyRepo := arepository.NewAPIKeyRepository(db)
+	identityRepo := arepository.NewIdentityRepository(db)
+	authService := aservice.NewAuthService(userRepo, apiKeyRepo, terminalService, token, cache, refreshDuration)
 	authHandler := ahttp.NewAuthHandler(authService)
 
+	// Role
+	roleRepo := rolerepository.NewRoleRepository(db)
+	roleService := roleservice.NewRoleService(roleRepo, cache)
+	roleHandler := rolehttp.NewRoleHandler(roleService)
+
+	// User
+	registration, err := udomain.NewRegistration(config.Auth.Registration)
+	if err != nil {
+		slog.Error("Error loading registration settings", "error", err)
+		os.Exit(1)
+	}
+
+	inviteRepo := urepository.NewInviteRepository(db)
+	userService := uservice.NewUserService(userRepo, inviteRepo, identityRepo, db, cache, authService, roleService, auditService, registration)
+	userHandler := uhttp.NewUserHandler(userService)
+
+	inviteService := uservice.NewInviteService(inviteRepo, roleService, cache)
+	inviteHandler := uhttp.NewInviteHandler(inviteService)
+
+	// Bootstrap the initial admin
+	if config.Auth.AdminEmail != "" {
+		admin, err := userService.BootstrapAdmin(ctx, &udomain.User{
+			Name:     config.Auth.AdminName,
+			Email:    config.Auth.AdminEmail,
+			Password: config.Auth.AdminPassword,
+		})
+		if err != nil {
+			slog.Error("Error creating the initial admin", "error", err)
+			os.Exit(1)
+		}
+
+		if admin != nil {
+			slog.Info("Successfully created the initial admin", "email", admin.Email)
+		}
+	}
+
+	// Approval
+	approvalService := aservice.NewApprovalService(userRepo, roleService, cache)
+	approvalHandler := ahttp.NewApprovalHandler(approvalService)
+
+	// Password
+	passwordService := aservice.NewPasswordService(userRepo, db, authService, mail, cache, auditService, config.Mail.ResetURL)
+	passwordHandler := ahttp.NewPasswordHandler(passwordService)
+
+	// API key
+	apiKeyService := aservice.NewAPIKeyService(apiKeyRepo, roleService)
+	apiKeyHandler := ahttp.NewAPIKeyHandler(apiKeyService)
+
+	// Single sign-on
+	oidcProvider, err := oidc.New(config.OIDC)
+	if err != nil {
+		slog.Error("Error initializing single sign-on", "error", err)
+		os.Exit(1)
+	}
+
+	oidcRoles, err := adomain.NewOIDCRoleMapping(config.OIDC.RoleMapping)
+	if err != nil {
+		slog.Error("Error loading single sign-on role mapping", "error", err)
+		os.Exit(1)
+	}
+
+	for _, groupRole := range oidcRoles {
+		_, err := roleService.GetRole(ctx, groupRole.Role)
+		if err != nil {
+			slog.Error("Error loading single sign-on role mapping", "error", err, "group", groupRole.Group, "role", groupRole.Role)
+			os.Exit(1)
+		}
+	}
+
+	oidcService := aservice.NewOIDCService(oidcProvider, identityRepo, userRepo, userService, authService, cache, oidcRoles)
+	oidcHandler := ahttp.NewOIDCHandler(oidcService)
+
+	// Image
+	imageService := imgservice.NewImageService(files, config.Storage.PublicURL)
+	imageHandler := imghttp.NewImageHandler(imageService)
+
 	// Payment
 	paymentRepo := payrepository.NewPaymentRepository(db)
-	paymentService := payservice.NewPaymentService(paymentRepo, cache)
+	paymentService := payservice.NewPaymentService(paymentRepo, db, imageService, cache, auditService)
 	paymentHandler := payhttp.NewPaymentHandler(paymentService)
 
 	// Category
 	categoryRepo := crepository.NewCategoryRepository(db)
-	categoryService := cservice.NewCategoryService(categoryRepo, cache)
+	categoryService := cservice.NewCategoryService(categoryRepo, db, cache, auditService)
 	categoryHandler := chttp.NewCategoryHandler(categoryService)
 
+	// Day-part
+	dayPartRepo := dprepository.NewDayPartRepository(db)
+	dayPartService := dpservice.NewDayPartService(dayPartRepo, db, cache, auditService)
+	dayPartHandler := dphttp.NewDayPartHandler(dayPartService)
+
+	// Channel
+	priceListRepo := chrepository.NewPriceListRepository(db)
+	priceListService := chservice.NewPriceListService(priceListRepo, db, cache, auditService)
+	priceListHandler := chhttp.NewPriceListHandler(priceListService)
+	menuRepo := chrepository.NewMenuRepository(db)
+	menuService := chservice.NewMenuService(menuRepo, db, cache, auditService)
+	menuHandler := chhttp.NewMenuHandler(menuService)
+
 	// Product
 	productRepo := prepository.NewProductRepository(db)
-	productService := pservice.NewProductService(productRepo, categoryRepo, cache)
+	priceRepo := prepository.NewProductPriceRepository(db)
+	productService := pservice.NewProductService(productRepo, priceRepo, categoryRepo, priceListRepo, db, imageService, cache, auditService, store)
 	productHandler := phttp.NewProductHandler(productService)
+	priceService := pservice.NewProductPriceService(productRepo, priceRepo, db, cache, auditService)
+	productPriceHandler := phttp.NewProductPriceHandler(priceService)
+	go priceService.SchedulePrices(ctx)
+	go productService.ScheduleAvailabilityResets(ctx)
 
 	// Order
 	orderRepo := orepository.NewOrderRepository(db)
-	orderService := oservice.NewOrderService(orderRepo, productRepo, categoryRepo, userRepo, paymentRepo, cache)
+	orderService := oservice.NewOrderService(orderRepo, productRepo, priceRepo, categoryRepo, priceListRepo, menuRepo, userRepo, paymentRepo, db, cache, approvalService, auditService, store)
 	orderHandler := ohttp.NewOrderHandler(orderService)
 
+	// Report
+	reportRepo := rrepository.NewReportRepository(db)
+	reportService := rservice.NewReportService(reportRepo, store)
+	reportHandler := rhttp.NewReportHandler(reportService)
+
 	// Init router
 	router, err := http.NewRouter(
 		config.HTTP,
-		token,
+		authService,
+		roleService,
 		*userHandler,
+		*inviteHandler,
 		*authHandler,
+		*approvalHandler,
+		*passwordHandler,
+		*apiKeyHandler,
+		*oidcHandler,
+		*auditHandler,
 		*paymentHandler,
 		*categoryHandler,
+		*dayPartHandler,
+		*priceListHandler,
+		*menuHandler,
+		*imageHandler,
 		*productHandler,
+		*productPriceHandler,
 		*orderHandler,
+		*reportHandler,
+		*roleHandler,
+		*terminalHandler,
 	)
 	if err != nil {
 		slog.Error("Error initializing router", "error", err)
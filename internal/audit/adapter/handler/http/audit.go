@@ -0,0 +1,91 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-restaurant/internal/audit/domain"
+	"go-restaurant/internal/audit/port"
+	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+	cmutil "go-restaurant/internal/common/util"
+	"time"
+)
+
+// AuditHandler represents the HTTP handler for audit-related requests
+type AuditHandler struct {
+	svc port.AuditService
+}
+
+// NewAuditHandler creates a new AuditHandler instance
+func NewAuditHandler(svc port.AuditService) *AuditHandler {
+	return &AuditHandler{
+		svc,
+	}
+}
+
+// listEntriesRequest represents a request body for listing audit entries
+type listEntriesRequest struct {
+	ActorID    uint64            `form:"actor_id" binding:"omitempty,min=1" example:"1"`
+	Action     domain.Action     `form:"action" binding:"omitempty,audit_action" example:"update"`
+	EntityType domain.EntityType `form:"entity_type" binding:"omitempty,audit_entity_type" example:"product"`
+	EntityID   uint64            `form:"entity_id" binding:"omitempty,min=1" example:"1"`
+	StartDate  time.Time         `form:"start_date" binding:"omitempty" time_format:"2006-01-02" example:"2024-01-01"`
+	EndDate    time.Time         `form:"end_date" binding:"omitempty" time_format:"2006-01-02" example:"2024-01-31"`
+	Skip       uint64            `form:"skip" binding:"required,min=0" example:"0"`
+	Limit      uint64            `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListEntries godoc
+//
+//	@Summary		List audit entries
+//	@Description	List the recorded changes of users, products, categories, payments and orders with who made them, newest first
+//	@Tags			Audit
+//	@Accept			json
+//	@Produce		json
+//	@Param			actor_id	query		uint64			false	"Actor user ID"
+//	@Param			action		query		string			false	"Action"		Enums(create, update, delete, void, refund, restore)
+//	@Param			entity_type	query		string			false	"Entity type"	Enums(user, product, product_price, category, day_part, price_list, menu, payment, order)
+//	@Param			entity_id	query		uint64			false	"Entity ID"
+//	@Param			start_date	query		string			false	"Start date (YYYY-MM-DD)"
+//	@Param			end_date	query		string			false	"End date, inclusive (YYYY-MM-DD)"
+//	@Param			skip		query		uint64			true	"Skip"
+//	@Param			limit		query		uint64			true	"Limit"
+//	@Success		200			{object}	meta			"Audit entries displayed"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/audit [get]
+//	@Security		BearerAuth
+func (ah *AuditHandler) ListEntries(ctx *gin.Context) {
+	var req listEntriesRequest
+	var entriesList []entryResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	filter := domain.EntryFilter{
+		ActorID:    req.ActorID,
+		Action:     req.Action,
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+	}
+
+	entries, err := ah.svc.ListEntries(ctx, filter, req.Skip, req.Limit)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	for _, entry := range entries {
+		entriesList = append(entriesList, newEntryResponse(&entry))
+	}
+
+	total := uint64(len(entriesList))
+	meta := cmhttp.NewMeta(total, req.Limit, req.Skip)
+	rsp := cmutil.ToMap(meta, entriesList, "entries")
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
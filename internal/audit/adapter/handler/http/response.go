@@ -0,0 +1,52 @@
+package http
+
+import (
+	"go-restaurant/internal/audit/domain"
+	"time"
+)
+
+// changeResponse represents the value of a field before and after a change in a Response body
+type changeResponse struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// entryResponse represents an audit entry Response body
+type entryResponse struct {
+	ID         uint64                    `json:"id" example:"1"`
+	ActorID    uint64                    `json:"actor_id,omitempty" example:"1"`
+	Action     domain.Action             `json:"action" example:"update"`
+	EntityType domain.EntityType         `json:"entity_type" example:"product"`
+	EntityID   uint64                    `json:"entity_id" example:"1"`
+	Before     map[string]any            `json:"before"`
+	After      map[string]any            `json:"after"`
+	Changes    map[string]changeResponse `json:"changes"`
+	IP         string                    `json:"ip" example:"203.0.113.7"`
+	RequestID  string                    `json:"request_id" example:"3f1c2b7e-4a5d-4b8e-9c0f-1a2b3c4d5e6f"`
+	CreatedAt  time.Time                 `json:"created_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newEntryResponse is a helper function to create a Response body for handling audit entry data
+func newEntryResponse(entry *domain.Entry) entryResponse {
+	changes := make(map[string]changeResponse)
+	for field, change := range entry.Changes {
+		changes[field] = changeResponse{
+			Before: change.Before,
+			After:  change.After,
+		}
+	}
+
+	return entryResponse{
+		ID:         entry.ID,
+		ActorID:    entry.ActorID,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Before:     entry.Before,
+		After:      entry.After,
+		Changes:    changes,
+		IP:         entry.IP,
+		RequestID:  entry.RequestID,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
@@ -0,0 +1,30 @@
+package http
+
+import (
+	"github.com/go-playground/validator/v10"
+	"go-restaurant/internal/audit/domain"
+)
+
+// AuditActionValidator is a custom validator for validating audit actions
+var AuditActionValidator validator.Func = func(fl validator.FieldLevel) bool {
+	action := fl.Field().Interface().(domain.Action)
+
+	switch action {
+	case "create", "update", "delete", "void", "refund", "restore":
+		return true
+	default:
+		return false
+	}
+}
+
+// AuditEntityTypeValidator is a custom validator for validating audited entity types
+var AuditEntityTypeValidator validator.Func = func(fl validator.FieldLevel) bool {
+	entityType := fl.Field().Interface().(domain.EntityType)
+
+	switch entityType {
+	case "user", "product", "product_price", "category", "day_part", "price_list", "menu", "payment", "order":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"go-restaurant/internal/audit/domain"
+	"go-restaurant/internal/common/adapter/storage/postgres"
+	cmutil "go-restaurant/internal/common/util"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/*AuditRepository implements port.AuditRepository interface
+ * and provides access to the postgres database
+ */
+type AuditRepository struct {
+	db *postgres.DB
+}
+
+// NewAuditRepository creates a new audit repository instance
+func NewAuditRepository(db *postgres.DB) *AuditRepository {
+	return &AuditRepository{
+		db,
+	}
+}
+
+// CreateEntry creates a new audit entry record in the database
+func (ar *AuditRepository) CreateEntry(ctx context.Context, entry *domain.Entry) (*domain.Entry, error) {
+	before, err := marshalFields(entry.Before)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := marshalFields(entry.After)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return nil, err
+	}
+
+	query := ar.db.QueryBuilder.Insert("audit_entries").
+		Columns("actor_id", "action", "entity_type", "entity_id", "before", "after", "changes", "ip", "request_id").
+		Values(cmutil.NullUint64(entry.ActorID), entry.Action, entry.EntityType, entry.EntityID, before, after, changes, entry.IP, entry.RequestID).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanEntry(ar.db.QueryRow(ctx, sql, args...), entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// ListEntries retrieves a filtered list of audit entries from the database, newest first
+func (ar *AuditRepository) ListEntries(ctx context.Context, filter domain.EntryFilter, skip, limit uint64) ([]domain.Entry, error) {
+	var entries []domain.Entry
+
+	query := ar.db.QueryBuilder.Select("*").
+		From("audit_entries").
+		OrderBy("id DESC").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	if filter.ActorID != 0 {
+		query = query.Where(sq.Eq{"actor_id": filter.ActorID})
+	}
+
+	if filter.Action != "" {
+		query = query.Where(sq.Eq{"action": filter.Action})
+	}
+
+	if filter.EntityType != "" {
+		query = query.Where(sq.Eq{"entity_type": filter.EntityType})
+	}
+
+	if filter.EntityID != 0 {
+		query = query.Where(sq.Eq{"entity_id": filter.EntityID})
+	}
+
+	if !filter.StartDate.IsZero() {
+		query = query.Where(sq.GtOrEq{"created_at": filter.StartDate})
+	}
+
+	if !filter.EndDate.IsZero() {
+		query = query.Where(sq.Lt{"created_at": filter.EndDate.AddDate(0, 0, 1)})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ar.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var entry domain.Entry
+
+		err := scanEntry(rows, &entry)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// scanEntry scans an audit_entries row into an audit entry
+func scanEntry(row pgx.Row, entry *domain.Entry) error {
+	var actorID sql.NullInt64
+	var before, after, changes []byte
+
+	err := row.Scan(
+		&entry.ID,
+		&actorID,
+		&entry.Action,
+		&entry.EntityType,
+		&entry.EntityID,
+		&before,
+		&after,
+		&changes,
+		&entry.IP,
+		&entry.RequestID,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	entry.ActorID = uint64(actorID.Int64)
+	entry.Before = nil
+	entry.After = nil
+	entry.Changes = nil
+
+	if before != nil {
+		err = json.Unmarshal(before, &entry.Before)
+		if err != nil {
+			return err
+		}
+	}
+
+	if after != nil {
+		err = json.Unmarshal(after, &entry.After)
+		if err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal(changes, &entry.Changes)
+}
+
+// marshalFields encodes the fields of an entity as JSON, or as NULL if there is no entity
+func marshalFields(fields map[string]any) ([]byte, error) {
+	if fields == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(fields)
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"time"
+)
+
+// Action is an enum for the kind of change an audit entry records
+type Action string
+
+// Action enum values
+const (
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionDelete  Action = "delete"
+	ActionVoid    Action = "void"
+	ActionRefund  Action = "refund"
+	ActionRestore Action = "restore"
+)
+
+// EntityType is an enum for the kind of entity an audit entry records a change of
+type EntityType string
+
+// EntityType enum values
+const (
+	EntityUser         EntityType = "user"
+	EntityProduct      EntityType = "product"
+	EntityProductPrice EntityType = "product_price"
+	EntityCategory     EntityType = "category"
+	EntityDayPart      EntityType = "day_part"
+	EntityPriceList    EntityType = "price_list"
+	EntityMenu         EntityType = "menu"
+	EntityPayment      EntityType = "payment"
+	EntityOrder        EntityType = "order"
+)
+
+// Actor is a value object that represents who made a request and where it came from.
+// UserID is zero for requests that are not authenticated
+type Actor struct {
+	UserID    uint64
+	IP        string
+	RequestID string
+}
+
+// Change is a value object that represents the value of a field before and after a change
+type Change struct {
+	Before any
+	After  any
+}
+
+// Entry is an entity that represents a recorded change of an entity. Entries are never updated or deleted
+type Entry struct {
+	ID         uint64
+	ActorID    uint64
+	Action     Action
+	EntityType EntityType
+	EntityID   uint64
+	Before     map[string]any
+	After      map[string]any
+	Changes    map[string]Change
+	IP         string
+	RequestID  string
+	CreatedAt  time.Time
+}
+
+// EntryFilter is a value object that represents the filter of a list of audit entries.
+// Zero values do not filter
+type EntryFilter struct {
+	ActorID    uint64
+	Action     Action
+	EntityType EntityType
+	EntityID   uint64
+	StartDate  time.Time
+	EndDate    time.Time
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"go-restaurant/internal/audit/domain"
+)
+
+//go:generate mockgen -source=audit.go -destination=mock/audit.go -package=mock
+
+// AuditRepository is an interface for interacting with audit entry-related data
+type AuditRepository interface {
+	// CreateEntry inserts a new audit entry into the database
+	CreateEntry(ctx context.Context, entry *domain.Entry) (*domain.Entry, error)
+	// ListEntries selects a filtered list of audit entries with pagination, newest first
+	ListEntries(ctx context.Context, filter domain.EntryFilter, skip, limit uint64) ([]domain.Entry, error)
+}
+
+// AuditService is an interface for interacting with audit-related business logic
+type AuditService interface {
+	// Record records a change of an entity by the actor of the request. Before is nil for
+	// created entities and after is nil for deleted entities
+	Record(ctx context.Context, action domain.Action, entityType domain.EntityType, entityID uint64, before, after any) error
+	// ListEntries returns a filtered list of audit entries with pagination
+	ListEntries(ctx context.Context, filter domain.EntryFilter, skip, limit uint64) ([]domain.Entry, error)
+}
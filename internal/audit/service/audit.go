@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"go-restaurant/internal/audit/domain"
+	"go-restaurant/internal/audit/port"
+	"go-restaurant/internal/audit/util"
+	cmdomain "go-restaurant/internal/common/domain"
+	"reflect"
+)
+
+// redacted replaces the value of fields that hold secrets, so the log shows they changed but not to what
+const redacted = "[REDACTED]"
+
+// redactedFields are the fields of entities that hold secrets
+var redactedFields = []string{"Password", "PIN", "KeyHash", "CodeHash"}
+
+/*AuditService implements port.AuditService interface
+ * and provides access to the audit repository
+ */
+type AuditService struct {
+	repo port.AuditRepository
+}
+
+// NewAuditService creates a new audit service instance
+func NewAuditService(repo port.AuditRepository) *AuditService {
+	return &AuditService{
+		repo,
+	}
+}
+
+// Record records a change of an entity with the fields that changed between its state before and after,
+// attributed to the user, IP and request ID of the request in the context
+func (as *AuditService) Record(ctx context.Context, action domain.Action, entityType domain.EntityType, entityID uint64, before, after any) error {
+	beforeFields, err := toFields(before)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	afterFields, err := toFields(after)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	changes := diffFields(beforeFields, afterFields)
+
+	redactFields(beforeFields)
+	redactFields(afterFields)
+	for _, field := range redactedFields {
+		if _, ok := changes[field]; ok {
+			changes[field] = domain.Change{
+				Before: redacted,
+				After:  redacted,
+			}
+		}
+	}
+
+	actor := util.GetActor(ctx)
+
+	entry := &domain.Entry{
+		ActorID:    actor.UserID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     beforeFields,
+		After:      afterFields,
+		Changes:    changes,
+		IP:         actor.IP,
+		RequestID:  actor.RequestID,
+	}
+
+	_, err = as.repo.CreateEntry(ctx, entry)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// ListEntries lists audit entries matching a filter, newest first
+func (as *AuditService) ListEntries(ctx context.Context, filter domain.EntryFilter, skip, limit uint64) ([]domain.Entry, error) {
+	entries, err := as.repo.ListEntries(ctx, filter, skip, limit)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return entries, nil
+}
+
+// toFields converts an entity to its fields as they are encoded to JSON, or nil if there is no entity
+func toFields(entity any) (map[string]any, error) {
+	if entity == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// diffFields returns the fields whose values differ before and after a change
+func diffFields(before, after map[string]any) map[string]domain.Change {
+	changes := make(map[string]domain.Change)
+
+	for field, value := range after {
+		if !reflect.DeepEqual(before[field], value) {
+			changes[field] = domain.Change{
+				Before: before[field],
+				After:  value,
+			}
+		}
+	}
+
+	for field, value := range before {
+		if _, ok := after[field]; !ok {
+			changes[field] = domain.Change{
+				Before: value,
+			}
+		}
+	}
+
+	return changes
+}
+
+// redactFields replaces the values of fields that hold secrets
+func redactFields(fields map[string]any) {
+	for _, field := range redactedFields {
+		value, ok := fields[field]
+		if ok && value != nil && value != "" {
+			fields[field] = redacted
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package util
+
+import (
+	"context"
+	"go-restaurant/internal/audit/domain"
+)
+
+// ActorKey is the key for the actor of a request in the context
+const ActorKey = "audit_actor"
+
+// GetActor is a helper function to get the actor of a request from the context,
+// which is empty outside of a request
+func GetActor(ctx context.Context) domain.Actor {
+	actor, _ := ctx.Value(ActorKey).(domain.Actor)
+
+	return actor
+}
@@ -0,0 +1,199 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-restaurant/internal/auth/domain"
+	"go-restaurant/internal/auth/port"
+	"go-restaurant/internal/auth/util"
+	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+	cmutil "go-restaurant/internal/common/util"
+	rdomain "go-restaurant/internal/role/domain"
+	"time"
+)
+
+// APIKeyHandler represents the HTTP handler for API key-related requests
+type APIKeyHandler struct {
+	svc port.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler instance
+func NewAPIKeyHandler(svc port.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		svc,
+	}
+}
+
+// createAPIKeyRequest represents a request body for creating an API key
+type createAPIKeyRequest struct {
+	Name      string               `json:"name" binding:"required" example:"Online ordering"`
+	UserID    uint64               `json:"user_id" binding:"required,min=1" example:"1"`
+	Scopes    []rdomain.Permission `json:"scopes" binding:"required,dive,permission" example:"order.create"`
+	ExpiresAt *time.Time           `json:"expires_at" binding:"omitempty" example:"2030-01-01T00:00:00Z"`
+}
+
+// CreateAPIKey godoc
+//
+//	@Summary		Create an API key
+//	@Description	create an API key for an integration to act as a user. Requests made with it are limited to its scopes and to what the role of the user allows, and only scopes the current user is allowed can be given. The key is only returned once
+//	@Tags			API Keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			createAPIKeyRequest	body		createAPIKeyRequest		true	"Create API key request"
+//	@Success		200					{object}	apiKeyCreationResponse	"API key created"
+//	@Failure		400					{object}	errorResponse			"Validation error"
+//	@Failure		401					{object}	errorResponse			"Unauthorized error"
+//	@Failure		403					{object}	errorResponse			"Forbidden error"
+//	@Failure		404					{object}	errorResponse			"Data not found error"
+//	@Failure		500					{object}	errorResponse			"Internal server error"
+//	@Router			/api-keys [post]
+//	@Security		BearerAuth
+func (ah *APIKeyHandler) CreateAPIKey(ctx *gin.Context) {
+	var req createAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	authPayload := util.GetAuthPayload(ctx, cmhttp.AuthorizationPayloadKey)
+
+	apiKey := domain.APIKey{
+		Name:      req.Name,
+		UserID:    req.UserID,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	key, plainKey, err := ah.svc.CreateAPIKey(ctx, authPayload, &apiKey)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newAPIKeyCreationResponse(key, plainKey)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// getAPIKeyRequest represents a request body for retrieving an API key
+type getAPIKeyRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetAPIKey godoc
+//
+//	@Summary		Get an API key
+//	@Description	get an API key by id
+//	@Tags			API Keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"API key ID"
+//	@Success		200	{object}	apiKeyResponse	"API key retrieved"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/api-keys/{id} [get]
+//	@Security		BearerAuth
+func (ah *APIKeyHandler) GetAPIKey(ctx *gin.Context) {
+	var req getAPIKeyRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	key, err := ah.svc.GetAPIKey(ctx, req.ID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newAPIKeyResponse(key)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// listAPIKeysRequest represents a request body for listing API keys
+type listAPIKeysRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListAPIKeys godoc
+//
+//	@Summary		List API keys
+//	@Description	List API keys with pagination
+//	@Tags			API Keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"API keys displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/api-keys [get]
+//	@Security		BearerAuth
+func (ah *APIKeyHandler) ListAPIKeys(ctx *gin.Context) {
+	var req listAPIKeysRequest
+	var keysList []apiKeyResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	keys, err := ah.svc.ListAPIKeys(ctx, req.Skip, req.Limit)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	for _, key := range keys {
+		keysList = append(keysList, newAPIKeyResponse(&key))
+	}
+
+	total := uint64(len(keysList))
+	meta := cmhttp.NewMeta(total, req.Limit, req.Skip)
+	rsp := cmutil.ToMap(meta, keysList, "api_keys")
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// deleteAPIKeyRequest represents a request body for deleting an API key
+type deleteAPIKeyRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeleteAPIKey godoc
+//
+//	@Summary		Delete an API key
+//	@Description	Delete an API key by id. It stops working right away
+//	@Tags			API Keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"API key ID"
+//	@Success		200	{object}	response		"API key deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/api-keys/{id} [delete]
+//	@Security		BearerAuth
+func (ah *APIKeyHandler) DeleteAPIKey(ctx *gin.Context) {
+	var req deleteAPIKeyRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	err := ah.svc.DeleteAPIKey(ctx, req.ID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	cmhttp.HandleSuccess(ctx, nil)
+}
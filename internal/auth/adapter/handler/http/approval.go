@@ -0,0 +1,65 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-restaurant/internal/auth/port"
+	"go-restaurant/internal/auth/util"
+	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+	rdomain "go-restaurant/internal/role/domain"
+)
+
+// ApprovalHandler represents the HTTP handler for approval-related requests
+type ApprovalHandler struct {
+	svc port.ApprovalService
+}
+
+// NewApprovalHandler creates a new ApprovalHandler instance
+func NewApprovalHandler(svc port.ApprovalService) *ApprovalHandler {
+	return &ApprovalHandler{
+		svc,
+	}
+}
+
+// createApprovalRequest represents the request body for creating an approval token
+type createApprovalRequest struct {
+	ApproverID uint64             `json:"approver_id" binding:"required,min=1" example:"2"`
+	PIN        string             `json:"pin" binding:"required,numeric,min=4,max=8" example:"4821"`
+	Permission rdomain.Permission `json:"permission" binding:"required,permission" example:"order.void"`
+	TargetID   uint64             `json:"target_id" binding:"omitempty" example:"1"`
+}
+
+// CreateApprovalToken godoc
+//
+//	@Summary		Create an approval token
+//	@Description	A user whose role allows the permission enters their PIN to approve one sensitive action of the current user on the target, such as the ID of the order to void. The target is left empty for actions creating a new record. The returned token is sent with that action, can be used once and expires after five minutes
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			createApprovalRequest	body		createApprovalRequest	true	"Create approval request"
+//	@Success		200						{object}	approvalResponse		"Approval created"
+//	@Failure		400						{object}	errorResponse			"Validation error"
+//	@Failure		401						{object}	errorResponse			"Unauthorized error"
+//	@Failure		403						{object}	errorResponse			"Forbidden error"
+//	@Failure		429						{object}	errorResponse			"Too many attempts error"
+//	@Failure		500						{object}	errorResponse			"Internal server error"
+//	@Router			/approvals [post]
+//	@Security		BearerAuth
+func (ah *ApprovalHandler) CreateApprovalToken(ctx *gin.Context) {
+	var req createApprovalRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	authPayload := util.GetAuthPayload(ctx, cmhttp.AuthorizationPayloadKey)
+
+	token, err := ah.svc.CreateApprovalToken(ctx, authPayload.UserID, req.ApproverID, req.PIN, req.Permission, req.TargetID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newApprovalResponse(token)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
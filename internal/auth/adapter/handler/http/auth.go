@@ -3,6 +3,7 @@ package http
 import (
 	"github.com/gin-gonic/gin"
 	"go-restaurant/internal/auth/port"
+	"go-restaurant/internal/auth/util"
 	cmhttp "go-restaurant/internal/common/adapter/handler/http"
 )
 
@@ -27,7 +28,7 @@ type loginRequest struct {
 // Login godoc
 //
 //	@Summary		Login and get an access token
-//	@Description	Logs in a registered user and returns an access token if the credentials are valid.
+//	@Description	Logs in a registered user and returns an access token and a refresh token if the credentials are valid. Retries are delayed after a few failed logins for an email, and the account is locked after too many.
 //	@Tags			Users
 //	@Accept			json
 //	@Produce		json
@@ -35,6 +36,8 @@ type loginRequest struct {
 //	@Success		200		{object}	authResponse	"Succesfully logged in"
 //	@Failure		400		{object}	errorResponse	"Validation error"
 //	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		423		{object}	errorResponse	"Account locked error"
+//	@Failure		429		{object}	errorResponse	"Too many attempts error"
 //	@Failure		500		{object}	errorResponse	"Internal server error"
 //	@Router			/users/login [post]
 func (ah *AuthHandler) Login(ctx *gin.Context) {
@@ -44,7 +47,7 @@ func (ah *AuthHandler) Login(ctx *gin.Context) {
 		return
 	}
 
-	token, err := ah.svc.Login(ctx, req.Email, req.Password)
+	token, err := ah.svc.Login(ctx, req.Email, req.Password, ctx.ClientIP())
 	if err != nil {
 		cmhttp.HandleError(ctx, err)
 		return
@@ -54,3 +57,175 @@ func (ah *AuthHandler) Login(ctx *gin.Context) {
 
 	cmhttp.HandleSuccess(ctx, rsp)
 }
+
+// pinLoginRequest represents the request body for logging in a user with their PIN on a terminal
+type pinLoginRequest struct {
+	TerminalKey string `json:"terminal_key" binding:"required" example:"bXkgdGVybWluYWwga2V5IGlzIHNlY3JldCBhbmQgcmFuZG9t"`
+	UserID      uint64 `json:"user_id" binding:"required,min=1" example:"1"`
+	PIN         string `json:"pin" binding:"required,numeric,min=4,max=8" example:"4821"`
+}
+
+// PINLogin godoc
+//
+//	@Summary		Login with a PIN on a terminal
+//	@Description	Logs in a user with their PIN on a registered terminal, identified by the key it was issued. Returns a short-lived access token bound to the terminal and no refresh token. Requests with the token must send the terminal key in the X-Terminal-Key header. The PIN is locked after too many wrong attempts.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		pinLoginRequest	true	"PIN login request body"
+//	@Success		200		{object}	authResponse	"Succesfully logged in"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		429		{object}	errorResponse	"Too many attempts error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/users/login/pin [post]
+func (ah *AuthHandler) PINLogin(ctx *gin.Context) {
+	var req pinLoginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	token, err := ah.svc.PINLogin(ctx, req.TerminalKey, req.UserID, req.PIN)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newAuthResponse(token)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// refreshRequest represents the request body for refreshing an access token
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required" example:"kVx0dI2cJ6n0Vt0tq6bW7Zq2Q0x4mXnq3sY2m1n0b9E"`
+}
+
+// Refresh godoc
+//
+//	@Summary		Refresh an access token
+//	@Description	Exchanges a refresh token for a new access token and refresh token. A refresh token can only be used once.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		refreshRequest	true	"Refresh request body"
+//	@Success		200		{object}	authResponse	"Succesfully refreshed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/auth/refresh [post]
+func (ah *AuthHandler) Refresh(ctx *gin.Context) {
+	var req refreshRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	token, err := ah.svc.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newAuthResponse(token)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// logoutRequest represents the request body for logging out a user
+type logoutRequest struct {
+	RefreshToken string `json:"refresh_token" example:"kVx0dI2cJ6n0Vt0tq6bW7Zq2Q0x4mXnq3sY2m1n0b9E"`
+}
+
+// Logout godoc
+//
+//	@Summary		Logout
+//	@Description	Revokes the access token of the request and, if given, its refresh token.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		logoutRequest	false	"Logout request body"
+//	@Success		200		{object}	response		"Succesfully logged out"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/auth/logout [post]
+//	@Security		BearerAuth
+func (ah *AuthHandler) Logout(ctx *gin.Context) {
+	var req logoutRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			cmhttp.ValidationError(ctx, err)
+			return
+		}
+	}
+
+	authPayload := util.GetAuthPayload(ctx, cmhttp.AuthorizationPayloadKey)
+
+	err := ah.svc.Logout(ctx, authPayload, req.RefreshToken)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	cmhttp.HandleSuccess(ctx, nil)
+}
+
+// unlockUserRequest represents the request body for unlocking a user
+type unlockUserRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// UnlockUser godoc
+//
+//	@Summary		Unlock a user
+//	@Description	Unlocks a user whose account or PIN is locked after too many failed attempts
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"User ID"
+//	@Success		200	{object}	response		"User unlocked"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/users/{id}/unlock [post]
+//	@Security		BearerAuth
+func (ah *AuthHandler) UnlockUser(ctx *gin.Context) {
+	var req unlockUserRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	err := ah.svc.UnlockUser(ctx, req.ID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	cmhttp.HandleSuccess(ctx, nil)
+}
+
+// ListPublicKeys godoc
+//
+//	@Summary		List token public keys
+//	@Description	Lists the public keys that verify v4.public access tokens, identified by the key ID in the token footer. The list is empty when tokens are v2.local.
+//	@Tags			Users
+//	@Produce		json
+//	@Success		200	{object}	publicKeySetResponse	"Public keys listed"
+//	@Failure		500	{object}	errorResponse			"Internal server error"
+//	@Router			/auth/keys [get]
+func (ah *AuthHandler) ListPublicKeys(ctx *gin.Context) {
+	keys, err := ah.svc.ListPublicKeys(ctx)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newPublicKeySetResponse(keys)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
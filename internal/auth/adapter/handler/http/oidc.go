@@ -0,0 +1,83 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-restaurant/internal/auth/port"
+	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+)
+
+// OIDCHandler represents the HTTP handler for single sign-on-related requests
+type OIDCHandler struct {
+	svc port.OIDCService
+}
+
+// NewOIDCHandler creates a new OIDCHandler instance
+func NewOIDCHandler(svc port.OIDCService) *OIDCHandler {
+	return &OIDCHandler{
+		svc,
+	}
+}
+
+// StartLogin godoc
+//
+//	@Summary		Start a single sign-on login
+//	@Description	Starts a login at the identity provider and returns the URL to send the user to. The identity provider redirects the user back to the configured redirect URL with a code and state, which must be sent to the callback within ten minutes
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	oidcLoginResponse	"Single sign-on login started"
+//	@Failure		404	{object}	errorResponse		"Single sign-on is not configured"
+//	@Failure		500	{object}	errorResponse		"Internal server error"
+//	@Router			/auth/oidc/login [get]
+func (oh *OIDCHandler) StartLogin(ctx *gin.Context) {
+	authURL, err := oh.svc.StartLogin(ctx)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newOIDCLoginResponse(authURL)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// oidcCallbackRequest represents the request query for finishing a single sign-on login
+type oidcCallbackRequest struct {
+	Code  string `form:"code" binding:"required" example:"SplxlOBeZQQYbYS6WxSbIA"`
+	State string `form:"state" binding:"required" example:"af0ifjsldkj"`
+}
+
+// Callback godoc
+//
+//	@Summary		Finish a single sign-on login
+//	@Description	Finishes a login with the code and state the identity provider redirected the user back with and returns the same tokens as a password login. The groups of the user at the identity provider decide their role, and a user is created on their first login
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	query		string			true	"Authorization code"
+//	@Param			state	query		string			true	"State"
+//	@Success		200		{object}	authResponse	"Succesfully logged in"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		404		{object}	errorResponse	"Single sign-on is not configured"
+//	@Failure		409		{object}	errorResponse	"Data conflict error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/auth/oidc/callback [get]
+func (oh *OIDCHandler) Callback(ctx *gin.Context) {
+	var req oidcCallbackRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	token, err := oh.svc.Login(ctx, req.State, req.Code)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newAuthResponse(token)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-restaurant/internal/auth/port"
+	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+)
+
+// PasswordHandler represents the HTTP handler for password reset-related requests
+type PasswordHandler struct {
+	svc port.PasswordService
+}
+
+// NewPasswordHandler creates a new PasswordHandler instance
+func NewPasswordHandler(svc port.PasswordService) *PasswordHandler {
+	return &PasswordHandler{
+		svc,
+	}
+}
+
+// forgotPasswordRequest represents the request body for requesting a password reset
+type forgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email" example:"test@example.com"`
+}
+
+// ForgotPassword godoc
+//
+//	@Summary		Request a password reset
+//	@Description	Emails a password reset token to the user with the email. The response is the same whether or not the email is registered
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		forgotPasswordRequest	true	"Forgot password request body"
+//	@Success		200		{object}	response				"Password reset requested"
+//	@Failure		400		{object}	errorResponse			"Validation error"
+//	@Failure		500		{object}	errorResponse			"Internal server error"
+//	@Router			/auth/password/forgot [post]
+func (ph *PasswordHandler) ForgotPassword(ctx *gin.Context) {
+	var req forgotPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	err := ph.svc.RequestPasswordReset(ctx, req.Email)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	cmhttp.HandleSuccess(ctx, nil)
+}
+
+// resetPasswordRequest represents the request body for resetting a password
+type resetPasswordRequest struct {
+	Token    string `json:"token" binding:"required" example:"kVx0dI2cJ6n0Vt0tq6bW7Zq2Q0x4mXnq3sY2m1n0b9E"`
+	Password string `json:"password" binding:"required,min=8" example:"87654321"`
+}
+
+// ResetPassword godoc
+//
+//	@Summary		Reset a password
+//	@Description	Sets a new password with a reset token, which can only be used once and expires after an hour. Every session of the user is logged out and their account is unlocked
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		resetPasswordRequest	true	"Reset password request body"
+//	@Success		200		{object}	response				"Password reset"
+//	@Failure		400		{object}	errorResponse			"Validation error"
+//	@Failure		500		{object}	errorResponse			"Internal server error"
+//	@Router			/auth/password/reset [post]
+func (ph *PasswordHandler) ResetPassword(ctx *gin.Context) {
+	var req resetPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	err := ph.svc.ResetPassword(ctx, req.Token, req.Password)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	cmhttp.HandleSuccess(ctx, nil)
+}
@@ -1,13 +1,124 @@
 package http
 
+import (
+	"encoding/base64"
+	"go-restaurant/internal/auth/domain"
+	rdomain "go-restaurant/internal/role/domain"
+	"time"
+)
+
 // authResponse represents an authentication Response body
 type authResponse struct {
-	AccessToken string `json:"token" example:"v2.local.Gdh5kiOTyyaQ3_bNykYDeYHO21Jg2..."`
+	AccessToken  string `json:"token" example:"v2.local.Gdh5kiOTyyaQ3_bNykYDeYHO21Jg2..."`
+	RefreshToken string `json:"refresh_token,omitempty" example:"kVx0dI2cJ6n0Vt0tq6bW7Zq2Q0x4mXnq3sY2m1n0b9E"`
 }
 
 // newAuthResponse is a helper function to create a Response body for handling authentication data
-func newAuthResponse(token string) authResponse {
+func newAuthResponse(token *domain.AuthToken) authResponse {
 	return authResponse{
-		AccessToken: token,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+	}
+}
+
+// oidcLoginResponse represents a single sign-on login Response body
+type oidcLoginResponse struct {
+	URL string `json:"url" example:"https://idp.example.com/authorize?client_id=go-restaurant&response_type=code"`
+}
+
+// newOIDCLoginResponse is a helper function to create a Response body for handling single sign-on login data
+func newOIDCLoginResponse(url string) oidcLoginResponse {
+	return oidcLoginResponse{
+		URL: url,
+	}
+}
+
+// publicKeyResponse represents a public key in the JSON Web Key format
+type publicKeyResponse struct {
+	KeyType   string    `json:"kty" example:"OKP"`
+	Curve     string    `json:"crv" example:"Ed25519"`
+	Use       string    `json:"use" example:"sig"`
+	Algorithm string    `json:"alg" example:"v4.public"`
+	KeyID     string    `json:"kid" example:"9a4c25d3-9786-492c-b084-85cb75c1ee3e"`
+	X         string    `json:"x" example:"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"`
+	ActiveAt  time.Time `json:"active_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// publicKeySetResponse represents a set of public keys in the JSON Web Key Set format
+type publicKeySetResponse struct {
+	Keys []publicKeyResponse `json:"keys"`
+}
+
+// newPublicKeySetResponse is a helper function to create a Response body for handling public key data
+func newPublicKeySetResponse(keys []domain.PublicKey) publicKeySetResponse {
+	rsp := publicKeySetResponse{
+		Keys: []publicKeyResponse{},
+	}
+
+	for _, key := range keys {
+		rsp.Keys = append(rsp.Keys, publicKeyResponse{
+			KeyType:   "OKP",
+			Curve:     "Ed25519",
+			Use:       "sig",
+			Algorithm: "v4.public",
+			KeyID:     key.ID,
+			X:         base64.RawURLEncoding.EncodeToString(key.Key),
+			ActiveAt:  key.ActiveAt,
+		})
+	}
+
+	return rsp
+}
+
+// approvalResponse represents an approval Response body
+type approvalResponse struct {
+	Token string `json:"token" example:"Q2hpa2kgQmFsbCBhcHByb3ZhbCB0b2tlbg"`
+}
+
+// newApprovalResponse is a helper function to create a Response body for handling approval data
+func newApprovalResponse(token string) approvalResponse {
+	return approvalResponse{
+		Token: token,
+	}
+}
+
+// apiKeyResponse represents an API key Response body
+type apiKeyResponse struct {
+	ID         uint64               `json:"id" example:"1"`
+	Name       string               `json:"name" example:"Online ordering"`
+	Prefix     string               `json:"prefix" example:"3f9a1c0b7d2e"`
+	UserID     uint64               `json:"user_id" example:"1"`
+	Scopes     []rdomain.Permission `json:"scopes" example:"order.create"`
+	ExpiresAt  *time.Time           `json:"expires_at" example:"1970-01-01T00:00:00Z"`
+	LastUsedAt *time.Time           `json:"last_used_at" example:"1970-01-01T00:00:00Z"`
+	CreatedAt  time.Time            `json:"created_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newAPIKeyResponse is a helper function to create a Response body for handling API key data
+func newAPIKeyResponse(key *domain.APIKey) apiKeyResponse {
+	return apiKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		UserID:     key.UserID,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+// apiKeyCreationResponse represents a created API key Response body
+type apiKeyCreationResponse struct {
+	apiKeyResponse
+	Key string `json:"key" example:"grk_3f9a1c0b7d2e_bXkgYXBpIGtleSBpcyBzZWNyZXQgYW5kIHJhbmRvbQ"`
+}
+
+// newAPIKeyCreationResponse is a helper function to create a Response body for handling
+// a created API key and the key itself
+func newAPIKeyCreationResponse(key *domain.APIKey, plainKey string) apiKeyCreationResponse {
+	return apiKeyCreationResponse{
+		apiKeyResponse: newAPIKeyResponse(key),
+		Key:            plainKey,
 	}
 }
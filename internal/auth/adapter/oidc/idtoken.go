@@ -0,0 +1,316 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"go-restaurant/internal/auth/domain"
+	cmdomain "go-restaurant/internal/common/domain"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	// clockSkew is how far the clock of the identity provider may be ahead of ours
+	clockSkew = time.Minute
+	// keysRefreshInterval is how long to wait before fetching the signing keys again for an unknown key id
+	keysRefreshInterval = time.Minute
+)
+
+// errUnsupportedKey is an error for when a signing key is of a type that is not supported
+var errUnsupportedKey = errors.New("oidc: unsupported key")
+
+// idTokenHeader is the header of an ID token
+type idTokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// audience is the aud claim of an ID token, which is either a string or a list of strings
+type audience []string
+
+// UnmarshalJSON decodes an audience from a string or a list of strings
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+
+	*a = list
+	return nil
+}
+
+// idTokenClaims are the claims of an ID token that are verified or describe the user
+type idTokenClaims struct {
+	Issuer        string   `json:"iss"`
+	Subject       string   `json:"sub"`
+	Audience      audience `json:"aud"`
+	ExpiresAt     float64  `json:"exp"`
+	Nonce         string   `json:"nonce"`
+	Email         string   `json:"email"`
+	EmailVerified any      `json:"email_verified"`
+	Name          string   `json:"name"`
+}
+
+// jwk is a public key in the JSON Web Key format
+type jwk struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+// verifyIDToken verifies the signature and claims of an ID token and returns the identity of its user.
+// Tokens signed with RS256 and ES256 are supported
+func (p *Provider) verifyIDToken(ctx context.Context, rawToken, nonce string) (*domain.OIDCIdentity, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return nil, cmdomain.ErrInvalidSSOLogin
+	}
+
+	var header idTokenHeader
+	err := decodeSegment(parts[0], &header)
+	if err != nil {
+		return nil, cmdomain.ErrInvalidSSOLogin
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, cmdomain.ErrInvalidSSOLogin
+	}
+
+	key, err := p.getKey(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	isValid := verifySignature(header.Algorithm, key, parts[0]+"."+parts[1], signature)
+	if !isValid {
+		return nil, cmdomain.ErrInvalidSSOLogin
+	}
+
+	var claims idTokenClaims
+	err = decodeSegment(parts[1], &claims)
+	if err != nil {
+		return nil, cmdomain.ErrInvalidSSOLogin
+	}
+
+	var rawClaims map[string]json.RawMessage
+	err = decodeSegment(parts[1], &rawClaims)
+	if err != nil {
+		return nil, cmdomain.ErrInvalidSSOLogin
+	}
+
+	isAudience := false
+	for _, aud := range claims.Audience {
+		if aud == p.clientID {
+			isAudience = true
+		}
+	}
+
+	expiredAt := time.Unix(int64(claims.ExpiresAt), 0)
+	isExpired := time.Now().Add(-clockSkew).After(expiredAt)
+
+	if claims.Issuer != p.issuer || !isAudience || isExpired || claims.Nonce != nonce || claims.Subject == "" {
+		return nil, cmdomain.ErrInvalidSSOLogin
+	}
+
+	return &domain.OIDCIdentity{
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified == true || claims.EmailVerified == "true",
+		Name:          claims.Name,
+		Groups:        parseGroups(rawClaims[p.groupsClaim]),
+	}, nil
+}
+
+// getKey returns the signing key with a key id, fetching the keys of the identity provider again
+// if the key is unknown, which happens after the provider rotates its keys
+func (p *Provider) getKey(ctx context.Context, keyID string) (crypto.PublicKey, error) {
+	p.keysMu.Lock()
+	defer p.keysMu.Unlock()
+
+	key, ok := p.findKey(keyID)
+	if ok {
+		return key, nil
+	}
+
+	isRecent := time.Since(p.keysFetchedAt) < keysRefreshInterval
+	if isRecent {
+		return nil, cmdomain.ErrInvalidSSOLogin
+	}
+
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var keySet struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	err = p.getJSON(ctx, d.JWKSURI, &keySet)
+	if err != nil {
+		return nil, err
+	}
+
+	p.keys = make(map[string]crypto.PublicKey)
+	for _, k := range keySet.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+
+		p.keys[k.KeyID] = publicKey
+	}
+	p.keysFetchedAt = time.Now()
+
+	key, ok = p.findKey(keyID)
+	if !ok {
+		return nil, cmdomain.ErrInvalidSSOLogin
+	}
+
+	return key, nil
+}
+
+// findKey looks up a fetched signing key by key id. A token without a key id
+// can only be verified if the identity provider has a single key
+func (p *Provider) findKey(keyID string) (crypto.PublicKey, bool) {
+	if keyID == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+
+	key, ok := p.keys[keyID]
+
+	return key, ok
+}
+
+// publicKey decodes an RSA or P-256 public key
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		if !e.IsInt64() {
+			return nil, errUnsupportedKey
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Curve != "P-256" {
+			return nil, errUnsupportedKey
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	default:
+		return nil, errUnsupportedKey
+	}
+}
+
+// verifySignature verifies the signature of the signed part of a token with the key and algorithm
+func verifySignature(algorithm string, key crypto.PublicKey, signed string, signature []byte) bool {
+	hash := sha256.Sum256([]byte(signed))
+
+	switch algorithm {
+	case "RS256":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return false
+		}
+
+		return rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, hash[:], signature) == nil
+	case "ES256":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(signature) != 64 {
+			return false
+		}
+
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+
+		return ecdsa.Verify(ecKey, hash[:], r, s)
+	default:
+		return false
+	}
+}
+
+// parseGroups decodes the groups claim, which is either a list of strings or a single string
+func parseGroups(claim json.RawMessage) []string {
+	if claim == nil {
+		return nil
+	}
+
+	var groups []string
+	if err := json.Unmarshal(claim, &groups); err == nil {
+		return groups
+	}
+
+	var group string
+	if err := json.Unmarshal(claim, &group); err == nil {
+		return []string{group}
+	}
+
+	return nil
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a token
+func decodeSegment(segment string, output any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, output)
+}
+
+// decodeBigInt decodes a base64url encoded big-endian integer of a key
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
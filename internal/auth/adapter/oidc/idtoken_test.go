@@ -0,0 +1,432 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"go-restaurant/internal/common/adapter/config"
+	cmdomain "go-restaurant/internal/common/domain"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testClientID = "go-restaurant"
+	testNonce    = "nonce"
+)
+
+// mockProvider is a local identity provider that publishes its discovery document and signing keys
+// and answers the token endpoint with an ID token set by the test
+type mockProvider struct {
+	*httptest.Server
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+
+	mu      sync.Mutex
+	keys    []jwk
+	idToken string
+	form    map[string]string
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	mp := &mockProvider{
+		rsaKey: rsaKey,
+		ecKey:  ecKey,
+		keys:   []jwk{rsaJWK("rsa-1", &rsaKey.PublicKey), ecJWK("ec-1", &ecKey.PublicKey)},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(discovery{
+			Issuer:                mp.URL,
+			AuthorizationEndpoint: mp.URL + "/authorize",
+			TokenEndpoint:         mp.URL + "/token",
+			JWKSURI:               mp.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		mp.mu.Lock()
+		defer mp.mu.Unlock()
+
+		_ = json.NewEncoder(w).Encode(map[string][]jwk{"keys": mp.keys})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		mp.mu.Lock()
+		defer mp.mu.Unlock()
+
+		_ = r.ParseForm()
+		mp.form = map[string]string{
+			"code":          r.PostForm.Get("code"),
+			"code_verifier": r.PostForm.Get("code_verifier"),
+		}
+
+		if r.PostForm.Get("code") != "code" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": mp.idToken})
+	})
+
+	mp.Server = httptest.NewServer(mux)
+	t.Cleanup(mp.Close)
+
+	return mp
+}
+
+func (mp *mockProvider) newProvider(t *testing.T) *Provider {
+	t.Helper()
+
+	provider, err := New(&config.OIDC{
+		Issuer:      mp.URL,
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost:8080/v1/auth/sso/callback",
+	})
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	return provider.(*Provider)
+}
+
+// claims returns the claims of a valid ID token, which the tests change one at a time
+func (mp *mockProvider) claims() map[string]any {
+	return map[string]any{
+		"iss":            mp.URL,
+		"sub":            "user-1",
+		"aud":            testClientID,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"nonce":          testNonce,
+		"email":          "cashier@example.com",
+		"email_verified": true,
+		"name":           "Cashier",
+		"groups":         []string{"pos-cashiers"},
+	}
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) jwk {
+	return jwk{
+		KeyType: "RSA",
+		KeyID:   kid,
+		Use:     "sig",
+		N:       base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) jwk {
+	return jwk{
+		KeyType: "EC",
+		KeyID:   kid,
+		Curve:   "P-256",
+		X:       base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		Y:       base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+// signToken creates a token with the claims signed with the key for the algorithm
+func signToken(t *testing.T, algorithm, kid string, key crypto.PrivateKey, claims map[string]any) string {
+	t.Helper()
+
+	header, err := json.Marshal(idTokenHeader{Algorithm: algorithm, KeyID: kid})
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	hash := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, hash[:])
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, hash[:])
+		if err == nil {
+			signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+		}
+	}
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	mp := newMockProvider(t)
+	p := mp.newProvider(t)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	header, _ := json.Marshal(idTokenHeader{Algorithm: "none", KeyID: "rsa-1"})
+	payload, _ := json.Marshal(mp.claims())
+	unsignedToken := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+
+	with := func(claim string, value any) map[string]any {
+		claims := mp.claims()
+		if value == nil {
+			delete(claims, claim)
+		} else {
+			claims[claim] = value
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "RS256",
+			token: signToken(t, "RS256", "rsa-1", mp.rsaKey, mp.claims()),
+		},
+		{
+			name:  "ES256",
+			token: signToken(t, "ES256", "ec-1", mp.ecKey, mp.claims()),
+		},
+		{
+			name:  "audience list",
+			token: signToken(t, "RS256", "rsa-1", mp.rsaKey, with("aud", []string{"other", testClientID})),
+		},
+		{
+			name:  "expired within clock skew",
+			token: signToken(t, "RS256", "rsa-1", mp.rsaKey, with("exp", time.Now().Add(-clockSkew/2).Unix())),
+		},
+		{
+			name:    "expired",
+			token:   signToken(t, "RS256", "rsa-1", mp.rsaKey, with("exp", time.Now().Add(-2*clockSkew).Unix())),
+			wantErr: true,
+		},
+		{
+			name:    "other issuer",
+			token:   signToken(t, "RS256", "rsa-1", mp.rsaKey, with("iss", "https://example.com")),
+			wantErr: true,
+		},
+		{
+			name:    "other audience",
+			token:   signToken(t, "RS256", "rsa-1", mp.rsaKey, with("aud", "other")),
+			wantErr: true,
+		},
+		{
+			name:    "other nonce",
+			token:   signToken(t, "RS256", "rsa-1", mp.rsaKey, with("nonce", "other")),
+			wantErr: true,
+		},
+		{
+			name:    "missing subject",
+			token:   signToken(t, "RS256", "rsa-1", mp.rsaKey, with("sub", nil)),
+			wantErr: true,
+		},
+		{
+			name:    "signed with another key",
+			token:   signToken(t, "RS256", "rsa-1", otherKey, mp.claims()),
+			wantErr: true,
+		},
+		{
+			name:    "algorithm of another key type",
+			token:   signToken(t, "ES256", "rsa-1", mp.ecKey, mp.claims()),
+			wantErr: true,
+		},
+		{
+			name:    "unsupported algorithm",
+			token:   unsignedToken,
+			wantErr: true,
+		},
+		{
+			name:    "unknown key id",
+			token:   signToken(t, "RS256", "rsa-2", mp.rsaKey, mp.claims()),
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			token:   "token",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := p.verifyIDToken(context.Background(), tt.token, testNonce)
+
+			if tt.wantErr {
+				if !errors.Is(err, cmdomain.ErrInvalidSSOLogin) {
+					t.Fatalf("got error %v, want %v", err, cmdomain.ErrInvalidSSOLogin)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+
+			if identity.Issuer != mp.URL || identity.Subject != "user-1" || identity.Email != "cashier@example.com" || !identity.EmailVerified {
+				t.Fatalf("got identity %+v", identity)
+			}
+		})
+	}
+}
+
+func TestVerifyIDTokenClaims(t *testing.T) {
+	mp := newMockProvider(t)
+	p := mp.newProvider(t)
+
+	tests := []struct {
+		name              string
+		claim             string
+		value             any
+		wantGroups        []string
+		wantEmailVerified bool
+	}{
+		{
+			name:              "group list",
+			claim:             "groups",
+			value:             []string{"pos-cashiers", "pos-managers"},
+			wantGroups:        []string{"pos-cashiers", "pos-managers"},
+			wantEmailVerified: true,
+		},
+		{
+			name:              "single group",
+			claim:             "groups",
+			value:             "pos-managers",
+			wantGroups:        []string{"pos-managers"},
+			wantEmailVerified: true,
+		},
+		{
+			name:              "email verified as a string",
+			claim:             "email_verified",
+			value:             "true",
+			wantGroups:        []string{"pos-cashiers"},
+			wantEmailVerified: true,
+		},
+		{
+			name:       "email not verified",
+			claim:      "email_verified",
+			value:      false,
+			wantGroups: []string{"pos-cashiers"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := mp.claims()
+			claims[tt.claim] = tt.value
+
+			identity, err := p.verifyIDToken(context.Background(), signToken(t, "RS256", "rsa-1", mp.rsaKey, claims), testNonce)
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+
+			if !reflect.DeepEqual(identity.Groups, tt.wantGroups) {
+				t.Fatalf("got groups %v, want %v", identity.Groups, tt.wantGroups)
+			}
+
+			if identity.EmailVerified != tt.wantEmailVerified {
+				t.Fatalf("got email verified %v, want %v", identity.EmailVerified, tt.wantEmailVerified)
+			}
+		})
+	}
+}
+
+func TestVerifyIDTokenKeyRotation(t *testing.T) {
+	mp := newMockProvider(t)
+	p := mp.newProvider(t)
+	ctx := context.Background()
+
+	_, err := p.verifyIDToken(ctx, signToken(t, "RS256", "rsa-1", mp.rsaKey, mp.claims()), testNonce)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	mp.mu.Lock()
+	mp.keys = []jwk{rsaJWK("rsa-2", &newKey.PublicKey)}
+	mp.mu.Unlock()
+
+	token := signToken(t, "RS256", "rsa-2", newKey, mp.claims())
+
+	_, err = p.verifyIDToken(ctx, token, testNonce)
+	if !errors.Is(err, cmdomain.ErrInvalidSSOLogin) {
+		t.Fatalf("got error %v right after fetching the keys, want %v", err, cmdomain.ErrInvalidSSOLogin)
+	}
+
+	p.keysFetchedAt = time.Now().Add(-keysRefreshInterval)
+
+	_, err = p.verifyIDToken(ctx, token, testNonce)
+	if err != nil {
+		t.Fatalf("got error %v after the keys were rotated", err)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	mp := newMockProvider(t)
+	p := mp.newProvider(t)
+	ctx := context.Background()
+
+	mp.idToken = signToken(t, "ES256", "ec-1", mp.ecKey, mp.claims())
+
+	identity, err := p.Exchange(ctx, "code", "verifier", testNonce)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if identity.Subject != "user-1" {
+		t.Fatalf("got identity %+v", identity)
+	}
+
+	mp.mu.Lock()
+	codeVerifier := mp.form["code_verifier"]
+	mp.mu.Unlock()
+
+	if codeVerifier != "verifier" {
+		t.Fatalf("got code verifier %q, want %q", codeVerifier, "verifier")
+	}
+
+	_, err = p.Exchange(ctx, "other", "verifier", testNonce)
+	if !errors.Is(err, cmdomain.ErrInvalidSSOLogin) {
+		t.Fatalf("got error %v for a rejected code, want %v", err, cmdomain.ErrInvalidSSOLogin)
+	}
+
+	_, err = p.Exchange(ctx, "code", "verifier", "other")
+	if !errors.Is(err, cmdomain.ErrInvalidSSOLogin) {
+		t.Fatalf("got error %v for another nonce, want %v", err, cmdomain.ErrInvalidSSOLogin)
+	}
+}
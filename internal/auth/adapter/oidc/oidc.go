@@ -0,0 +1,226 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"go-restaurant/internal/auth/domain"
+	"go-restaurant/internal/auth/port"
+	"go-restaurant/internal/common/adapter/config"
+	cmdomain "go-restaurant/internal/common/domain"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// requestTimeout is how long a request to the identity provider may take
+	requestTimeout = 10 * time.Second
+	// defaultGroupsClaim is the ID token claim that lists the groups of a user, unless configured otherwise
+	defaultGroupsClaim = "groups"
+)
+
+// defaultScopes are the scopes requested unless configured otherwise
+var defaultScopes = []string{"openid", "email", "profile"}
+
+// discovery is the metadata an identity provider publishes at its discovery endpoint
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+/*Provider implements port.OIDCProvider interface
+ * with the authorization code flow and PKCE
+ * and provides access to an OpenID Connect identity provider
+ */
+type Provider struct {
+	client       *http.Client
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+	groupsClaim  string
+
+	discoveryMu sync.Mutex
+	discovery   *discovery
+
+	keysMu        sync.Mutex
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+// New creates a new identity provider instance, or returns nil if single sign-on is not configured.
+// The provider is discovered on first use, so the server starts while the identity provider is down
+func New(config *config.OIDC) (port.OIDCProvider, error) {
+	if config.Issuer == "" {
+		return nil, nil
+	}
+
+	if config.ClientID == "" || config.RedirectURL == "" {
+		return nil, cmdomain.ErrInvalidOIDCConfig
+	}
+
+	scopes := defaultScopes
+	if config.Scopes != "" {
+		scopes = strings.Fields(strings.ReplaceAll(config.Scopes, ",", " "))
+	}
+
+	hasOpenID := false
+	for _, scope := range scopes {
+		if scope == "openid" {
+			hasOpenID = true
+		}
+	}
+
+	if !hasOpenID {
+		scopes = append([]string{"openid"}, scopes...)
+	}
+
+	groupsClaim := config.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = defaultGroupsClaim
+	}
+
+	return &Provider{
+		client:       &http.Client{Timeout: requestTimeout},
+		issuer:       config.Issuer,
+		clientID:     config.ClientID,
+		clientSecret: config.ClientSecret,
+		redirectURL:  config.RedirectURL,
+		scopes:       scopes,
+		groupsClaim:  groupsClaim,
+	}, nil
+}
+
+// AuthCodeURL returns the authorization endpoint URL that starts a login with a S256 code challenge
+func (p *Provider) AuthCodeURL(ctx context.Context, state, codeChallenge, nonce string) (string, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.clientID)
+	query.Set("redirect_uri", p.redirectURL)
+	query.Set("scope", strings.Join(p.scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+// Exchange redeems an authorization code at the token endpoint and verifies the ID token it returns
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*domain.OIDCIdentity, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.redirectURL)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", p.clientID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.clientID), url.QueryEscape(p.clientSecret))
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusBadRequest || res.StatusCode == http.StatusUnauthorized {
+		return nil, cmdomain.ErrInvalidSSOLogin
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: token endpoint returned %s", res.Status)
+	}
+
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+
+	err = json.NewDecoder(res.Body).Decode(&token)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.IDToken == "" {
+		return nil, cmdomain.ErrInvalidSSOLogin
+	}
+
+	return p.verifyIDToken(ctx, token.IDToken, nonce)
+}
+
+// getDiscovery returns the metadata of the identity provider, fetching it on first use
+func (p *Provider) getDiscovery(ctx context.Context) (*discovery, error) {
+	p.discoveryMu.Lock()
+	defer p.discoveryMu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var d discovery
+	err := p.getJSON(ctx, strings.TrimSuffix(p.issuer, "/")+"/.well-known/openid-configuration", &d)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.Issuer != p.issuer {
+		return nil, fmt.Errorf("oidc: discovered issuer %q does not match %q", d.Issuer, p.issuer)
+	}
+
+	p.discovery = &d
+
+	return p.discovery, nil
+}
+
+// getJSON fetches a JSON document from the identity provider
+func (p *Provider) getJSON(ctx context.Context, url string, output any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("oidc: %s returned %s", url, res.Status)
+	}
+
+	return json.NewDecoder(res.Body).Decode(output)
+}
@@ -1,28 +1,44 @@
 package paseto
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"github.com/o1egl/paseto"
 	"go-restaurant/internal/auth/domain"
 	"go-restaurant/internal/auth/port"
 	"go-restaurant/internal/common/adapter/config"
 	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
 	udomain "go-restaurant/internal/user/domain"
 	"golang.org/x/crypto/chacha20poly1305"
 	"time"
 )
 
 /*Token implements port.TokenService interface
+ * with v2.local tokens encrypted with a symmetric key
  * and provides access to the paseto library
  */
 type Token struct {
-	paseto       *paseto.V2
-	symmetricKey []byte
-	duration     time.Duration
+	paseto           *paseto.V2
+	symmetricKey     []byte
+	duration         time.Duration
+	terminalDuration time.Duration
 }
 
-// New creates a new paseto instance
-func New(config *config.Token) (port.TokenService, error) {
+// New creates a new paseto instance of the token type selected by the config
+func New(ctx context.Context, config *config.Token, cache cmport.CacheRepository) (port.TokenService, error) {
+	switch config.Type {
+	case "", "local":
+		return newLocal(config)
+	case "public":
+		return newPublic(ctx, config, cache)
+	default:
+		return nil, cmdomain.ErrInvalidTokenType
+	}
+}
+
+// newLocal creates a new paseto instance with v2.local tokens
+func newLocal(config *config.Token) (port.TokenService, error) {
 	symmetricKey := config.SymmetricKey
 	durationStr := config.Duration
 
@@ -36,26 +52,43 @@ func New(config *config.Token) (port.TokenService, error) {
 		return nil, err
 	}
 
+	terminalDuration, err := time.ParseDuration(config.TerminalDuration)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Token{
 		paseto.NewV2(),
 		[]byte(symmetricKey),
 		duration,
+		terminalDuration,
 	}, nil
 }
 
 // CreateToken creates a new paseto token
 func (pt *Token) CreateToken(user *udomain.User) (string, error) {
+	return pt.createToken(user, 0, pt.duration)
+}
+
+// CreateTerminalToken creates a new paseto token bound to a terminal, which expires sooner
+func (pt *Token) CreateTerminalToken(user *udomain.User, terminalID uint64) (string, error) {
+	return pt.createToken(user, terminalID, pt.terminalDuration)
+}
+
+// createToken creates a new paseto token that expires after the given duration
+func (pt *Token) createToken(user *udomain.User, terminalID uint64, duration time.Duration) (string, error) {
 	id, err := uuid.NewRandom()
 	if err != nil {
 		return "", cmdomain.ErrTokenCreation
 	}
 
 	payload := domain.TokenPayload{
-		ID:        id,
-		UserID:    user.ID,
-		Role:      user.Role,
-		IssuedAt:  time.Now(),
-		ExpiredAt: time.Now().Add(pt.duration),
+		ID:         id,
+		UserID:     user.ID,
+		Role:       user.Role,
+		TerminalID: terminalID,
+		IssuedAt:   time.Now(),
+		ExpiredAt:  time.Now().Add(duration),
 	}
 
 	token, err := pt.paseto.Encrypt(pt.symmetricKey, payload, nil)
@@ -82,3 +115,8 @@ func (pt *Token) VerifyToken(token string) (*domain.TokenPayload, error) {
 
 	return &payload, nil
 }
+
+// ListPublicKeys returns no keys, since v2.local tokens can only be verified with the symmetric key
+func (pt *Token) ListPublicKeys() []domain.PublicKey {
+	return nil
+}
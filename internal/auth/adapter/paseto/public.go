@@ -0,0 +1,427 @@
+package paseto
+
+import (
+	pasetov4 "aidanwoods.dev/go-paseto"
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"go-restaurant/internal/auth/domain"
+	"go-restaurant/internal/auth/port"
+	"go-restaurant/internal/common/adapter/config"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
+	udomain "go-restaurant/internal/user/domain"
+	"log/slog"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// keysCacheKey is the cache key of the signing key schedule shared by every instance of the service
+	keysCacheKey = "token_keys"
+	// minSigningSecretSize is the minimum size of the secret the signing keys are derived from
+	minSigningSecretSize = 32
+	// keySyncInterval is how often the signing keys are synchronized with the cache and rotated.
+	// A new key is published two intervals before it signs tokens, so every instance knows it by then
+	keySyncInterval = time.Minute
+)
+
+// signingKey is a private key that signs tokens from ActiveAt until a newer key becomes active.
+// Only its ID and schedule are shared through the cache, every instance derives the private key
+// from the signing secret and the ID
+type signingKey struct {
+	ID         string
+	PrivateKey ed25519.PrivateKey `json:"-"`
+	CreatedAt  time.Time
+	ActiveAt   time.Time
+}
+
+// tokenFooter is the footer of a token, which tells verifiers which key signed it
+type tokenFooter struct {
+	KeyID string `json:"kid"`
+}
+
+/*PublicToken implements port.TokenService interface
+ * with v4.public tokens signed with rotating private keys
+ * and provides access to the paseto library
+ */
+type PublicToken struct {
+	cache            cmport.CacheRepository
+	secret           []byte
+	duration         time.Duration
+	terminalDuration time.Duration
+	rotation         time.Duration
+	mu               sync.RWMutex
+	keys             []signingKey
+}
+
+// newPublic creates a new paseto instance with v4.public tokens and starts rotating its keys
+func newPublic(ctx context.Context, config *config.Token, cache cmport.CacheRepository) (port.TokenService, error) {
+	duration, err := time.ParseDuration(config.Duration)
+	if err != nil {
+		return nil, err
+	}
+
+	terminalDuration, err := time.ParseDuration(config.TerminalDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	rotation, err := time.ParseDuration(config.RotationInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	if rotation < keySyncInterval {
+		return nil, cmdomain.ErrInvalidTokenRotation
+	}
+
+	if len(config.SigningSecret) < minSigningSecretSize {
+		return nil, cmdomain.ErrInvalidTokenSigningSecret
+	}
+
+	pt := &PublicToken{
+		cache:            cache,
+		secret:           []byte(config.SigningSecret),
+		duration:         duration,
+		terminalDuration: terminalDuration,
+		rotation:         rotation,
+	}
+
+	err = pt.syncKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	go pt.rotate(ctx)
+
+	return pt, nil
+}
+
+// CreateToken creates a new paseto token signed with the active key
+func (pt *PublicToken) CreateToken(user *udomain.User) (string, error) {
+	return pt.createToken(user, 0, pt.duration)
+}
+
+// CreateTerminalToken creates a new paseto token bound to a terminal, which expires sooner
+func (pt *PublicToken) CreateTerminalToken(user *udomain.User, terminalID uint64) (string, error) {
+	return pt.createToken(user, terminalID, pt.terminalDuration)
+}
+
+// createToken creates a new paseto token signed with the active key that expires after the given duration
+func (pt *PublicToken) createToken(user *udomain.User, terminalID uint64, duration time.Duration) (string, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", cmdomain.ErrTokenCreation
+	}
+
+	key, ok := pt.activeKey()
+	if !ok {
+		return "", cmdomain.ErrTokenCreation
+	}
+
+	secretKey, err := pasetov4.NewV4AsymmetricSecretKeyFromEd25519(key.PrivateKey)
+	if err != nil {
+		return "", cmdomain.ErrTokenCreation
+	}
+
+	footer, err := json.Marshal(tokenFooter{key.ID})
+	if err != nil {
+		return "", cmdomain.ErrTokenCreation
+	}
+
+	now := time.Now()
+
+	token := pasetov4.NewToken()
+	token.SetJti(id.String())
+	token.SetSubject(strconv.FormatUint(user.ID, 10))
+	token.SetString("role", string(user.Role))
+	token.SetIssuedAt(now)
+	token.SetExpiration(now.Add(duration))
+	token.SetFooter(footer)
+
+	if terminalID != 0 {
+		token.SetString("terminal_id", strconv.FormatUint(terminalID, 10))
+	}
+
+	return token.V4Sign(secretKey, nil), nil
+}
+
+// VerifyToken verifies the paseto token with the key named in its footer
+func (pt *PublicToken) VerifyToken(token string) (*domain.TokenPayload, error) {
+	parser := pasetov4.NewParserWithoutExpiryCheck()
+
+	footer, err := parser.UnsafeParseFooter(pasetov4.V4Public, token)
+	if err != nil {
+		return nil, cmdomain.ErrInvalidToken
+	}
+
+	var tf tokenFooter
+	err = json.Unmarshal(footer, &tf)
+	if err != nil {
+		return nil, cmdomain.ErrInvalidToken
+	}
+
+	key, ok := pt.findKey(tf.KeyID)
+	if !ok {
+		return nil, cmdomain.ErrInvalidToken
+	}
+
+	publicKey, err := pasetov4.NewV4AsymmetricPublicKeyFromEd25519(key.PrivateKey.Public().(ed25519.PublicKey))
+	if err != nil {
+		return nil, cmdomain.ErrInvalidToken
+	}
+
+	parsed, err := parser.ParseV4Public(publicKey, token, nil)
+	if err != nil {
+		return nil, cmdomain.ErrInvalidToken
+	}
+
+	payload, err := newTokenPayload(parsed)
+	if err != nil {
+		return nil, cmdomain.ErrInvalidToken
+	}
+
+	isExpired := time.Now().After(payload.ExpiredAt)
+	if isExpired {
+		return nil, cmdomain.ErrExpiredToken
+	}
+
+	return payload, nil
+}
+
+// ListPublicKeys lists the public keys of every key that signs or will sign tokens,
+// or signed tokens that have not expired yet
+func (pt *PublicToken) ListPublicKeys() []domain.PublicKey {
+	pt.mu.RLock()
+	defer pt.mu.RUnlock()
+
+	var keys []domain.PublicKey
+
+	for _, key := range pt.keys {
+		keys = append(keys, domain.PublicKey{
+			ID:       key.ID,
+			Key:      key.PrivateKey.Public().(ed25519.PublicKey),
+			ActiveAt: key.ActiveAt,
+		})
+	}
+
+	return keys
+}
+
+// rotate synchronizes and rotates the signing keys on a schedule until the context is done
+func (pt *PublicToken) rotate(ctx context.Context) {
+	ticker := time.NewTicker(keySyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := pt.syncKeys(ctx)
+			if err != nil {
+				slog.Error("Error rotating token keys", "error", err)
+			}
+		}
+	}
+}
+
+// syncKeys merges the local signing keys with the ones other instances stored in the cache,
+// drops the keys that can no longer have signed an unexpired token and creates a new key
+// when the newest one is due for rotation
+func (pt *PublicToken) syncKeys(ctx context.Context) error {
+	merged := make(map[string]signingKey)
+
+	cachedKeys, err := pt.cache.Get(ctx, keysCacheKey)
+	if err == nil {
+		var keys []signingKey
+		err = json.Unmarshal(cachedKeys, &keys)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			key.PrivateKey = pt.deriveKey(key.ID)
+			merged[key.ID] = key
+		}
+	} else if !errors.Is(err, cmdomain.ErrDataNotFound) {
+		return err
+	}
+
+	pt.mu.RLock()
+	for _, key := range pt.keys {
+		merged[key.ID] = key
+	}
+	pt.mu.RUnlock()
+
+	sorted := make([]signingKey, 0, len(merged))
+	for _, key := range merged {
+		sorted = append(sorted, key)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ActiveAt.Before(sorted[j].ActiveAt)
+	})
+
+	now := time.Now()
+
+	var keys []signingKey
+
+	for i, key := range sorted {
+		isLast := i == len(sorted)-1
+		if !isLast {
+			retiredAt := sorted[i+1].ActiveAt
+			if now.After(retiredAt.Add(pt.duration)) {
+				continue
+			}
+		}
+
+		keys = append(keys, key)
+	}
+
+	needsRotation := len(keys) == 0 || now.After(keys[len(keys)-1].CreatedAt.Add(pt.rotation))
+	if needsRotation {
+		activeAt := now.Add(2 * keySyncInterval)
+		if len(keys) == 0 {
+			activeAt = now
+		}
+
+		keys = append(keys, pt.newSigningKey(now, activeAt))
+	}
+
+	keysSerialized, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+
+	err = pt.cache.Set(ctx, keysCacheKey, keysSerialized, 0)
+	if err != nil {
+		return err
+	}
+
+	pt.mu.Lock()
+	pt.keys = keys
+	pt.mu.Unlock()
+
+	return nil
+}
+
+// activeKey returns the newest key that is allowed to sign tokens
+func (pt *PublicToken) activeKey() (signingKey, bool) {
+	pt.mu.RLock()
+	defer pt.mu.RUnlock()
+
+	now := time.Now()
+
+	for i := len(pt.keys) - 1; i >= 0; i-- {
+		if !pt.keys[i].ActiveAt.After(now) {
+			return pt.keys[i], true
+		}
+	}
+
+	return signingKey{}, false
+}
+
+// findKey returns the key with the given ID
+func (pt *PublicToken) findKey(id string) (signingKey, bool) {
+	pt.mu.RLock()
+	defer pt.mu.RUnlock()
+
+	for _, key := range pt.keys {
+		if key.ID == id {
+			return key, true
+		}
+	}
+
+	return signingKey{}, false
+}
+
+// newSigningKey creates a new signing key with a random ID
+func (pt *PublicToken) newSigningKey(createdAt, activeAt time.Time) signingKey {
+	id := uuid.NewString()
+
+	return signingKey{
+		ID:         id,
+		PrivateKey: pt.deriveKey(id),
+		CreatedAt:  createdAt,
+		ActiveAt:   activeAt,
+	}
+}
+
+// deriveKey derives the private key with the given ID from the signing secret,
+// so a key can only be used by the instances that are configured with the secret
+func (pt *PublicToken) deriveKey(id string) ed25519.PrivateKey {
+	mac := hmac.New(sha256.New, pt.secret)
+	mac.Write([]byte(id))
+
+	return ed25519.NewKeyFromSeed(mac.Sum(nil))
+}
+
+// newTokenPayload converts the claims of a verified token to a token payload
+func newTokenPayload(token *pasetov4.Token) (*domain.TokenPayload, error) {
+	jti, err := token.GetJti()
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(jti)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := token.GetSubject()
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := strconv.ParseUint(subject, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := token.GetString("role")
+	if err != nil {
+		return nil, err
+	}
+
+	issuedAt, err := token.GetIssuedAt()
+	if err != nil {
+		return nil, err
+	}
+
+	expiredAt, err := token.GetExpiration()
+	if err != nil {
+		return nil, err
+	}
+
+	var terminalID uint64
+
+	_, isTerminalToken := token.Claims()["terminal_id"]
+	if isTerminalToken {
+		terminal, err := token.GetString("terminal_id")
+		if err != nil {
+			return nil, err
+		}
+
+		terminalID, err = strconv.ParseUint(terminal, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.TokenPayload{
+		ID:         id,
+		UserID:     userID,
+		Role:       udomain.UserRole(role),
+		TerminalID: terminalID,
+		IssuedAt:   issuedAt,
+		ExpiredAt:  expiredAt,
+	}, nil
+}
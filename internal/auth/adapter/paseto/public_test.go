@@ -0,0 +1,289 @@
+package paseto
+
+import (
+	"context"
+	"errors"
+	"go-restaurant/internal/common/adapter/config"
+	cmdomain "go-restaurant/internal/common/domain"
+	udomain "go-restaurant/internal/user/domain"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryCache is an in-memory cmport.CacheRepository that ignores expiry
+type memoryCache struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{values: make(map[string][]byte)}
+}
+
+func (mc *memoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	mc.values[key] = append([]byte(nil), value...)
+	return nil
+}
+
+func (mc *memoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	value, ok := mc.values[key]
+	if !ok {
+		return nil, cmdomain.ErrDataNotFound
+	}
+	return value, nil
+}
+
+func (mc *memoryCache) GetAndDelete(ctx context.Context, key string) ([]byte, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	value, ok := mc.values[key]
+	if !ok {
+		return nil, cmdomain.ErrDataNotFound
+	}
+	delete(mc.values, key)
+	return value, nil
+}
+
+func (mc *memoryCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (mc *memoryCache) Delete(ctx context.Context, key string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	delete(mc.values, key)
+	return nil
+}
+
+func (mc *memoryCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	for key := range mc.values {
+		if strings.HasPrefix(key, strings.TrimSuffix(prefix, "*")) {
+			delete(mc.values, key)
+		}
+	}
+	return nil
+}
+
+func (mc *memoryCache) Close() error {
+	return nil
+}
+
+const testSigningSecret = "0123456789abcdef0123456789abcdef"
+
+func newTestConfig(secret string) *config.Token {
+	return &config.Token{
+		Type:             "public",
+		SigningSecret:    secret,
+		Duration:         "1h",
+		TerminalDuration: "10m",
+		RotationInterval: "24h",
+	}
+}
+
+func newTestPublicToken(t *testing.T, config *config.Token, cache *memoryCache) *PublicToken {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ts, err := newPublic(ctx, config, cache)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	return ts.(*PublicToken)
+}
+
+func TestNewPublicInvalidConfig(t *testing.T) {
+	shortRotation := newTestConfig(testSigningSecret)
+	shortRotation.RotationInterval = "30s"
+
+	tests := []struct {
+		name    string
+		config  *config.Token
+		wantErr error
+	}{
+		{
+			name:    "rotation shorter than the sync interval",
+			config:  shortRotation,
+			wantErr: cmdomain.ErrInvalidTokenRotation,
+		},
+		{
+			name:    "short signing secret",
+			config:  newTestConfig("secret"),
+			wantErr: cmdomain.ErrInvalidTokenSigningSecret,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newPublic(context.Background(), tt.config, newMemoryCache())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPublicTokenVerify(t *testing.T) {
+	pt := newTestPublicToken(t, newTestConfig(testSigningSecret), newMemoryCache())
+	user := &udomain.User{ID: 7, Role: udomain.Cashier}
+
+	token, err := pt.CreateToken(user)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	payload, err := pt.VerifyToken(token)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if payload.UserID != user.ID || payload.Role != user.Role || payload.TerminalID != 0 {
+		t.Fatalf("got payload %+v", payload)
+	}
+
+	terminalToken, err := pt.CreateTerminalToken(user, 3)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	payload, err = pt.VerifyToken(terminalToken)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if payload.TerminalID != 3 {
+		t.Fatalf("got terminal %d, want 3", payload.TerminalID)
+	}
+
+	parts := strings.Split(token, ".")
+	body := []byte(parts[2])
+	body[len(body)/2] ^= 1
+	parts[2] = string(body)
+
+	_, err = pt.VerifyToken(strings.Join(parts, "."))
+	if !errors.Is(err, cmdomain.ErrInvalidToken) {
+		t.Fatalf("got error %v for a tampered token, want %v", err, cmdomain.ErrInvalidToken)
+	}
+}
+
+func TestPublicTokenRotation(t *testing.T) {
+	ctx := context.Background()
+	pt := newTestPublicToken(t, newTestConfig(testSigningSecret), newMemoryCache())
+	user := &udomain.User{ID: 7, Role: udomain.Cashier}
+
+	if len(pt.keys) != 1 {
+		t.Fatalf("got %d keys, want 1", len(pt.keys))
+	}
+
+	oldKey := pt.keys[0]
+	oldToken, err := pt.CreateToken(user)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	pt.keys[0].CreatedAt = time.Now().Add(-pt.rotation - time.Minute)
+
+	err = pt.syncKeys(ctx)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if len(pt.keys) != 2 {
+		t.Fatalf("got %d keys after rotating, want 2", len(pt.keys))
+	}
+
+	newKey := pt.keys[1]
+	if !newKey.ActiveAt.After(time.Now()) {
+		t.Fatal("new key signs tokens before every instance knows it")
+	}
+
+	activeKey, _ := pt.activeKey()
+	if activeKey.ID != oldKey.ID {
+		t.Fatal("new key signs tokens before it is active")
+	}
+
+	pt.keys[1].ActiveAt = time.Now().Add(-time.Second)
+
+	activeKey, _ = pt.activeKey()
+	if activeKey.ID != newKey.ID {
+		t.Fatal("new key does not sign tokens once it is active")
+	}
+
+	newToken, err := pt.CreateToken(user)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	for _, token := range []string{oldToken, newToken} {
+		_, err = pt.VerifyToken(token)
+		if err != nil {
+			t.Fatalf("got error %v while both keys are kept", err)
+		}
+	}
+
+	pt.keys[0].ActiveAt = time.Now().Add(-2*pt.duration - time.Minute)
+	pt.keys[1].ActiveAt = time.Now().Add(-pt.duration - time.Minute)
+
+	err = pt.syncKeys(ctx)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if len(pt.keys) != 1 || pt.keys[0].ID != newKey.ID {
+		t.Fatalf("got %d keys, want only the new key once the old one cannot have signed an unexpired token", len(pt.keys))
+	}
+
+	_, err = pt.VerifyToken(oldToken)
+	if !errors.Is(err, cmdomain.ErrInvalidToken) {
+		t.Fatalf("got error %v for a token of a dropped key, want %v", err, cmdomain.ErrInvalidToken)
+	}
+
+	_, err = pt.VerifyToken(newToken)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+}
+
+func TestPublicTokenSharedKeys(t *testing.T) {
+	cache := newMemoryCache()
+	user := &udomain.User{ID: 7, Role: udomain.Cashier}
+
+	signer := newTestPublicToken(t, newTestConfig(testSigningSecret), cache)
+	verifier := newTestPublicToken(t, newTestConfig(testSigningSecret), cache)
+
+	if len(verifier.keys) != 1 || verifier.keys[0].ID != signer.keys[0].ID {
+		t.Fatal("second instance did not pick up the key of the first one")
+	}
+
+	token, err := signer.CreateToken(user)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	_, err = verifier.VerifyToken(token)
+	if err != nil {
+		t.Fatalf("got error %v on another instance with the same secret", err)
+	}
+
+	other := newTestPublicToken(t, newTestConfig(strings.Repeat("x", minSigningSecretSize)), cache)
+
+	_, err = other.VerifyToken(token)
+	if !errors.Is(err, cmdomain.ErrInvalidToken) {
+		t.Fatalf("got error %v on an instance with another secret, want %v", err, cmdomain.ErrInvalidToken)
+	}
+}
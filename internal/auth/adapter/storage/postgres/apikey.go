@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-restaurant/internal/auth/domain"
+	"go-restaurant/internal/common/adapter/storage/postgres"
+	cmdomain "go-restaurant/internal/common/domain"
+	rdomain "go-restaurant/internal/role/domain"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// apiKeyTouchInterval is how often the last use of an API key is written to the database
+const apiKeyTouchInterval = time.Minute
+
+/*APIKeyRepository implements port.APIKeyRepository interface
+ * and provides access to the postgres database
+ */
+type APIKeyRepository struct {
+	db *postgres.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository instance
+func NewAPIKeyRepository(db *postgres.DB) *APIKeyRepository {
+	return &APIKeyRepository{
+		db,
+	}
+}
+
+// selectAPIKeys builds a query that selects API keys with the current role of their user.
+// Keys of deleted users are left out, so they stop working with the user
+func (ar *APIKeyRepository) selectAPIKeys() sq.SelectBuilder {
+	return ar.db.QueryBuilder.Select(
+		"k.id",
+		"k.name",
+		"k.prefix",
+		"k.key_hash",
+		"k.user_id",
+		"u.role",
+		"k.scopes",
+		"k.expires_at",
+		"k.last_used_at",
+		"k.created_at",
+	).
+		From("api_keys k").
+		Join("users u ON u.id = k.user_id").
+		Where(sq.Eq{"u.deleted_at": nil})
+}
+
+// scanAPIKey scans a row selected by selectAPIKeys into an API key
+func scanAPIKey(row pgx.Row, key *domain.APIKey) error {
+	var scopes []string
+
+	err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		&key.UserID,
+		&key.Role,
+		&scopes,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	key.Scopes = make([]rdomain.Permission, 0, len(scopes))
+	for _, scope := range scopes {
+		key.Scopes = append(key.Scopes, rdomain.Permission(scope))
+	}
+
+	return nil
+}
+
+// CreateAPIKey creates a new API key record in the database
+func (ar *APIKeyRepository) CreateAPIKey(ctx context.Context, key *domain.APIKey) (*domain.APIKey, error) {
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	query := ar.db.QueryBuilder.Insert("api_keys").
+		Columns("name", "prefix", "key_hash", "user_id", "scopes", "expires_at").
+		Values(key.Name, key.Prefix, key.KeyHash, key.UserID, scopes, key.ExpiresAt).
+		Suffix("RETURNING id")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ar.db.QueryRow(ctx, sql, args...).Scan(&key.ID)
+	if err != nil {
+		if errCode := ar.db.ErrorCode(err); errCode == "23503" {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return ar.GetAPIKeyByID(ctx, key.ID)
+}
+
+// GetAPIKeyByID retrieves an API key record from the database by id
+func (ar *APIKeyRepository) GetAPIKeyByID(ctx context.Context, id uint64) (*domain.APIKey, error) {
+	return ar.getAPIKey(ctx, sq.Eq{"k.id": id})
+}
+
+// GetAPIKeyByPrefix retrieves an API key record from the database by its prefix
+func (ar *APIKeyRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	return ar.getAPIKey(ctx, sq.Eq{"k.prefix": prefix})
+}
+
+// getAPIKey retrieves the API key record matching a condition from the database
+func (ar *APIKeyRepository) getAPIKey(ctx context.Context, where sq.Eq) (*domain.APIKey, error) {
+	var key domain.APIKey
+
+	query := ar.selectAPIKeys().
+		Where(where).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanAPIKey(ar.db.QueryRow(ctx, sql, args...), &key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// ListAPIKeys retrieves a list of API keys from the database
+func (ar *APIKeyRepository) ListAPIKeys(ctx context.Context, skip, limit uint64) ([]domain.APIKey, error) {
+	var key domain.APIKey
+	var keys []domain.APIKey
+
+	query := ar.selectAPIKeys().
+		OrderBy("k.id").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ar.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		err := scanAPIKey(rows, &key)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// TouchAPIKey sets the last use of an API key to now, at most once per interval
+// so a busy integration does not write on every request
+func (ar *APIKeyRepository) TouchAPIKey(ctx context.Context, id uint64) error {
+	now := time.Now()
+
+	query := ar.db.QueryBuilder.Update("api_keys").
+		Set("last_used_at", now).
+		Where(sq.Eq{"id": id}).
+		Where(sq.Or{
+			sq.Eq{"last_used_at": nil},
+			sq.Lt{"last_used_at": now.Add(-apiKeyTouchInterval)},
+		})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = ar.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAPIKey deletes an API key record from the database by id
+func (ar *APIKeyRepository) DeleteAPIKey(ctx context.Context, id uint64) error {
+	query := ar.db.QueryBuilder.Delete("api_keys").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = ar.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-restaurant/internal/auth/domain"
+	"go-restaurant/internal/common/adapter/storage/postgres"
+	cmdomain "go-restaurant/internal/common/domain"
+	udomain "go-restaurant/internal/user/domain"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/*IdentityRepository implements port.IdentityRepository interface
+ * and provides access to the postgres database
+ */
+type IdentityRepository struct {
+	db *postgres.DB
+}
+
+// NewIdentityRepository creates a new identity repository instance
+func NewIdentityRepository(db *postgres.DB) *IdentityRepository {
+	return &IdentityRepository{
+		db,
+	}
+}
+
+// GetUserIdentity retrieves the user identity record of an identity provider account from the database
+func (ir *IdentityRepository) GetUserIdentity(ctx context.Context, issuer, subject string) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+
+	query := ir.db.QueryBuilder.Select("*").
+		From("user_identities").
+		Where(sq.Eq{"issuer": issuer, "subject": subject}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ir.db.QueryRow(ctx, sql, args...).Scan(
+		&identity.ID,
+		&identity.Issuer,
+		&identity.Subject,
+		&identity.UserID,
+		&identity.CreatedAt,
+		&identity.Provisioned,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+// CreateUserIdentity creates a new user identity record in the database
+func (ir *IdentityRepository) CreateUserIdentity(ctx context.Context, identity *domain.UserIdentity) (*domain.UserIdentity, error) {
+	query := ir.db.QueryBuilder.Insert("user_identities").
+		Columns("issuer", "subject", "user_id").
+		Values(identity.Issuer, identity.Subject, identity.UserID).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ir.db.QueryRow(ctx, sql, args...).Scan(
+		&identity.ID,
+		&identity.Issuer,
+		&identity.Subject,
+		&identity.UserID,
+		&identity.CreatedAt,
+		&identity.Provisioned,
+	)
+	if err != nil {
+		if errCode := ir.db.ErrorCode(err); errCode == "23505" {
+			return nil, cmdomain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+// ProvisionUser creates a new user and the user identity record linking it to an identity provider account
+// in the database, inside a transaction so a user is never left without the account that signs them in
+func (ir *IdentityRepository) ProvisionUser(ctx context.Context, identity *domain.UserIdentity, user *udomain.User) (*udomain.User, error) {
+	tx, err := ir.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	userQuery := ir.db.QueryBuilder.Insert("users").
+		Columns("name", "email", "password", "role").
+		Values(user.Name, user.Email, user.Password, user.Role).
+		Suffix("RETURNING *")
+
+	sql, args, err := userQuery.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.Password,
+		&user.Role,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.PIN,
+		&user.DeletedAt,
+	)
+	if err != nil {
+		if errCode := ir.db.ErrorCode(err); errCode == "23505" {
+			return nil, cmdomain.ErrConflictingData
+		} else if errCode == "23503" {
+			return nil, cmdomain.ErrInvalidRole
+		}
+		return nil, err
+	}
+
+	identityQuery := ir.db.QueryBuilder.Insert("user_identities").
+		Columns("issuer", "subject", "user_id", "provisioned").
+		Values(identity.Issuer, identity.Subject, user.ID, identity.Provisioned)
+
+	sql, args, err = identityQuery.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		if errCode := ir.db.ErrorCode(err); errCode == "23505" {
+			return nil, cmdomain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
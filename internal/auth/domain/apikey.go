@@ -0,0 +1,22 @@
+package domain
+
+import (
+	rdomain "go-restaurant/internal/role/domain"
+	udomain "go-restaurant/internal/user/domain"
+	"time"
+)
+
+// APIKey is an entity that represents a key an integration authenticates with instead of logging in.
+// It acts as its user, limited to its scopes. Role is the current role of the user
+type APIKey struct {
+	ID         uint64
+	Name       string
+	Prefix     string
+	KeyHash    string
+	UserID     uint64
+	Role       udomain.UserRole
+	Scopes     []rdomain.Permission
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
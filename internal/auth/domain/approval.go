@@ -0,0 +1,25 @@
+package domain
+
+import (
+	rdomain "go-restaurant/internal/role/domain"
+	"time"
+)
+
+// ApprovalRequest is an entity that represents the approval a sensitive request carries,
+// either the ID and PIN of the approving user or an approval token they created
+type ApprovalRequest struct {
+	ApproverID uint64
+	PIN        string
+	Token      string
+}
+
+// Approval is an entity that represents a user allowing another user to perform an action
+// that requires a permission. TargetID is the ID of the record the action applies to,
+// or 0 for an action that creates a new record
+type Approval struct {
+	ApproverID  uint64
+	RequesterID uint64
+	Permission  rdomain.Permission
+	TargetID    uint64
+	ApprovedAt  time.Time
+}
@@ -0,0 +1,7 @@
+package domain
+
+// AuthToken is an entity that represents the pair of tokens given to an authenticated user
+type AuthToken struct {
+	AccessToken  string
+	RefreshToken string
+}
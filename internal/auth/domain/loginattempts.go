@@ -0,0 +1,11 @@
+package domain
+
+import (
+	"time"
+)
+
+// LoginAttempts is an entity that represents the failed logins for an email or from a client IP
+type LoginAttempts struct {
+	Count        int
+	LastFailedAt time.Time
+}
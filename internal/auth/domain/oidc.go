@@ -0,0 +1,84 @@
+package domain
+
+import (
+	cmdomain "go-restaurant/internal/common/domain"
+	udomain "go-restaurant/internal/user/domain"
+	"strings"
+	"time"
+)
+
+// OIDCIdentity is an entity that represents a user as verified by the identity provider
+type OIDCIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Groups        []string
+}
+
+// OIDCLogin is an entity that represents a single sign-on login in progress,
+// kept until the identity provider redirects the user back
+type OIDCLogin struct {
+	CodeVerifier string
+	Nonce        string
+}
+
+// UserIdentity is an entity that represents the link between a user and their account at an identity provider.
+// Provisioned is set when the user was created for the account, rather than linked to an existing user
+type UserIdentity struct {
+	ID          uint64
+	Issuer      string
+	Subject     string
+	UserID      uint64
+	CreatedAt   time.Time
+	Provisioned bool
+}
+
+// OIDCGroupRole is an entity that represents the local role given to members of an identity provider group
+type OIDCGroupRole struct {
+	Group string
+	Role  udomain.UserRole
+}
+
+// OIDCRoleMapping is a list of identity provider groups and their local roles, in order of precedence
+type OIDCRoleMapping []OIDCGroupRole
+
+// NewOIDCRoleMapping parses the configured role mapping, a comma separated list of group=role pairs
+func NewOIDCRoleMapping(mapping string) (OIDCRoleMapping, error) {
+	var roles OIDCRoleMapping
+
+	for _, pair := range strings.Split(mapping, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		group, role, ok := strings.Cut(pair, "=")
+		group = strings.TrimSpace(group)
+		role = strings.TrimSpace(role)
+		if !ok || group == "" || role == "" {
+			return nil, cmdomain.ErrInvalidOIDCRoleMapping
+		}
+
+		roles = append(roles, OIDCGroupRole{
+			Group: group,
+			Role:  udomain.UserRole(role),
+		})
+	}
+
+	return roles, nil
+}
+
+// Role returns the role of the first mapped group the user is a member of
+func (m OIDCRoleMapping) Role(groups []string) (udomain.UserRole, bool) {
+	for _, groupRole := range m {
+		for _, group := range groups {
+			if group == groupRole.Group {
+				return groupRole.Role, true
+			}
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"crypto/ed25519"
+	"time"
+)
+
+// PublicKey is an entity that represents a public key used to verify tokens
+type PublicKey struct {
+	ID       string
+	Key      ed25519.PublicKey
+	ActiveAt time.Time
+}
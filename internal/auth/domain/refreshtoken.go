@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"time"
+)
+
+// RefreshToken is an entity that represents a refresh token stored on the server
+type RefreshToken struct {
+	UserID    uint64
+	IssuedAt  time.Time
+	ExpiredAt time.Time
+}
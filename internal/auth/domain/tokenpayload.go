@@ -2,15 +2,37 @@ package domain
 
 import (
 	"github.com/google/uuid"
+	rdomain "go-restaurant/internal/role/domain"
 	udomain "go-restaurant/internal/user/domain"
 	"time"
 )
 
-// TokenPayload is an entity that represents the payload of the token
+// TokenPayload is an entity that represents the payload of the token.
+// TerminalID is only set for tokens given by a PIN login on a registered terminal,
+// and APIKeyID and Scopes are only set for requests authenticated with an API key
 type TokenPayload struct {
-	ID        uuid.UUID
-	UserID    uint64
-	Role      udomain.UserRole
-	IssuedAt  time.Time
-	ExpiredAt time.Time
+	ID         uuid.UUID
+	UserID     uint64
+	Role       udomain.UserRole
+	TerminalID uint64
+	APIKeyID   uint64
+	Scopes     []rdomain.Permission
+	IssuedAt   time.Time
+	ExpiredAt  time.Time
+}
+
+// HasScope checks if the payload is allowed to use a permission its role has.
+// Only API keys are limited to their scopes
+func (tp *TokenPayload) HasScope(permission rdomain.Permission) bool {
+	if tp.APIKeyID == 0 {
+		return true
+	}
+
+	for _, scope := range tp.Scopes {
+		if scope == permission {
+			return true
+		}
+	}
+
+	return false
 }
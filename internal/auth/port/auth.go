@@ -3,6 +3,7 @@ package port
 import (
 	"context"
 	"go-restaurant/internal/auth/domain"
+	rdomain "go-restaurant/internal/role/domain"
 	udomain "go-restaurant/internal/user/domain"
 )
 
@@ -12,12 +13,106 @@ import (
 type TokenService interface {
 	// CreateToken creates a new token for a given user
 	CreateToken(user *udomain.User) (string, error)
+	// CreateTerminalToken creates a new short-lived token for a given user bound to a terminal
+	CreateTerminalToken(user *udomain.User, terminalID uint64) (string, error)
 	// VerifyToken verifies the token and returns the payload
 	VerifyToken(token string) (*domain.TokenPayload, error)
+	// ListPublicKeys lists the public keys that verify tokens, if tokens are signed
+	ListPublicKeys() []domain.PublicKey
 }
 
 // AuthService is an interface for interacting with user authentication-related business logic
 type AuthService interface {
-	// Login authenticates a user by email and password and returns a token
-	Login(ctx context.Context, email, password string) (string, error)
+	// Login authenticates a user by email and password and returns a token pair,
+	// limiting failed logins per email and per client IP
+	Login(ctx context.Context, email, password, clientIP string) (*domain.AuthToken, error)
+	// PINLogin authenticates a user by PIN on a registered terminal and returns a short-lived access token
+	PINLogin(ctx context.Context, terminalKey string, userID uint64, pin string) (*domain.AuthToken, error)
+	// Refresh exchanges a refresh token for a new token pair
+	Refresh(ctx context.Context, refreshToken string) (*domain.AuthToken, error)
+	// Logout revokes the access token and its refresh token
+	Logout(ctx context.Context, payload *domain.TokenPayload, refreshToken string) error
+	// VerifyToken verifies the access token and checks that it has not been revoked,
+	// and that a token bound to a terminal is sent with the key of that terminal
+	VerifyToken(ctx context.Context, token, terminalKey string) (*domain.TokenPayload, error)
+	// VerifyAPIKey verifies the API key and returns a payload acting as its user
+	VerifyAPIKey(ctx context.Context, key string) (*domain.TokenPayload, error)
+	// RevokeUserTokens revokes every token issued to a user so far
+	RevokeUserTokens(ctx context.Context, userID uint64) error
+	// UnlockUser unlocks a user locked out after too many failed logins or PIN attempts
+	UnlockUser(ctx context.Context, userID uint64) error
+	// ListPublicKeys lists the public keys that verify access tokens
+	ListPublicKeys(ctx context.Context) ([]domain.PublicKey, error)
+}
+
+// ApprovalService is an interface for interacting with approval-related business logic
+type ApprovalService interface {
+	// CreateApprovalToken verifies the PIN of an approver and returns a single-use token
+	// approving one action of the requester on the target
+	CreateApprovalToken(ctx context.Context, requesterID, approverID uint64, pin string, permission rdomain.Permission, targetID uint64) (string, error)
+	// Authorize checks that a user may perform an action on the target, either through their own role
+	// or through the approval of a user whose role allows it, and that their API key is scoped for it
+	Authorize(ctx context.Context, payload *domain.TokenPayload, permission rdomain.Permission, targetID uint64, approval *domain.ApprovalRequest) (*domain.Approval, error)
+}
+
+// PasswordService is an interface for interacting with password reset-related business logic
+type PasswordService interface {
+	// RequestPasswordReset emails a password reset token to the user with the email, if there is one
+	RequestPasswordReset(ctx context.Context, email string) error
+	// ResetPassword sets a new password for the user a reset token was sent to
+	ResetPassword(ctx context.Context, token, password string) error
+}
+
+// APIKeyRepository is an interface for interacting with API key-related data
+type APIKeyRepository interface {
+	// CreateAPIKey inserts a new API key into the database
+	CreateAPIKey(ctx context.Context, key *domain.APIKey) (*domain.APIKey, error)
+	// GetAPIKeyByID selects an API key by id
+	GetAPIKeyByID(ctx context.Context, id uint64) (*domain.APIKey, error)
+	// GetAPIKeyByPrefix selects an API key by its prefix
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error)
+	// ListAPIKeys selects a list of API keys with pagination
+	ListAPIKeys(ctx context.Context, skip, limit uint64) ([]domain.APIKey, error)
+	// TouchAPIKey records that an API key was used
+	TouchAPIKey(ctx context.Context, id uint64) error
+	// DeleteAPIKey deletes an API key
+	DeleteAPIKey(ctx context.Context, id uint64) error
+}
+
+// APIKeyService is an interface for interacting with API key-related business logic
+type APIKeyService interface {
+	// CreateAPIKey creates a new API key, if the grantor is allowed all of its scopes, and returns the key to authenticate with
+	CreateAPIKey(ctx context.Context, grantor *domain.TokenPayload, key *domain.APIKey) (*domain.APIKey, string, error)
+	// GetAPIKey returns an API key by id
+	GetAPIKey(ctx context.Context, id uint64) (*domain.APIKey, error)
+	// ListAPIKeys returns a list of API keys with pagination
+	ListAPIKeys(ctx context.Context, skip, limit uint64) ([]domain.APIKey, error)
+	// DeleteAPIKey deletes an API key
+	DeleteAPIKey(ctx context.Context, id uint64) error
+}
+
+// OIDCProvider is an interface for interacting with an OpenID Connect identity provider
+type OIDCProvider interface {
+	// AuthCodeURL returns the URL of the identity provider that users log in at
+	AuthCodeURL(ctx context.Context, state, codeChallenge, nonce string) (string, error)
+	// Exchange exchanges an authorization code for the verified identity of the user
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*domain.OIDCIdentity, error)
+}
+
+// IdentityRepository is an interface for interacting with identity provider account-related data
+type IdentityRepository interface {
+	// GetUserIdentity selects the link of an identity provider account to a user
+	GetUserIdentity(ctx context.Context, issuer, subject string) (*domain.UserIdentity, error)
+	// CreateUserIdentity links an identity provider account to an existing user
+	CreateUserIdentity(ctx context.Context, identity *domain.UserIdentity) (*domain.UserIdentity, error)
+	// ProvisionUser inserts a new user linked to an identity provider account
+	ProvisionUser(ctx context.Context, identity *domain.UserIdentity, user *udomain.User) (*udomain.User, error)
+}
+
+// OIDCService is an interface for interacting with single sign-on-related business logic
+type OIDCService interface {
+	// StartLogin starts a single sign-on login and returns the URL to send the user to
+	StartLogin(ctx context.Context) (string, error)
+	// Login finishes a single sign-on login and returns a token pair, provisioning the user on their first login
+	Login(ctx context.Context, state, code string) (*domain.AuthToken, error)
 }
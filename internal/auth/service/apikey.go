@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"go-restaurant/internal/auth/domain"
+	"go-restaurant/internal/auth/port"
+	cmdomain "go-restaurant/internal/common/domain"
+	roleport "go-restaurant/internal/role/port"
+	"strings"
+)
+
+// apiKeyPrefix starts every API key, so leaked keys are easy to recognize
+const apiKeyPrefix = "grk"
+
+/*APIKeyService implements port.APIKeyService interface
+ * and provides access to the API key repository
+ * and role service
+ */
+type APIKeyService struct {
+	repo  port.APIKeyRepository
+	roles roleport.RoleService
+}
+
+// NewAPIKeyService creates a new API key service instance
+func NewAPIKeyService(repo port.APIKeyRepository, roles roleport.RoleService) *APIKeyService {
+	return &APIKeyService{
+		repo,
+		roles,
+	}
+}
+
+// CreateAPIKey creates a new API key for a user and generates the key. A key can do no more than its scopes
+// allow, so the grantor must be allowed every scope. Only the prefix that identifies the key and its hash
+// are stored, so the key is returned this one time
+func (as *APIKeyService) CreateAPIKey(ctx context.Context, grantor *domain.TokenPayload, key *domain.APIKey) (*domain.APIKey, string, error) {
+	canGrant, err := as.roles.CanGrant(ctx, grantor, key.Scopes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !canGrant {
+		return nil, "", cmdomain.ErrRoleNotGrantable
+	}
+
+	prefix := make([]byte, 6)
+	_, err = rand.Read(prefix)
+	if err != nil {
+		return nil, "", cmdomain.ErrInternal
+	}
+
+	secret := make([]byte, 32)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return nil, "", cmdomain.ErrInternal
+	}
+
+	key.Prefix = hex.EncodeToString(prefix)
+	plainKey := apiKeyPrefix + "_" + key.Prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	key.KeyHash = hashAPIKey(plainKey)
+
+	key, err = as.repo.CreateAPIKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, "", err
+		}
+		return nil, "", cmdomain.ErrInternal
+	}
+
+	return key, plainKey, nil
+}
+
+// GetAPIKey retrieves an API key by id
+func (as *APIKeyService) GetAPIKey(ctx context.Context, id uint64) (*domain.APIKey, error) {
+	key, err := as.repo.GetAPIKeyByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	return key, nil
+}
+
+// ListAPIKeys retrieves a list of API keys
+func (as *APIKeyService) ListAPIKeys(ctx context.Context, skip, limit uint64) ([]domain.APIKey, error) {
+	keys, err := as.repo.ListAPIKeys(ctx, skip, limit)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return keys, nil
+}
+
+// DeleteAPIKey deletes an API key by id, which stops it from working right away
+func (as *APIKeyService) DeleteAPIKey(ctx context.Context, id uint64) error {
+	_, err := as.repo.GetAPIKeyByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return err
+		}
+		return cmdomain.ErrInternal
+	}
+
+	err = as.repo.DeleteAPIKey(ctx, id)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// parseAPIKeyPrefix returns the prefix that identifies an API key
+func parseAPIKeyPrefix(key string) (string, bool) {
+	parts := strings.SplitN(key, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || parts[1] == "" {
+		return "", false
+	}
+
+	return parts[1], true
+}
+
+// hashAPIKey hashes an API key, which is random enough that a fast hash is safe
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(hash[:])
+}
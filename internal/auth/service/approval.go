@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"go-restaurant/internal/auth/domain"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
+	cmutil "go-restaurant/internal/common/util"
+	rdomain "go-restaurant/internal/role/domain"
+	rport "go-restaurant/internal/role/port"
+	uport "go-restaurant/internal/user/port"
+	"time"
+)
+
+const (
+	// approvalTokenDuration is how long an approval token can be used after it is created
+	approvalTokenDuration = 5 * time.Minute
+)
+
+/*ApprovalService implements port.ApprovalService interface
+ * and provides access to the user repository,
+ * role service and cache service
+ */
+type ApprovalService struct {
+	repo  uport.UserRepository
+	roles rport.RoleService
+	cache cmport.CacheRepository
+}
+
+// NewApprovalService creates a new approval service instance
+func NewApprovalService(repo uport.UserRepository, roles rport.RoleService, cache cmport.CacheRepository) *ApprovalService {
+	return &ApprovalService{
+		repo,
+		roles,
+		cache,
+	}
+}
+
+// CreateApprovalToken verifies the PIN of an approver and returns a token that approves
+// one action of the requester requiring the permission on the target within the next few minutes
+func (as *ApprovalService) CreateApprovalToken(ctx context.Context, requesterID, approverID uint64, pin string, permission rdomain.Permission, targetID uint64) (string, error) {
+	approval, err := as.verifyPIN(ctx, requesterID, approverID, pin, permission, targetID)
+	if err != nil {
+		return "", err
+	}
+
+	secret := make([]byte, 32)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return "", cmdomain.ErrInternal
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	approvalSerialized, err := cmutil.Serialize(approval)
+	if err != nil {
+		return "", cmdomain.ErrInternal
+	}
+
+	err = as.cache.Set(ctx, approvalTokenCacheKey(token), approvalSerialized, approvalTokenDuration)
+	if err != nil {
+		return "", cmdomain.ErrInternal
+	}
+
+	return token, nil
+}
+
+// Authorize lets a user perform an action requiring the permission on the target if their role allows it.
+// Otherwise the request must carry the PIN or an approval token of a user whose role allows it,
+// who is returned as the approver. A request made with an API key must have the permission
+// among the scopes of the key either way
+func (as *ApprovalService) Authorize(ctx context.Context, payload *domain.TokenPayload, permission rdomain.Permission, targetID uint64, approval *domain.ApprovalRequest) (*domain.Approval, error) {
+	if !payload.HasScope(permission) {
+		return nil, cmdomain.ErrForbidden
+	}
+
+	userID := payload.UserID
+
+	isAllowed, err := as.roles.HasPermission(ctx, payload.Role, permission)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	if isAllowed {
+		return &domain.Approval{
+			ApproverID:  userID,
+			RequesterID: userID,
+			Permission:  permission,
+			TargetID:    targetID,
+			ApprovedAt:  time.Now(),
+		}, nil
+	}
+
+	if approval == nil || (approval.Token == "" && approval.PIN == "") {
+		return nil, cmdomain.ErrApprovalRequired
+	}
+
+	if approval.Token != "" {
+		return as.redeemToken(ctx, approval.Token, userID, permission, targetID)
+	}
+
+	return as.verifyPIN(ctx, userID, approval.ApproverID, approval.PIN, permission, targetID)
+}
+
+// verifyPIN checks the PIN of an approver and that their role allows the permission,
+// locking the PIN after too many wrong attempts
+func (as *ApprovalService) verifyPIN(ctx context.Context, requesterID, approverID uint64, pin string, permission rdomain.Permission, targetID uint64) (*domain.Approval, error) {
+	approver, err := as.repo.GetUserByID(ctx, approverID)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, cmdomain.ErrInvalidApproval
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	isValid, err := comparePIN(ctx, as.cache, approver, pin)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isValid {
+		return nil, cmdomain.ErrInvalidApproval
+	}
+
+	isAllowed, err := as.roles.HasPermission(ctx, approver.Role, permission)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	if !isAllowed {
+		return nil, cmdomain.ErrInvalidApproval
+	}
+
+	return &domain.Approval{
+		ApproverID:  approver.ID,
+		RequesterID: requesterID,
+		Permission:  permission,
+		TargetID:    targetID,
+		ApprovedAt:  time.Now(),
+	}, nil
+}
+
+// redeemToken consumes an approval token and checks that it approves the permission
+// for the same requester and target it was created for
+func (as *ApprovalService) redeemToken(ctx context.Context, token string, requesterID uint64, permission rdomain.Permission, targetID uint64) (*domain.Approval, error) {
+	cachedApproval, err := as.cache.GetAndDelete(ctx, approvalTokenCacheKey(token))
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, cmdomain.ErrInvalidApproval
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	var approval domain.Approval
+	err = cmutil.Deserialize(cachedApproval, &approval)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	if approval.Permission != permission || approval.RequesterID != requesterID || approval.TargetID != targetID {
+		return nil, cmdomain.ErrInvalidApproval
+	}
+
+	return &approval, nil
+}
+
+// approvalTokenCacheKey generates the cache key of an approval token, which is stored hashed
+func approvalTokenCacheKey(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return cmutil.GenerateCacheKey("approval_token", hex.EncodeToString(hash[:]))
+}
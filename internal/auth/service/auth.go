@@ -2,49 +2,405 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"go-restaurant/internal/auth/domain"
 	"go-restaurant/internal/auth/port"
-	"go-restaurant/internal/common/domain"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
 	cmutil "go-restaurant/internal/common/util"
+	tport "go-restaurant/internal/terminal/port"
+	udomain "go-restaurant/internal/user/domain"
 	uport "go-restaurant/internal/user/port"
+	"time"
 )
 
 /*AuthService implements port.AuthService interface
- * and provides access to the user repository
- * and token service
+ * and provides access to the user repository, API key repository,
+ * terminal service, token service and cache service
  */
 type AuthService struct {
-	repo uport.UserRepository
-	ts   port.TokenService
+	repo            uport.UserRepository
+	apiKeys         port.APIKeyRepository
+	terminals       tport.TerminalService
+	ts              port.TokenService
+	cache           cmport.CacheRepository
+	refreshDuration time.Duration
 }
 
 // NewAuthService creates a new auth service instance
-func NewAuthService(repo uport.UserRepository, ts port.TokenService) *AuthService {
+func NewAuthService(repo uport.UserRepository, apiKeys port.APIKeyRepository, terminals tport.TerminalService, ts port.TokenService, cache cmport.CacheRepository, refreshDuration time.Duration) *AuthService {
 	return &AuthService{
 		repo,
+		apiKeys,
+		terminals,
 		ts,
+		cache,
+		refreshDuration,
 	}
 }
 
-// Login gives a registered user an access token if the credentials are valid
-func (as *AuthService) Login(ctx context.Context, email, password string) (string, error) {
+// Login gives a registered user an access token and a refresh token if the credentials are valid.
+// Failed logins are counted per email and per client IP, which delays and eventually blocks retries
+func (as *AuthService) Login(ctx context.Context, email, password, clientIP string) (*domain.AuthToken, error) {
+	err := as.checkLoginAttempts(ctx, email, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := as.repo.GetUserByEmail(ctx, email)
 	if err != nil {
-		if errors.Is(err, domain.ErrDataNotFound) {
-			return "", domain.ErrInvalidCredentials
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, as.failLogin(ctx, email, clientIP)
 		}
-		return "", domain.ErrInternal
+		return nil, cmdomain.ErrInternal
 	}
 
 	err = cmutil.ComparePassword(password, user.Password)
 	if err != nil {
-		return "", domain.ErrInvalidCredentials
+		return nil, as.failLogin(ctx, email, clientIP)
+	}
+
+	err = as.clearLoginAttempts(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	return as.createAuthToken(ctx, user)
+}
+
+// PINLogin gives a user an access token bound to a registered terminal if their PIN is valid.
+// The token is short-lived and comes without a refresh token, so users log in again at the counter
+func (as *AuthService) PINLogin(ctx context.Context, terminalKey string, userID uint64, pin string) (*domain.AuthToken, error) {
+	terminal, err := as.terminals.AuthenticateTerminal(ctx, terminalKey)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := as.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, cmdomain.ErrInvalidPIN
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	isValid, err := comparePIN(ctx, as.cache, user, pin)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isValid {
+		return nil, cmdomain.ErrInvalidPIN
+	}
+
+	accessToken, err := as.ts.CreateTerminalToken(user, terminal.ID)
+	if err != nil {
+		return nil, cmdomain.ErrTokenCreation
+	}
+
+	return &domain.AuthToken{
+		AccessToken: accessToken,
+	}, nil
+}
+
+// Refresh gives a new access token and refresh token in exchange for a valid refresh token,
+// which can only be used once
+func (as *AuthService) Refresh(ctx context.Context, refreshToken string) (*domain.AuthToken, error) {
+	cacheKey := refreshTokenCacheKey(refreshToken)
+
+	cachedToken, err := as.cache.GetAndDelete(ctx, cacheKey)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, cmdomain.ErrInvalidRefreshToken
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	var token domain.RefreshToken
+	err = cmutil.Deserialize(cachedToken, &token)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	isRevoked, err := as.isUserRevoked(ctx, token.UserID, token.IssuedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	isExpired := time.Now().After(token.ExpiredAt)
+	if isExpired || isRevoked {
+		return nil, cmdomain.ErrInvalidRefreshToken
+	}
+
+	user, err := as.repo.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, cmdomain.ErrInvalidRefreshToken
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	return as.createAuthToken(ctx, user)
+}
+
+// Logout revokes the access token until it expires and deletes the refresh token of the session.
+// API keys have no session to end, they are deleted instead
+func (as *AuthService) Logout(ctx context.Context, payload *domain.TokenPayload, refreshToken string) error {
+	if payload.APIKeyID != 0 {
+		return cmdomain.ErrForbidden
+	}
+
+	ttl := time.Until(payload.ExpiredAt)
+	if ttl > 0 {
+		cacheKey := cmutil.GenerateCacheKey("revoked_token", payload.ID)
+
+		err := as.cache.Set(ctx, cacheKey, []byte{1}, ttl)
+		if err != nil {
+			return cmdomain.ErrInternal
+		}
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	cacheKey := refreshTokenCacheKey(refreshToken)
+
+	cachedToken, err := as.cache.Get(ctx, cacheKey)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil
+		}
+		return cmdomain.ErrInternal
+	}
+
+	var token domain.RefreshToken
+	err = cmutil.Deserialize(cachedToken, &token)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	if token.UserID != payload.UserID {
+		return cmdomain.ErrInvalidRefreshToken
+	}
+
+	err = as.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// VerifyToken verifies the access token and rejects it if it was revoked by a logout,
+// by revoking every token of its user or by deleting the terminal it is bound to.
+// A token bound to a terminal is only accepted with the key of that terminal,
+// so it cannot be used from another device
+func (as *AuthService) VerifyToken(ctx context.Context, token, terminalKey string) (*domain.TokenPayload, error) {
+	payload, err := as.ts.VerifyToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("revoked_token", payload.ID)
+
+	_, err = as.cache.Get(ctx, cacheKey)
+	if err == nil {
+		return nil, cmdomain.ErrRevokedToken
+	}
+	if !errors.Is(err, cmdomain.ErrDataNotFound) {
+		return nil, cmdomain.ErrInternal
+	}
+
+	isRevoked, err := as.isUserRevoked(ctx, payload.UserID, payload.IssuedAt)
+	if err != nil {
+		return nil, err
+	}
+	if isRevoked {
+		return nil, cmdomain.ErrRevokedToken
+	}
+
+	if payload.TerminalID != 0 {
+		if terminalKey == "" {
+			return nil, cmdomain.ErrTerminalMismatch
+		}
+
+		terminal, err := as.terminals.AuthenticateTerminal(ctx, terminalKey)
+		if err != nil {
+			if errors.Is(err, cmdomain.ErrInvalidTerminal) {
+				return nil, cmdomain.ErrTerminalMismatch
+			}
+			return nil, cmdomain.ErrInternal
+		}
+
+		if terminal.ID != payload.TerminalID {
+			return nil, cmdomain.ErrTerminalMismatch
+		}
+	}
+
+	return payload, nil
+}
+
+// VerifyAPIKey verifies the API key and returns a payload that acts as its user with the user's current role,
+// limited to the scopes of the key
+func (as *AuthService) VerifyAPIKey(ctx context.Context, key string) (*domain.TokenPayload, error) {
+	prefix, ok := parseAPIKeyPrefix(key)
+	if !ok {
+		return nil, cmdomain.ErrInvalidAPIKey
+	}
+
+	apiKey, err := as.apiKeys.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, cmdomain.ErrInvalidAPIKey
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	isValid := subtle.ConstantTimeCompare([]byte(hashAPIKey(key)), []byte(apiKey.KeyHash)) == 1
+	if !isValid {
+		return nil, cmdomain.ErrInvalidAPIKey
+	}
+
+	var expiredAt time.Time
+	if apiKey.ExpiresAt != nil {
+		expiredAt = *apiKey.ExpiresAt
+
+		isExpired := time.Now().After(expiredAt)
+		if isExpired {
+			return nil, cmdomain.ErrExpiredAPIKey
+		}
+	}
+
+	err = as.apiKeys.TouchAPIKey(ctx, apiKey.ID)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return &domain.TokenPayload{
+		UserID:    apiKey.UserID,
+		Role:      apiKey.Role,
+		APIKeyID:  apiKey.ID,
+		Scopes:    apiKey.Scopes,
+		IssuedAt:  apiKey.CreatedAt,
+		ExpiredAt: expiredAt,
+	}, nil
+}
+
+// RevokeUserTokens revokes every access token and refresh token issued to a user until now
+func (as *AuthService) RevokeUserTokens(ctx context.Context, userID uint64) error {
+	revokedAt, err := cmutil.Serialize(time.Now())
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("revoked_user", userID)
+
+	err = as.cache.Set(ctx, cacheKey, revokedAt, as.refreshDuration)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// UnlockUser clears the failed logins and PIN attempts of a user, unlocking their account and PIN
+func (as *AuthService) UnlockUser(ctx context.Context, userID uint64) error {
+	user, err := as.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return err
+		}
+		return cmdomain.ErrInternal
+	}
+
+	err = as.clearLoginAttempts(ctx, user.Email)
+	if err != nil {
+		return err
 	}
 
+	err = as.cache.Delete(ctx, cmutil.GenerateCacheKey("pin_attempts", user.ID))
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// ListPublicKeys lists the public keys that verify access tokens
+func (as *AuthService) ListPublicKeys(ctx context.Context) ([]domain.PublicKey, error) {
+	return as.ts.ListPublicKeys(), nil
+}
+
+// createAuthToken issues an access token and stores a new refresh token for a user
+func (as *AuthService) createAuthToken(ctx context.Context, user *udomain.User) (*domain.AuthToken, error) {
 	accessToken, err := as.ts.CreateToken(user)
 	if err != nil {
-		return "", domain.ErrTokenCreation
+		return nil, cmdomain.ErrTokenCreation
+	}
+
+	secret := make([]byte, 32)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return nil, cmdomain.ErrTokenCreation
+	}
+
+	refreshToken := base64.RawURLEncoding.EncodeToString(secret)
+
+	token := domain.RefreshToken{
+		UserID:    user.ID,
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(as.refreshDuration),
+	}
+
+	tokenSerialized, err := cmutil.Serialize(token)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = as.cache.Set(ctx, refreshTokenCacheKey(refreshToken), tokenSerialized, as.refreshDuration)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
 	}
 
-	return accessToken, nil
+	return &domain.AuthToken{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// isUserRevoked checks if the tokens of a user issued at the given time have been revoked.
+// It fails closed, so tokens cannot be used while the revocations cannot be read from the cache
+func (as *AuthService) isUserRevoked(ctx context.Context, userID uint64, issuedAt time.Time) (bool, error) {
+	cacheKey := cmutil.GenerateCacheKey("revoked_user", userID)
+
+	cachedRevokedAt, err := as.cache.Get(ctx, cacheKey)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return false, nil
+		}
+		return false, cmdomain.ErrInternal
+	}
+
+	var revokedAt time.Time
+	err = cmutil.Deserialize(cachedRevokedAt, &revokedAt)
+	if err != nil {
+		return false, cmdomain.ErrInternal
+	}
+
+	// Public tokens are issued at a whole second, so they are compared with the second
+	// of the revocation to accept the tokens issued right after it
+	return issuedAt.Before(revokedAt.Truncate(time.Second)), nil
+}
+
+// refreshTokenCacheKey generates the cache key of a refresh token, which is stored hashed
+func refreshTokenCacheKey(refreshToken string) string {
+	hash := sha256.Sum256([]byte(refreshToken))
+
+	return cmutil.GenerateCacheKey("refresh_token", hex.EncodeToString(hash[:]))
 }
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-restaurant/internal/auth/domain"
+	"go-restaurant/internal/auth/port"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmutil "go-restaurant/internal/common/util"
+	udomain "go-restaurant/internal/user/domain"
+	uport "go-restaurant/internal/user/port"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryCache is an in-memory cmport.CacheRepository that ignores expiry
+type memoryCache struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{values: make(map[string][]byte)}
+}
+
+func (mc *memoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	mc.values[key] = append([]byte(nil), value...)
+	return nil
+}
+
+func (mc *memoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	value, ok := mc.values[key]
+	if !ok {
+		return nil, cmdomain.ErrDataNotFound
+	}
+	return value, nil
+}
+
+func (mc *memoryCache) GetAndDelete(ctx context.Context, key string) ([]byte, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	value, ok := mc.values[key]
+	if !ok {
+		return nil, cmdomain.ErrDataNotFound
+	}
+	delete(mc.values, key)
+	return value, nil
+}
+
+func (mc *memoryCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	count, _ := strconv.ParseInt(string(mc.values[key]), 10, 64)
+	count++
+	mc.values[key] = []byte(strconv.FormatInt(count, 10))
+	return count, nil
+}
+
+func (mc *memoryCache) Delete(ctx context.Context, key string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	delete(mc.values, key)
+	return nil
+}
+
+func (mc *memoryCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	for key := range mc.values {
+		if strings.HasPrefix(key, strings.TrimSuffix(prefix, "*")) {
+			delete(mc.values, key)
+		}
+	}
+	return nil
+}
+
+func (mc *memoryCache) Close() error {
+	return nil
+}
+
+// fakeUserRepository is a uport.UserRepository with a fixed set of users,
+// which panics on the methods the tests do not use
+type fakeUserRepository struct {
+	uport.UserRepository
+	mu    sync.Mutex
+	users map[uint64]*udomain.User
+}
+
+func (fr *fakeUserRepository) GetUserByID(ctx context.Context, id uint64) (*udomain.User, error) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	user, ok := fr.users[id]
+	if !ok {
+		return nil, cmdomain.ErrDataNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+// fakeTokenService is a port.TokenService that creates unsigned tokens naming their user
+type fakeTokenService struct {
+	port.TokenService
+}
+
+func (fs *fakeTokenService) CreateToken(user *udomain.User) (string, error) {
+	return "access-" + strconv.FormatUint(user.ID, 10), nil
+}
+
+func newTestAuthService(cache *memoryCache) *AuthService {
+	repo := &fakeUserRepository{
+		users: map[uint64]*udomain.User{
+			1: {ID: 1, Name: "Cashier", Email: "cashier@example.com", Role: udomain.Cashier},
+		},
+	}
+
+	return NewAuthService(repo, nil, nil, &fakeTokenService{}, cache, time.Hour)
+}
+
+func TestRefresh(t *testing.T) {
+	ctx := context.Background()
+	as := newTestAuthService(newMemoryCache())
+
+	token, err := as.createAuthToken(ctx, &udomain.User{ID: 1})
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	refreshed, err := as.Refresh(ctx, token.RefreshToken)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if refreshed.RefreshToken == token.RefreshToken {
+		t.Fatal("got the same refresh token back")
+	}
+
+	_, err = as.Refresh(ctx, token.RefreshToken)
+	if !errors.Is(err, cmdomain.ErrInvalidRefreshToken) {
+		t.Fatalf("got error %v when reusing a refresh token, want %v", err, cmdomain.ErrInvalidRefreshToken)
+	}
+
+	_, err = as.Refresh(ctx, refreshed.RefreshToken)
+	if err != nil {
+		t.Fatalf("got error %v for the new refresh token", err)
+	}
+}
+
+func TestRefreshConcurrent(t *testing.T) {
+	ctx := context.Background()
+	as := newTestAuthService(newMemoryCache())
+
+	token, err := as.createAuthToken(ctx, &udomain.User{ID: 1})
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	const attempts = 20
+
+	var wg sync.WaitGroup
+	errs := make(chan error, attempts)
+
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := as.Refresh(ctx, token.RefreshToken)
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+		} else if !errors.Is(err, cmdomain.ErrInvalidRefreshToken) {
+			t.Errorf("got error %v, want %v", err, cmdomain.ErrInvalidRefreshToken)
+		}
+	}
+
+	if succeeded != 1 {
+		t.Fatalf("refresh token was used %d times, want once", succeeded)
+	}
+}
+
+func TestRefreshInvalid(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		token domain.RefreshToken
+	}{
+		{
+			name:  "expired",
+			token: domain.RefreshToken{UserID: 1, IssuedAt: time.Now().Add(-2 * time.Hour), ExpiredAt: time.Now().Add(-time.Hour)},
+		},
+		{
+			name:  "unknown user",
+			token: domain.RefreshToken{UserID: 2, IssuedAt: time.Now(), ExpiredAt: time.Now().Add(time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newMemoryCache()
+			as := newTestAuthService(cache)
+
+			tokenSerialized, err := cmutil.Serialize(tt.token)
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+
+			err = cache.Set(ctx, refreshTokenCacheKey("token"), tokenSerialized, time.Hour)
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+
+			_, err = as.Refresh(ctx, "token")
+			if !errors.Is(err, cmdomain.ErrInvalidRefreshToken) {
+				t.Fatalf("got error %v, want %v", err, cmdomain.ErrInvalidRefreshToken)
+			}
+		})
+	}
+
+	t.Run("unknown token", func(t *testing.T) {
+		as := newTestAuthService(newMemoryCache())
+
+		_, err := as.Refresh(ctx, "token")
+		if !errors.Is(err, cmdomain.ErrInvalidRefreshToken) {
+			t.Fatalf("got error %v, want %v", err, cmdomain.ErrInvalidRefreshToken)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-restaurant/internal/auth/domain"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmutil "go-restaurant/internal/common/util"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// loginDelayThreshold is how many failed logins for an email are allowed before each retry is delayed
+	loginDelayThreshold = 3
+	// baseLoginDelay is the delay after the first failed login past the threshold, doubling after every other one
+	baseLoginDelay = time.Second
+	// maxLoginDelay caps the delay between failed logins for an email
+	maxLoginDelay = time.Minute
+	// maxLoginAttempts is how many failed logins for an email lock the account
+	maxLoginAttempts = 10
+	// maxIPLoginAttempts is how many failed logins from a client IP, for any email, block the IP
+	maxIPLoginAttempts = 50
+	// loginAttemptsDuration is how long failed logins are remembered after the last one,
+	// which is also how long a locked account or blocked IP stays that way
+	loginAttemptsDuration = 15 * time.Minute
+)
+
+// checkLoginAttempts rejects a login before the credentials are checked if the client IP is blocked,
+// the account is locked or the delay since the last failed login for the email has not passed
+func (as *AuthService) checkLoginAttempts(ctx context.Context, email, clientIP string) error {
+	ipAttempts, err := as.getLoginAttempts(ctx, ipLoginAttemptsCacheKey(clientIP), "")
+	if err != nil {
+		return err
+	}
+
+	if ipAttempts.Count >= maxIPLoginAttempts {
+		return cmdomain.ErrLoginThrottled
+	}
+
+	emailAttempts, err := as.getLoginAttempts(ctx, emailLoginAttemptsCacheKey(email), lastFailedLoginCacheKey(email))
+	if err != nil {
+		return err
+	}
+
+	if emailAttempts.Count >= maxLoginAttempts {
+		return cmdomain.ErrAccountLocked
+	}
+
+	if emailAttempts.Count >= loginDelayThreshold {
+		delay := baseLoginDelay << (emailAttempts.Count - loginDelayThreshold)
+		if delay > maxLoginDelay {
+			delay = maxLoginDelay
+		}
+
+		if time.Since(emailAttempts.LastFailedAt) < delay {
+			return cmdomain.ErrLoginThrottled
+		}
+	}
+
+	return nil
+}
+
+// recordFailedLogin counts a failed login for the email and the client IP, and remembers when it happened for the email
+func (as *AuthService) recordFailedLogin(ctx context.Context, email, clientIP string) error {
+	for _, cacheKey := range []string{emailLoginAttemptsCacheKey(email), ipLoginAttemptsCacheKey(clientIP)} {
+		_, err := as.cache.Incr(ctx, cacheKey, loginAttemptsDuration)
+		if err != nil {
+			return cmdomain.ErrInternal
+		}
+	}
+
+	lastFailedAtSerialized, err := cmutil.Serialize(time.Now())
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	err = as.cache.Set(ctx, lastFailedLoginCacheKey(email), lastFailedAtSerialized, loginAttemptsDuration)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// failLogin records a failed login and returns the error for invalid credentials
+func (as *AuthService) failLogin(ctx context.Context, email, clientIP string) error {
+	err := as.recordFailedLogin(ctx, email, clientIP)
+	if err != nil {
+		return err
+	}
+
+	return cmdomain.ErrInvalidCredentials
+}
+
+// clearLoginAttempts forgets the failed logins for an email
+func (as *AuthService) clearLoginAttempts(ctx context.Context, email string) error {
+	for _, cacheKey := range []string{emailLoginAttemptsCacheKey(email), lastFailedLoginCacheKey(email)} {
+		err := as.cache.Delete(ctx, cacheKey)
+		if err != nil {
+			return cmdomain.ErrInternal
+		}
+	}
+
+	return nil
+}
+
+// getLoginAttempts retrieves the number of failed logins counted under a cache key, if there are any,
+// and for an email also when the last one happened
+func (as *AuthService) getLoginAttempts(ctx context.Context, cacheKey, lastFailedAtCacheKey string) (*domain.LoginAttempts, error) {
+	var attempts domain.LoginAttempts
+
+	cachedCount, err := as.cache.Get(ctx, cacheKey)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return &attempts, nil
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	attempts.Count, err = strconv.Atoi(string(cachedCount))
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	if lastFailedAtCacheKey == "" {
+		return &attempts, nil
+	}
+
+	cachedLastFailedAt, err := as.cache.Get(ctx, lastFailedAtCacheKey)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return &attempts, nil
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = cmutil.Deserialize(cachedLastFailedAt, &attempts.LastFailedAt)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return &attempts, nil
+}
+
+// emailLoginAttemptsCacheKey generates the cache key of the failed logins for an email
+func emailLoginAttemptsCacheKey(email string) string {
+	return cmutil.GenerateCacheKey("login_attempts:email", strings.ToLower(email))
+}
+
+// ipLoginAttemptsCacheKey generates the cache key of the failed logins from a client IP
+func ipLoginAttemptsCacheKey(clientIP string) string {
+	return cmutil.GenerateCacheKey("login_attempts:ip", clientIP)
+}
+
+// lastFailedLoginCacheKey generates the cache key of the time of the last failed login for an email
+func lastFailedLoginCacheKey(email string) string {
+	return cmutil.GenerateCacheKey("login_attempts:last_failed_at", strings.ToLower(email))
+}
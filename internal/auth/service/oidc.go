@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"go-restaurant/internal/auth/domain"
+	"go-restaurant/internal/auth/port"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
+	cmutil "go-restaurant/internal/common/util"
+	udomain "go-restaurant/internal/user/domain"
+	uport "go-restaurant/internal/user/port"
+	"time"
+)
+
+// oidcLoginDuration is how long a user has to log in at the identity provider after starting a login
+const oidcLoginDuration = 10 * time.Minute
+
+/*OIDCService implements port.OIDCService interface
+ * and provides access to the identity provider, identity repository,
+ * user repository, user service, auth service and cache service
+ */
+type OIDCService struct {
+	provider    port.OIDCProvider
+	identities  port.IdentityRepository
+	users       uport.UserRepository
+	userService uport.UserService
+	auth        *AuthService
+	cache       cmport.CacheRepository
+	roles       domain.OIDCRoleMapping
+}
+
+// NewOIDCService creates a new single sign-on service instance. The provider is nil if single sign-on is not configured
+func NewOIDCService(provider port.OIDCProvider, identities port.IdentityRepository, users uport.UserRepository, userService uport.UserService, auth *AuthService, cache cmport.CacheRepository, roles domain.OIDCRoleMapping) *OIDCService {
+	return &OIDCService{
+		provider,
+		identities,
+		users,
+		userService,
+		auth,
+		cache,
+		roles,
+	}
+}
+
+// StartLogin starts an authorization code login with PKCE. The code verifier and nonce are kept
+// under the state until the identity provider redirects the user back with it
+func (ss *OIDCService) StartLogin(ctx context.Context) (string, error) {
+	if ss.provider == nil {
+		return "", cmdomain.ErrSSODisabled
+	}
+
+	state, err := randomOIDCValue()
+	if err != nil {
+		return "", cmdomain.ErrInternal
+	}
+
+	codeVerifier, err := randomOIDCValue()
+	if err != nil {
+		return "", cmdomain.ErrInternal
+	}
+
+	nonce, err := randomOIDCValue()
+	if err != nil {
+		return "", cmdomain.ErrInternal
+	}
+
+	login := domain.OIDCLogin{
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+	}
+
+	loginSerialized, err := cmutil.Serialize(login)
+	if err != nil {
+		return "", cmdomain.ErrInternal
+	}
+
+	err = ss.cache.Set(ctx, oidcLoginCacheKey(state), loginSerialized, oidcLoginDuration)
+	if err != nil {
+		return "", cmdomain.ErrInternal
+	}
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+	codeChallenge := base64.RawURLEncoding.EncodeToString(challenge[:])
+
+	authURL, err := ss.provider.AuthCodeURL(ctx, state, codeChallenge, nonce)
+	if err != nil {
+		return "", cmdomain.ErrInternal
+	}
+
+	return authURL, nil
+}
+
+// Login finishes a login started by StartLogin, which can only be finished once. Only members of a mapped group
+// at the identity provider can log in, and a user is provisioned with the role of their group on their first login
+func (ss *OIDCService) Login(ctx context.Context, state, code string) (*domain.AuthToken, error) {
+	if ss.provider == nil {
+		return nil, cmdomain.ErrSSODisabled
+	}
+
+	cacheKey := oidcLoginCacheKey(state)
+
+	cachedLogin, err := ss.cache.GetAndDelete(ctx, cacheKey)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, cmdomain.ErrInvalidSSOLogin
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	var login domain.OIDCLogin
+	err = cmutil.Deserialize(cachedLogin, &login)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	identity, err := ss.provider.Exchange(ctx, code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrInvalidSSOLogin) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	role, ok := ss.roles.Role(identity.Groups)
+	if !ok {
+		return nil, cmdomain.ErrSSOAccessDenied
+	}
+
+	user, err := ss.getUser(ctx, identity, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return ss.auth.createAuthToken(ctx, user)
+}
+
+// getUser returns the user linked to an identity provider account. Accounts are linked to the existing user
+// with the same email if the provider verified it, or to a new user otherwise. Only the role of users provisioned
+// for their account follows the mapped role, while existing users keep the role given to them locally
+func (ss *OIDCService) getUser(ctx context.Context, identity *domain.OIDCIdentity, role udomain.UserRole) (*udomain.User, error) {
+	link, err := ss.identities.GetUserIdentity(ctx, identity.Issuer, identity.Subject)
+	if err == nil {
+		user, err := ss.users.GetUserByID(ctx, link.UserID)
+		if err != nil {
+			if errors.Is(err, cmdomain.ErrDataNotFound) {
+				return nil, cmdomain.ErrSSOAccessDenied
+			}
+			return nil, cmdomain.ErrInternal
+		}
+
+		if !link.Provisioned {
+			return user, nil
+		}
+
+		return ss.syncRole(ctx, user, role)
+	}
+
+	if !errors.Is(err, cmdomain.ErrDataNotFound) {
+		return nil, cmdomain.ErrInternal
+	}
+
+	if identity.Email == "" {
+		return nil, cmdomain.ErrInvalidSSOLogin
+	}
+
+	link = &domain.UserIdentity{
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+	}
+
+	user, err := ss.users.GetUserByEmail(ctx, identity.Email)
+	if err == nil {
+		if !identity.EmailVerified {
+			return nil, cmdomain.ErrConflictingData
+		}
+
+		link.UserID = user.ID
+
+		_, err = ss.identities.CreateUserIdentity(ctx, link)
+		if err != nil {
+			if errors.Is(err, cmdomain.ErrConflictingData) {
+				return nil, err
+			}
+			return nil, cmdomain.ErrInternal
+		}
+
+		return user, nil
+	}
+
+	if !errors.Is(err, cmdomain.ErrDataNotFound) {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return ss.provisionUser(ctx, identity, link, role)
+}
+
+// provisionUser creates a user for an identity provider account. The user gets a random password,
+// so they sign in through the identity provider unless they reset it
+func (ss *OIDCService) provisionUser(ctx context.Context, identity *domain.OIDCIdentity, link *domain.UserIdentity, role udomain.UserRole) (*udomain.User, error) {
+	password, err := randomOIDCValue()
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = identity.Email
+	}
+
+	return ss.userService.ProvisionUser(ctx, link, &udomain.User{
+		Name:     name,
+		Email:    identity.Email,
+		Password: password,
+		Role:     role,
+	})
+}
+
+// syncRole gives a provisioned user the role mapped from their identity provider groups if it changed
+func (ss *OIDCService) syncRole(ctx context.Context, user *udomain.User, role udomain.UserRole) (*udomain.User, error) {
+	if user.Role == role {
+		return user, nil
+	}
+
+	user, err := ss.userService.SyncRole(ctx, user.ID, role)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, cmdomain.ErrSSOAccessDenied
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// randomOIDCValue generates a random value for the state, code verifier and nonce of a login
+// and the password of a provisioned user
+func randomOIDCValue() (string, error) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// oidcLoginCacheKey generates the cache key of a login in progress, which is stored under the hash of its state
+func oidcLoginCacheKey(state string) string {
+	hash := sha256.Sum256([]byte(state))
+
+	return cmutil.GenerateCacheKey("oidc_login", hex.EncodeToString(hash[:]))
+}
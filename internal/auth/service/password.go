@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	auditdomain "go-restaurant/internal/audit/domain"
+	auditport "go-restaurant/internal/audit/port"
+	"go-restaurant/internal/auth/port"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
+	cmutil "go-restaurant/internal/common/util"
+	udomain "go-restaurant/internal/user/domain"
+	uport "go-restaurant/internal/user/port"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// resetTokenDuration is how long a password reset token can be used after it is sent
+	resetTokenDuration = time.Hour
+	// resetMailInterval is how long to wait before sending another reset email to the same address
+	resetMailInterval = time.Minute
+)
+
+/*PasswordService implements port.PasswordService interface
+ * and provides access to the user repository, transactor,
+ * auth service, mailer, cache service and audit service
+ */
+type PasswordService struct {
+	repo       uport.UserRepository
+	transactor cmport.Transactor
+	auth       port.AuthService
+	mailer     cmport.Mailer
+	cache      cmport.CacheRepository
+	audit      auditport.AuditService
+	resetURL   string
+}
+
+// NewPasswordService creates a new password service instance
+func NewPasswordService(repo uport.UserRepository, transactor cmport.Transactor, auth port.AuthService, mailer cmport.Mailer, cache cmport.CacheRepository, audit auditport.AuditService, resetURL string) *PasswordService {
+	return &PasswordService{
+		repo,
+		transactor,
+		auth,
+		mailer,
+		cache,
+		audit,
+		resetURL,
+	}
+}
+
+// RequestPasswordReset emails a single-use password reset token to a registered user.
+// Unknown emails are ignored without an error, so the response does not reveal who is registered
+func (ps *PasswordService) RequestPasswordReset(ctx context.Context, email string) error {
+	sentKey := cmutil.GenerateCacheKey("password_reset_sent", strings.ToLower(email))
+
+	_, err := ps.cache.Get(ctx, sentKey)
+	if err == nil {
+		return nil
+	}
+
+	user, err := ps.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil
+		}
+		return cmdomain.ErrInternal
+	}
+
+	secret := make([]byte, 32)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	err = ps.cache.Set(ctx, resetTokenCacheKey(token), []byte(strconv.FormatUint(user.ID, 10)), resetTokenDuration)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, sentKey, []byte{1}, resetMailInterval)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	mail, err := ps.newResetMail(user, token)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	err = ps.mailer.Send(ctx, mail)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password for the user a reset token was sent to, which uses up the token.
+// The change is audited, every session of the user is revoked and their account is unlocked
+func (ps *PasswordService) ResetPassword(ctx context.Context, token, password string) error {
+	cacheKey := resetTokenCacheKey(token)
+
+	cachedUserID, err := ps.cache.GetAndDelete(ctx, cacheKey)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return cmdomain.ErrInvalidResetToken
+		}
+		return cmdomain.ErrInternal
+	}
+
+	userID, err := strconv.ParseUint(string(cachedUserID), 10, 64)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	hashedPassword, err := cmutil.HashPassword(password)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	err = ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := ps.repo.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		updatedUser, err := ps.repo.UpdateUser(ctx, &udomain.User{
+			ID:       userID,
+			Password: hashedPassword,
+		})
+		if err != nil {
+			return err
+		}
+
+		return ps.audit.Record(ctx, auditdomain.ActionUpdate, auditdomain.EntityUser, userID, user, updatedUser)
+	})
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return cmdomain.ErrInvalidResetToken
+		}
+		return cmdomain.ErrInternal
+	}
+
+	err = ps.cache.Delete(ctx, cmutil.GenerateCacheKey("user", userID))
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	err = ps.auth.RevokeUserTokens(ctx, userID)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	err = ps.auth.UnlockUser(ctx, userID)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// newResetMail writes the email that sends a password reset token to a user
+func (ps *PasswordService) newResetMail(user *udomain.User, token string) (*cmdomain.Mail, error) {
+	link := token
+
+	if ps.resetURL != "" {
+		resetURL, err := url.Parse(ps.resetURL)
+		if err != nil {
+			return nil, err
+		}
+
+		query := resetURL.Query()
+		query.Set("token", token)
+		resetURL.RawQuery = query.Encode()
+
+		link = resetURL.String()
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\n"+
+		"Someone asked to reset the password of your account. Use the link below within an hour to choose a new one:\n\n"+
+		"%s\n\n"+
+		"If it was not you, ignore this email and your password stays the same.\n",
+		user.Name, link)
+
+	return &cmdomain.Mail{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body:    body,
+	}, nil
+}
+
+// resetTokenCacheKey generates the cache key of a password reset token, which is stored hashed
+func resetTokenCacheKey(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return cmutil.GenerateCacheKey("password_reset", hex.EncodeToString(hash[:]))
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	auditdomain "go-restaurant/internal/audit/domain"
+	auditport "go-restaurant/internal/audit/port"
+	"go-restaurant/internal/auth/port"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmutil "go-restaurant/internal/common/util"
+	udomain "go-restaurant/internal/user/domain"
+	"sync"
+	"testing"
+	"time"
+)
+
+func (fr *fakeUserRepository) UpdateUser(ctx context.Context, user *udomain.User) (*udomain.User, error) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	existingUser, ok := fr.users[user.ID]
+	if !ok {
+		return nil, cmdomain.ErrDataNotFound
+	}
+	if user.Password != "" {
+		existingUser.Password = user.Password
+	}
+	copied := *existingUser
+	return &copied, nil
+}
+
+// fakeTransactor is a cmport.Transactor that runs fn without a transaction
+type fakeTransactor struct{}
+
+func (ft fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeAuditService is an auditport.AuditService that discards every record
+type fakeAuditService struct {
+	auditport.AuditService
+}
+
+func (fa fakeAuditService) Record(ctx context.Context, action auditdomain.Action, entityType auditdomain.EntityType, entityID uint64, before, after any) error {
+	return nil
+}
+
+// fakeSessionService is a port.AuthService that counts the revocations of user tokens
+type fakeSessionService struct {
+	port.AuthService
+	mu      sync.Mutex
+	revoked int
+}
+
+func (fs *fakeSessionService) RevokeUserTokens(ctx context.Context, userID uint64) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	fs.revoked++
+	return nil
+}
+
+func (fs *fakeSessionService) UnlockUser(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+func newTestPasswordService(cache *memoryCache, auth port.AuthService) (*PasswordService, *fakeUserRepository) {
+	repo := &fakeUserRepository{
+		users: map[uint64]*udomain.User{
+			1: {ID: 1, Name: "Cashier", Email: "cashier@example.com", Role: udomain.Cashier},
+		},
+	}
+
+	return NewPasswordService(repo, fakeTransactor{}, auth, nil, cache, fakeAuditService{}, ""), repo
+}
+
+func TestResetPassword(t *testing.T) {
+	ctx := context.Background()
+	cache := newMemoryCache()
+	auth := &fakeSessionService{}
+	ps, repo := newTestPasswordService(cache, auth)
+
+	err := cache.Set(ctx, resetTokenCacheKey("token"), []byte("1"), time.Hour)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	err = ps.ResetPassword(ctx, "token", "new-password")
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	err = cmutil.ComparePassword("new-password", repo.users[1].Password)
+	if err != nil {
+		t.Fatal("password was not changed")
+	}
+
+	if auth.revoked != 1 {
+		t.Fatalf("tokens were revoked %d times, want once", auth.revoked)
+	}
+
+	err = ps.ResetPassword(ctx, "token", "other-password")
+	if !errors.Is(err, cmdomain.ErrInvalidResetToken) {
+		t.Fatalf("got error %v when reusing a reset token, want %v", err, cmdomain.ErrInvalidResetToken)
+	}
+
+	err = ps.ResetPassword(ctx, "unknown", "other-password")
+	if !errors.Is(err, cmdomain.ErrInvalidResetToken) {
+		t.Fatalf("got error %v for an unknown reset token, want %v", err, cmdomain.ErrInvalidResetToken)
+	}
+}
+
+func TestResetPasswordConcurrent(t *testing.T) {
+	ctx := context.Background()
+	cache := newMemoryCache()
+	auth := &fakeSessionService{}
+	ps, _ := newTestPasswordService(cache, auth)
+
+	err := cache.Set(ctx, resetTokenCacheKey("token"), []byte("1"), time.Hour)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	const attempts = 20
+
+	var wg sync.WaitGroup
+	errs := make(chan error, attempts)
+
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- ps.ResetPassword(ctx, "token", "new-password")
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+		} else if !errors.Is(err, cmdomain.ErrInvalidResetToken) {
+			t.Errorf("got error %v, want %v", err, cmdomain.ErrInvalidResetToken)
+		}
+	}
+
+	if succeeded != 1 {
+		t.Fatalf("reset token was used %d times, want once", succeeded)
+	}
+
+	if auth.revoked != 1 {
+		t.Fatalf("tokens were revoked %d times, want once", auth.revoked)
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
+	cmutil "go-restaurant/internal/common/util"
+	udomain "go-restaurant/internal/user/domain"
+	"time"
+)
+
+const (
+	// maxPINAttempts is how many wrong PINs can be entered for a user before their PIN is locked
+	maxPINAttempts = 5
+	// pinLockDuration is how long a PIN stays locked after too many wrong attempts
+	pinLockDuration = 15 * time.Minute
+)
+
+// comparePIN checks a PIN against the PIN of a user. Attempts are counted per user whether the PIN
+// was entered to log in or to approve an action, before it is checked so concurrent guesses are all counted,
+// and lock the PIN once there are too many wrong ones. A right PIN clears the count
+func comparePIN(ctx context.Context, cache cmport.CacheRepository, user *udomain.User, pin string) (bool, error) {
+	attemptsKey := cmutil.GenerateCacheKey("pin_attempts", user.ID)
+
+	attempts, err := cache.Incr(ctx, attemptsKey, pinLockDuration)
+	if err != nil {
+		return false, cmdomain.ErrInternal
+	}
+
+	if attempts > maxPINAttempts {
+		return false, cmdomain.ErrTooManyAttempts
+	}
+
+	if user.PIN == "" || cmutil.ComparePassword(pin, user.PIN) != nil {
+		return false, nil
+	}
+
+	err = cache.Delete(ctx, attemptsKey)
+	if err != nil {
+		return false, cmdomain.ErrInternal
+	}
+
+	return true, nil
+}
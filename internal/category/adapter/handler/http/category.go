@@ -22,13 +22,17 @@ func NewCategoryHandler(svc port.CategoryService) *CategoryHandler {
 
 // createCategoryRequest represents a request body for creating a new category
 type createCategoryRequest struct {
-	Name string `json:"name" binding:"required" example:"Foods"`
+	Name       string   `json:"name" binding:"required" example:"Foods"`
+	ParentID   *uint64  `json:"parent_id" binding:"omitempty,min=1" example:"1"`
+	SortOrder  int64    `json:"sort_order" example:"1"`
+	Image      string   `json:"image" example:"https://example.com/foods.png"`
+	DayPartIDs []uint64 `json:"day_part_ids" binding:"omitempty,dive,min=1" example:"1"`
 }
 
 // CreateCategory godoc
 //
 //	@Summary		Create a new category
-//	@Description	create a new category with name
+//	@Description	create a new category with name, image and display order, optionally under a parent category and restricted to day-parts
 //	@Tags			Categories
 //	@Accept			json
 //	@Produce		json
@@ -50,7 +54,11 @@ func (ch *CategoryHandler) CreateCategory(ctx *gin.Context) {
 	}
 
 	category := domain.Category{
-		Name: req.Name,
+		Name:       req.Name,
+		ParentID:   req.ParentID,
+		SortOrder:  &req.SortOrder,
+		Image:      &req.Image,
+		DayPartIDs: req.DayPartIDs,
 	}
 
 	_, err := ch.svc.CreateCategory(ctx, &category)
@@ -149,13 +157,17 @@ func (ch *CategoryHandler) ListCategories(ctx *gin.Context) {
 
 // updateCategoryRequest represents a request body for updating a category
 type updateCategoryRequest struct {
-	Name string `json:"name" binding:"omitempty,required" example:"Beverages"`
+	Name       string   `json:"name" binding:"omitempty,required" example:"Beverages"`
+	ParentID   *uint64  `json:"parent_id" example:"0"`
+	SortOrder  *int64   `json:"sort_order" example:"2"`
+	Image      *string  `json:"image" example:"https://example.com/beverages.png"`
+	DayPartIDs []uint64 `json:"day_part_ids" binding:"omitempty,dive,min=1" example:"1"`
 }
 
 // UpdateCategory godoc
 //
 //	@Summary		Update a category
-//	@Description	update a category's name by id
+//	@Description	update a category's name, image, display order or parent, where a parent id of 0 moves it to the top level and an empty image removes it, and, if given, replace the day-parts it is restricted to by id
 //	@Tags			Categories
 //	@Accept			json
 //	@Produce		json
@@ -185,8 +197,12 @@ func (ch *CategoryHandler) UpdateCategory(ctx *gin.Context) {
 	}
 
 	category := domain.Category{
-		ID:   id,
-		Name: req.Name,
+		ID:         id,
+		Name:       req.Name,
+		ParentID:   req.ParentID,
+		SortOrder:  req.SortOrder,
+		Image:      req.Image,
+		DayPartIDs: req.DayPartIDs,
 	}
 
 	_, err = ch.svc.UpdateCategory(ctx, &category)
@@ -208,7 +224,7 @@ type deleteCategoryRequest struct {
 // DeleteCategory godoc
 //
 //	@Summary		Delete a category
-//	@Description	Delete a category by id
+//	@Description	Delete a category that has no subcategories by id
 //	@Tags			Categories
 //	@Accept			json
 //	@Produce		json
@@ -218,6 +234,7 @@ type deleteCategoryRequest struct {
 //	@Failure		401	{object}	errorResponse	"Unauthorized error"
 //	@Failure		403	{object}	errorResponse	"Forbidden error"
 //	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		409	{object}	errorResponse	"Data conflict error"
 //	@Failure		500	{object}	errorResponse	"Internal server error"
 //	@Router			/categories/{id} [delete]
 //	@Security		BearerAuth
@@ -236,3 +253,43 @@ func (ch *CategoryHandler) DeleteCategory(ctx *gin.Context) {
 
 	cmhttp.HandleSuccess(ctx, nil)
 }
+
+// restoreCategoryRequest represents a request body for restoring a deleted category
+type restoreCategoryRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// RestoreCategory godoc
+//
+//	@Summary		Restore a category
+//	@Description	Restore a deleted category by id, unless its parent is deleted
+//	@Tags			Categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64				true	"Category ID"
+//	@Success		200	{object}	categoryResponse	"Category restored"
+//	@Failure		400	{object}	errorResponse		"Validation error"
+//	@Failure		401	{object}	errorResponse		"Unauthorized error"
+//	@Failure		403	{object}	errorResponse		"Forbidden error"
+//	@Failure		404	{object}	errorResponse		"Data not found error"
+//	@Failure		409	{object}	errorResponse		"Data conflict error"
+//	@Failure		500	{object}	errorResponse		"Internal server error"
+//	@Router			/categories/{id}/restore [post]
+//	@Security		BearerAuth
+func (ch *CategoryHandler) RestoreCategory(ctx *gin.Context) {
+	var req restoreCategoryRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	category, err := ch.svc.RestoreCategory(ctx, req.ID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := NewCategoryResponse(category)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
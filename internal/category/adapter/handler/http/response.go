@@ -4,14 +4,22 @@ import "go-restaurant/internal/category/domain"
 
 // CategoryResponse represents a category Response body
 type CategoryResponse struct {
-	ID   uint64 `json:"id" example:"1"`
-	Name string `json:"name" example:"Foods"`
+	ID         uint64   `json:"id" example:"1"`
+	Name       string   `json:"name" example:"Foods"`
+	ParentID   *uint64  `json:"parent_id" example:"1"`
+	SortOrder  int64    `json:"sort_order" example:"1"`
+	Image      string   `json:"image" example:"https://example.com/foods.png"`
+	DayPartIDs []uint64 `json:"day_part_ids" example:"1"`
 }
 
 // NewCategoryResponse is a helper function to create a Response body for handling category data
 func NewCategoryResponse(category *domain.Category) CategoryResponse {
 	return CategoryResponse{
-		ID:   category.ID,
-		Name: category.Name,
+		ID:         category.ID,
+		Name:       category.Name,
+		ParentID:   category.ParentID,
+		SortOrder:  *category.SortOrder,
+		Image:      *category.Image,
+		DayPartIDs: category.DayPartIDs,
 	}
 }
@@ -26,11 +26,78 @@ func NewCategoryRepository(db *postgres.DB) *CategoryRepository {
 	}
 }
 
-// CreateCategory creates a new category record in the database
+// categoryDayPartIDs selects the ids of the day-parts a category is restricted to as an array
+const categoryDayPartIDs = "ARRAY(SELECT day_part_id FROM category_day_parts WHERE category_id = categories.id ORDER BY day_part_id)"
+
+// setDayParts replaces the day-parts a category is restricted to inside a transaction
+// and returns the ids of the day-parts it ends up restricted to
+func (cr *CategoryRepository) setDayParts(ctx context.Context, tx pgx.Tx, categoryID uint64, dayPartIDs []uint64) ([]uint64, error) {
+	var ids []uint64
+
+	deleteQuery := cr.db.QueryBuilder.Delete("category_day_parts").
+		Where(sq.Eq{"category_id": categoryID})
+
+	sql, args, err := deleteQuery.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dayPartIDs) > 0 {
+		insertQuery := cr.db.QueryBuilder.Insert("category_day_parts").
+			Columns("category_id", "day_part_id").
+			Suffix("ON CONFLICT DO NOTHING")
+
+		for _, dayPartID := range dayPartIDs {
+			insertQuery = insertQuery.Values(categoryID, dayPartID)
+		}
+
+		sql, args, err := insertQuery.ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Exec(ctx, sql, args...)
+		if err != nil {
+			if errCode := cr.db.ErrorCode(err); errCode == "23503" {
+				return nil, cmdomain.ErrDataNotFound
+			}
+			return nil, err
+		}
+	}
+
+	selectQuery := cr.db.QueryBuilder.Select(categoryDayPartIDs).
+		From("categories").
+		Where(sq.Eq{"id": categoryID})
+
+	sql, args, err = selectQuery.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(&ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// CreateCategory creates a new category record and the day-parts it is restricted to in the database
 func (cr *CategoryRepository) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	tx, err := cr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
 	query := cr.db.QueryBuilder.Insert("categories").
-		Columns("name").
-		Values(category.Name).
+		Columns("name", "parent_id", "sort_order", "image").
+		Values(category.Name, category.ParentID, category.SortOrder, category.Image).
 		Suffix("RETURNING *")
 
 	sql, args, err := query.ToSql()
@@ -38,27 +105,79 @@ func (cr *CategoryRepository) CreateCategory(ctx context.Context, category *doma
 		return nil, err
 	}
 
-	err = cr.db.QueryRow(ctx, sql, args...).Scan(
+	err = tx.QueryRow(ctx, sql, args...).Scan(
 		&category.ID,
 		&category.Name,
 		&category.CreatedAt,
 		&category.UpdatedAt,
+		&category.DeletedAt,
+		&category.ParentID,
+		&category.SortOrder,
+		&category.Image,
 	)
 	if err != nil {
 		if errCode := cr.db.ErrorCode(err); errCode == "23505" {
 			return nil, cmdomain.ErrConflictingData
+		} else if errCode == "23503" {
+			return nil, cmdomain.ErrDataNotFound
 		}
 		return nil, err
 	}
 
+	category.DayPartIDs, err = cr.setDayParts(ctx, tx, category.ID, category.DayPartIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return category, nil
 }
 
-// GetCategoryByID retrieves a category record from the database by id
+// GetCategoryByID retrieves a category record that is not deleted from the database by id
 func (cr *CategoryRepository) GetCategoryByID(ctx context.Context, id uint64) (*domain.Category, error) {
 	var category domain.Category
 
-	query := cr.db.QueryBuilder.Select("*").
+	query := cr.db.QueryBuilder.Select("*", categoryDayPartIDs).
+		From("categories").
+		Where(sq.Eq{"id": id, "deleted_at": nil}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cr.db.QueryRow(ctx, sql, args...).Scan(
+		&category.ID,
+		&category.Name,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+		&category.DeletedAt,
+		&category.ParentID,
+		&category.SortOrder,
+		&category.Image,
+		&category.DayPartIDs,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// GetCategoryByIDIncludingDeleted retrieves a category record from the database by id,
+// even if it is deleted, so the orders it is part of still resolve it
+func (cr *CategoryRepository) GetCategoryByIDIncludingDeleted(ctx context.Context, id uint64) (*domain.Category, error) {
+	var category domain.Category
+
+	query := cr.db.QueryBuilder.Select("*", categoryDayPartIDs).
 		From("categories").
 		Where(sq.Eq{"id": id}).
 		Limit(1)
@@ -73,6 +192,46 @@ func (cr *CategoryRepository) GetCategoryByID(ctx context.Context, id uint64) (*
 		&category.Name,
 		&category.CreatedAt,
 		&category.UpdatedAt,
+		&category.DeletedAt,
+		&category.ParentID,
+		&category.SortOrder,
+		&category.Image,
+		&category.DayPartIDs,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// GetCategoryByName retrieves a category record that is not deleted from the database by name
+func (cr *CategoryRepository) GetCategoryByName(ctx context.Context, name string) (*domain.Category, error) {
+	var category domain.Category
+
+	query := cr.db.QueryBuilder.Select("*", categoryDayPartIDs).
+		From("categories").
+		Where(sq.Eq{"name": name, "deleted_at": nil}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cr.db.QueryRow(ctx, sql, args...).Scan(
+		&category.ID,
+		&category.Name,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+		&category.DeletedAt,
+		&category.ParentID,
+		&category.SortOrder,
+		&category.Image,
+		&category.DayPartIDs,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -89,8 +248,9 @@ func (cr *CategoryRepository) ListCategories(ctx context.Context, skip, limit ui
 	var category domain.Category
 	var categories []domain.Category
 
-	query := cr.db.QueryBuilder.Select("*").
+	query := cr.db.QueryBuilder.Select("*", categoryDayPartIDs).
 		From("categories").
+		Where(sq.Eq{"deleted_at": nil}).
 		OrderBy("id").
 		Limit(limit).
 		Offset((skip - 1) * limit)
@@ -111,6 +271,11 @@ func (cr *CategoryRepository) ListCategories(ctx context.Context, skip, limit ui
 			&category.Name,
 			&category.CreatedAt,
 			&category.UpdatedAt,
+			&category.DeletedAt,
+			&category.ParentID,
+			&category.SortOrder,
+			&category.Image,
+			&category.DayPartIDs,
 		)
 		if err != nil {
 			return nil, err
@@ -122,39 +287,167 @@ func (cr *CategoryRepository) ListCategories(ctx context.Context, skip, limit ui
 	return categories, nil
 }
 
-// UpdateCategory updates a category record in the database
+// ListAllCategories retrieves all categories that are not deleted from the database in display order
+func (cr *CategoryRepository) ListAllCategories(ctx context.Context) ([]domain.Category, error) {
+	var category domain.Category
+	var categories []domain.Category
+
+	query := cr.db.QueryBuilder.Select("*", categoryDayPartIDs).
+		From("categories").
+		Where(sq.Eq{"deleted_at": nil}).
+		OrderBy("sort_order", "name")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&category.ID,
+			&category.Name,
+			&category.CreatedAt,
+			&category.UpdatedAt,
+			&category.DeletedAt,
+			&category.ParentID,
+			&category.SortOrder,
+			&category.Image,
+			&category.DayPartIDs,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
+}
+
+// ListCategoryDescendantIDs retrieves the ids of the subcategories of a category at any depth from the database,
+// including deleted ones
+func (cr *CategoryRepository) ListCategoryDescendantIDs(ctx context.Context, id uint64) ([]uint64, error) {
+	var ids []uint64
+
+	query := cr.db.QueryBuilder.Select().
+		Column(sq.Expr(`ARRAY(
+			WITH RECURSIVE descendants AS (
+				SELECT id FROM categories WHERE parent_id = ?
+				UNION
+				SELECT categories.id FROM categories JOIN descendants ON categories.parent_id = descendants.id
+			)
+			SELECT id FROM descendants ORDER BY id
+		)`, id))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cr.db.QueryRow(ctx, sql, args...).Scan(&ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// HasSubcategories checks in the database if a category has subcategories that are not deleted
+func (cr *CategoryRepository) HasSubcategories(ctx context.Context, id uint64) (bool, error) {
+	var exists bool
+
+	query := cr.db.QueryBuilder.Select().
+		Column(sq.Expr("EXISTS (SELECT 1 FROM categories WHERE parent_id = ? AND deleted_at IS NULL)", id))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	err = cr.db.QueryRow(ctx, sql, args...).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// UpdateCategory updates a category record in the database and, if given, replaces the day-parts it is restricted to.
+// A parent id of 0 moves the category to the top level
 func (cr *CategoryRepository) UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	tx, err := cr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if category.DayPartIDs != nil {
+		_, err = cr.setDayParts(ctx, tx, category.ID, category.DayPartIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	name := sq.Expr("COALESCE(NULLIF(?, ''), name)", category.Name)
+	sortOrder := sq.Expr("COALESCE(?, sort_order)", category.SortOrder)
+	image := sq.Expr("COALESCE(?, image)", category.Image)
+
 	query := cr.db.QueryBuilder.Update("categories").
-		Set("name", category.Name).
+		Set("name", name).
+		Set("sort_order", sortOrder).
+		Set("image", image).
 		Set("updated_at", time.Now()).
 		Where(sq.Eq{"id": category.ID}).
-		Suffix("RETURNING *")
+		Suffix("RETURNING *, " + categoryDayPartIDs)
+
+	if category.ParentID != nil {
+		query = query.Set("parent_id", sq.Expr("NULLIF(?, 0)", *category.ParentID))
+	}
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = cr.db.QueryRow(ctx, sql, args...).Scan(
+	err = tx.QueryRow(ctx, sql, args...).Scan(
 		&category.ID,
 		&category.Name,
 		&category.CreatedAt,
 		&category.UpdatedAt,
+		&category.DeletedAt,
+		&category.ParentID,
+		&category.SortOrder,
+		&category.Image,
+		&category.DayPartIDs,
 	)
 	if err != nil {
 		if errCode := cr.db.ErrorCode(err); errCode == "23505" {
 			return nil, cmdomain.ErrConflictingData
+		} else if errCode == "23503" {
+			return nil, cmdomain.ErrDataNotFound
 		}
 		return nil, err
 	}
 
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return category, nil
 }
 
-// DeleteCategory deletes a category record from the database by id
+// DeleteCategory soft deletes a category record in the database by id, so the orders it is part of keep referring to it
 func (cr *CategoryRepository) DeleteCategory(ctx context.Context, id uint64) error {
-	query := cr.db.QueryBuilder.Delete("categories").
-		Where(sq.Eq{"id": id})
+	query := cr.db.QueryBuilder.Update("categories").
+		Set("deleted_at", time.Now()).
+		Where(sq.Eq{"id": id, "deleted_at": nil})
 
 	sql, args, err := query.ToSql()
 	if err != nil {
@@ -168,3 +461,41 @@ func (cr *CategoryRepository) DeleteCategory(ctx context.Context, id uint64) err
 
 	return nil
 }
+
+// RestoreCategory restores a soft deleted category record in the database by id
+func (cr *CategoryRepository) RestoreCategory(ctx context.Context, id uint64) (*domain.Category, error) {
+	var category domain.Category
+
+	query := cr.db.QueryBuilder.Update("categories").
+		Set("deleted_at", nil).
+		Where(sq.And{sq.Eq{"id": id}, sq.NotEq{"deleted_at": nil}}).
+		Suffix("RETURNING *, " + categoryDayPartIDs)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cr.db.QueryRow(ctx, sql, args...).Scan(
+		&category.ID,
+		&category.Name,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+		&category.DeletedAt,
+		&category.ParentID,
+		&category.SortOrder,
+		&category.Image,
+		&category.DayPartIDs,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		if errCode := cr.db.ErrorCode(err); errCode == "23505" {
+			return nil, cmdomain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return &category, nil
+}
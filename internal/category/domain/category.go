@@ -2,10 +2,18 @@ package domain
 
 import "time"
 
-// Category is an entity that represents a category of product
+// Category is an entity that represents a category of product. A category restricted to day-parts
+// restricts its products that are not restricted to day-parts themselves. Categories nest under a parent,
+// ordered among their siblings by SortOrder, and a top level category has no parent. SortOrder and Image
+// are only nil in an update that leaves them as they are
 type Category struct {
-	ID        uint64
-	Name      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID         uint64
+	Name       string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  *time.Time
+	ParentID   *uint64
+	SortOrder  *int64
+	Image      *string
+	DayPartIDs []uint64
 }
@@ -13,12 +13,24 @@ type CategoryRepository interface {
 	CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
 	// GetCategoryByID selects a category by id
 	GetCategoryByID(ctx context.Context, id uint64) (*domain.Category, error)
+	// GetCategoryByIDIncludingDeleted selects a category by id, even if it is deleted
+	GetCategoryByIDIncludingDeleted(ctx context.Context, id uint64) (*domain.Category, error)
+	// GetCategoryByName selects a category by name
+	GetCategoryByName(ctx context.Context, name string) (*domain.Category, error)
 	// ListCategories selects a list of categories with pagination
 	ListCategories(ctx context.Context, skip, limit uint64) ([]domain.Category, error)
-	// UpdateCategory updates a category
+	// ListAllCategories selects all categories in display order
+	ListAllCategories(ctx context.Context) ([]domain.Category, error)
+	// ListCategoryDescendantIDs selects the ids of the subcategories of a category at any depth
+	ListCategoryDescendantIDs(ctx context.Context, id uint64) ([]uint64, error)
+	// HasSubcategories checks if a category has subcategories
+	HasSubcategories(ctx context.Context, id uint64) (bool, error)
+	// UpdateCategory updates a category, moving it to the top level when its parent id is 0
 	UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
-	// DeleteCategory deletes a category
+	// DeleteCategory soft deletes a category
 	DeleteCategory(ctx context.Context, id uint64) error
+	// RestoreCategory restores a soft deleted category
+	RestoreCategory(ctx context.Context, id uint64) (*domain.Category, error)
 }
 
 // CategoryService is an interface for interacting with category-related business logic
@@ -29,8 +41,10 @@ type CategoryService interface {
 	GetCategory(ctx context.Context, id uint64) (*domain.Category, error)
 	// ListCategories returns a list of categories with pagination
 	ListCategories(ctx context.Context, skip, limit uint64) ([]domain.Category, error)
-	// UpdateCategory updates a category
+	// UpdateCategory updates a category, moving it to the top level when its parent id is 0
 	UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error)
-	// DeleteCategory deletes a category
+	// DeleteCategory soft deletes a category
 	DeleteCategory(ctx context.Context, id uint64) error
+	// RestoreCategory restores a soft deleted category
+	RestoreCategory(ctx context.Context, id uint64) (*domain.Category, error)
 }
@@ -3,35 +3,60 @@ package service
 import (
 	"context"
 	"errors"
+	auditdomain "go-restaurant/internal/audit/domain"
+	auditport "go-restaurant/internal/audit/port"
 	"go-restaurant/internal/category/domain"
 	"go-restaurant/internal/category/port"
 	cmdomain "go-restaurant/internal/common/domain"
 	cmport "go-restaurant/internal/common/port"
 	"go-restaurant/internal/common/util"
+	"slices"
 )
 
 /*CategoryService implements port.CategoryService interface
- * and provides access to the category repository
- * and cache service
+ * and provides access to the category repository,
+ * transactor, cache service and audit service
  */
 type CategoryService struct {
-	repo  port.CategoryRepository
-	cache cmport.CacheRepository
+	repo       port.CategoryRepository
+	transactor cmport.Transactor
+	cache      cmport.CacheRepository
+	audit      auditport.AuditService
 }
 
 // NewCategoryService creates a new category service instance
-func NewCategoryService(repo port.CategoryRepository, cache cmport.CacheRepository) *CategoryService {
+func NewCategoryService(repo port.CategoryRepository, transactor cmport.Transactor, cache cmport.CacheRepository, audit auditport.AuditService) *CategoryService {
 	return &CategoryService{
 		repo,
+		transactor,
 		cache,
+		audit,
 	}
 }
 
-// CreateCategory creates a new category
+// CreateCategory creates a new category, under its parent if given
 func (cs *CategoryService) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
-	category, err := cs.repo.CreateCategory(ctx, category)
+	if category.ParentID != nil {
+		_, err := cs.repo.GetCategoryByID(ctx, *category.ParentID)
+		if err != nil {
+			if errors.Is(err, cmdomain.ErrDataNotFound) {
+				return nil, err
+			}
+			return nil, cmdomain.ErrInternal
+		}
+	}
+
+	err := cs.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		category, err = cs.repo.CreateCategory(ctx, category)
+		if err != nil {
+			return err
+		}
+
+		return cs.audit.Record(ctx, auditdomain.ActionCreate, auditdomain.EntityCategory, category.ID, nil, category)
+	})
 	if err != nil {
-		if errors.Is(err, cmdomain.ErrConflictingData) {
+		if errors.Is(err, cmdomain.ErrConflictingData) || errors.Is(err, cmdomain.ErrDataNotFound) {
 			return nil, err
 		}
 		return nil, cmdomain.ErrInternal
@@ -48,7 +73,7 @@ func (cs *CategoryService) CreateCategory(ctx context.Context, category *domain.
 		return nil, cmdomain.ErrInternal
 	}
 
-	err = cs.cache.DeleteByPrefix(ctx, "categories:*")
+	err = cs.invalidateLists(ctx)
 	if err != nil {
 		return nil, cmdomain.ErrInternal
 	}
@@ -126,7 +151,7 @@ func (cs *CategoryService) ListCategories(ctx context.Context, skip, limit uint6
 	return categories, nil
 }
 
-// UpdateCategory updates a category
+// UpdateCategory updates a category, moving it to the top level when its parent id is 0
 func (cs *CategoryService) UpdateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
 	existingCategory, err := cs.repo.GetCategoryByID(ctx, category.ID)
 	if err != nil {
@@ -136,15 +161,37 @@ func (cs *CategoryService) UpdateCategory(ctx context.Context, category *domain.
 		return nil, cmdomain.ErrInternal
 	}
 
-	emptyData := category.Name == ""
-	sameData := existingCategory.Name == category.Name
+	emptyData := category.Name == "" &&
+		category.ParentID == nil &&
+		category.SortOrder == nil &&
+		category.Image == nil &&
+		category.DayPartIDs == nil
+	sameData := (category.Name == "" || existingCategory.Name == category.Name) &&
+		(category.ParentID == nil || sameParent(existingCategory.ParentID, *category.ParentID)) &&
+		(category.SortOrder == nil || *existingCategory.SortOrder == *category.SortOrder) &&
+		(category.Image == nil || *existingCategory.Image == *category.Image) &&
+		(category.DayPartIDs == nil || slices.Equal(existingCategory.DayPartIDs, category.DayPartIDs))
 	if emptyData || sameData {
 		return nil, cmdomain.ErrNoUpdatedData
 	}
 
-	_, err = cs.repo.UpdateCategory(ctx, category)
+	if category.ParentID != nil && *category.ParentID != 0 {
+		err = cs.validateParent(ctx, category.ID, *category.ParentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = cs.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := cs.repo.UpdateCategory(ctx, category)
+		if err != nil {
+			return err
+		}
+
+		return cs.audit.Record(ctx, auditdomain.ActionUpdate, auditdomain.EntityCategory, category.ID, existingCategory, category)
+	})
 	if err != nil {
-		if errors.Is(err, cmdomain.ErrConflictingData) {
+		if errors.Is(err, cmdomain.ErrConflictingData) || errors.Is(err, cmdomain.ErrDataNotFound) {
 			return nil, err
 		}
 		return nil, cmdomain.ErrInternal
@@ -167,7 +214,7 @@ func (cs *CategoryService) UpdateCategory(ctx context.Context, category *domain.
 		return nil, cmdomain.ErrInternal
 	}
 
-	err = cs.cache.DeleteByPrefix(ctx, "categories:*")
+	err = cs.invalidateLists(ctx)
 	if err != nil {
 		return nil, cmdomain.ErrInternal
 	}
@@ -175,9 +222,9 @@ func (cs *CategoryService) UpdateCategory(ctx context.Context, category *domain.
 	return category, nil
 }
 
-// DeleteCategory deletes a category
+// DeleteCategory soft deletes a category that has no subcategories
 func (cs *CategoryService) DeleteCategory(ctx context.Context, id uint64) error {
-	_, err := cs.repo.GetCategoryByID(ctx, id)
+	existingCategory, err := cs.repo.GetCategoryByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, cmdomain.ErrDataNotFound) {
 			return err
@@ -185,6 +232,15 @@ func (cs *CategoryService) DeleteCategory(ctx context.Context, id uint64) error
 		return cmdomain.ErrInternal
 	}
 
+	hasSubcategories, err := cs.repo.HasSubcategories(ctx, id)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	if hasSubcategories {
+		return cmdomain.ErrCategoryHasChildren
+	}
+
 	cacheKey := cmutil.GenerateCacheKey("category", id)
 
 	err = cs.cache.Delete(ctx, cacheKey)
@@ -192,10 +248,104 @@ func (cs *CategoryService) DeleteCategory(ctx context.Context, id uint64) error
 		return cmdomain.ErrInternal
 	}
 
-	err = cs.cache.DeleteByPrefix(ctx, "categories:*")
+	err = cs.invalidateLists(ctx)
 	if err != nil {
 		return cmdomain.ErrInternal
 	}
 
-	return cs.repo.DeleteCategory(ctx, id)
+	return cs.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := cs.repo.DeleteCategory(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return cs.audit.Record(ctx, auditdomain.ActionDelete, auditdomain.EntityCategory, id, existingCategory, nil)
+	})
+}
+
+// RestoreCategory restores a deleted category, unless its parent is deleted
+func (cs *CategoryService) RestoreCategory(ctx context.Context, id uint64) (*domain.Category, error) {
+	var category *domain.Category
+	err := cs.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		category, err = cs.repo.RestoreCategory(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if category.ParentID != nil {
+			parent, err := cs.repo.GetCategoryByIDIncludingDeleted(ctx, *category.ParentID)
+			if err != nil {
+				return err
+			}
+
+			if parent.DeletedAt != nil {
+				return cmdomain.ErrCategoryDeleted
+			}
+		}
+
+		return cs.audit.Record(ctx, auditdomain.ActionRestore, auditdomain.EntityCategory, id, nil, category)
+	})
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) || errors.Is(err, cmdomain.ErrConflictingData) || errors.Is(err, cmdomain.ErrCategoryDeleted) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = cs.invalidateLists(ctx)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return category, nil
+}
+
+// validateParent checks that a category can be moved under a parent that is not deleted,
+// which is neither the category itself nor one of its subcategories
+func (cs *CategoryService) validateParent(ctx context.Context, id, parentID uint64) error {
+	if parentID == id {
+		return cmdomain.ErrInvalidCategoryParent
+	}
+
+	_, err := cs.repo.GetCategoryByID(ctx, parentID)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return err
+		}
+		return cmdomain.ErrInternal
+	}
+
+	descendantIDs, err := cs.repo.ListCategoryDescendantIDs(ctx, id)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	if slices.Contains(descendantIDs, parentID) {
+		return cmdomain.ErrInvalidCategoryParent
+	}
+
+	return nil
+}
+
+// invalidateLists removes the cached category lists, and the cached product lists and menu
+// since they show the categories of their products
+func (cs *CategoryService) invalidateLists(ctx context.Context) error {
+	for _, prefix := range []string{"categories:*", "products:*"} {
+		err := cs.cache.DeleteByPrefix(ctx, prefix)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sameParent checks if a category with a parent id is under the given parent, where 0 is the top level
+func sameParent(parentID *uint64, newParentID uint64) bool {
+	if parentID == nil {
+		return newParentID == 0
+	}
+
+	return *parentID == newParentID
 }
@@ -0,0 +1,249 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-restaurant/internal/channel/domain"
+	"go-restaurant/internal/channel/port"
+	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+	cmutil "go-restaurant/internal/common/util"
+)
+
+// MenuHandler represents the HTTP handler for menu-related requests
+type MenuHandler struct {
+	svc port.MenuService
+}
+
+// NewMenuHandler creates a new MenuHandler instance
+func NewMenuHandler(svc port.MenuService) *MenuHandler {
+	return &MenuHandler{
+		svc,
+	}
+}
+
+// createMenuRequest represents a request body for creating a new menu
+type createMenuRequest struct {
+	Name       string         `json:"name" binding:"required" example:"Takeaway"`
+	Channel    domain.Channel `json:"channel" binding:"required,channel" example:"takeaway"`
+	ProductIDs []uint64       `json:"product_ids" binding:"required,dive,min=1" example:"1"`
+}
+
+// CreateMenu godoc
+//
+//	@Summary		Create a new menu
+//	@Description	create a new menu of a channel, selecting the products that appear in it
+//	@Tags			Menus
+//	@Accept			json
+//	@Produce		json
+//	@Param			createMenuRequest	body		createMenuRequest	true	"Create menu request"
+//	@Success		200					{object}	menuResponse		"Menu created"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/menus [post]
+//	@Security		BearerAuth
+func (mh *MenuHandler) CreateMenu(ctx *gin.Context) {
+	var req createMenuRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	menu := domain.Menu{
+		Name:       req.Name,
+		Channel:    req.Channel,
+		ProductIDs: req.ProductIDs,
+	}
+
+	_, err := mh.svc.CreateMenu(ctx, &menu)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newMenuResponse(&menu)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// getMenuRequest represents a request body for retrieving a menu
+type getMenuRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetMenu godoc
+//
+//	@Summary		Get a menu
+//	@Description	get a menu by id with its products
+//	@Tags			Menus
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Menu ID"
+//	@Success		200	{object}	menuResponse	"Menu retrieved"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/menus/{id} [get]
+//	@Security		BearerAuth
+func (mh *MenuHandler) GetMenu(ctx *gin.Context) {
+	var req getMenuRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	menu, err := mh.svc.GetMenu(ctx, req.ID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newMenuResponse(menu)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// listMenusRequest represents a request body for listing menus
+type listMenusRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListMenus godoc
+//
+//	@Summary		List menus
+//	@Description	List menus with pagination
+//	@Tags			Menus
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Menus displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/menus [get]
+//	@Security		BearerAuth
+func (mh *MenuHandler) ListMenus(ctx *gin.Context) {
+	var req listMenusRequest
+	var menusList []menuResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	menus, err := mh.svc.ListMenus(ctx, req.Skip, req.Limit)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	for _, menu := range menus {
+		menusList = append(menusList, newMenuResponse(&menu))
+	}
+
+	total := uint64(len(menusList))
+	meta := cmhttp.NewMeta(total, req.Limit, req.Skip)
+	rsp := cmutil.ToMap(meta, menusList, "menus")
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// updateMenuRequest represents a request body for updating a menu
+type updateMenuRequest struct {
+	Name       string         `json:"name" binding:"omitempty,required" example:"Takeaway"`
+	Channel    domain.Channel `json:"channel" binding:"omitempty,channel" example:"takeaway"`
+	ProductIDs []uint64       `json:"product_ids" binding:"omitempty,dive,min=1" example:"1"`
+}
+
+// UpdateMenu godoc
+//
+//	@Summary		Update a menu
+//	@Description	update a menu's name and channel and, if given, replace its products by id
+//	@Tags			Menus
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		uint64				true	"Menu ID"
+//	@Param			updateMenuRequest	body		updateMenuRequest	true	"Update menu request"
+//	@Success		200					{object}	menuResponse		"Menu updated"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/menus/{id} [put]
+//	@Security		BearerAuth
+func (mh *MenuHandler) UpdateMenu(ctx *gin.Context) {
+	var uri getMenuRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	var req updateMenuRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	menu := domain.Menu{
+		ID:         uri.ID,
+		Name:       req.Name,
+		Channel:    req.Channel,
+		ProductIDs: req.ProductIDs,
+	}
+
+	_, err := mh.svc.UpdateMenu(ctx, &menu)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newMenuResponse(&menu)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// deleteMenuRequest represents a request body for deleting a menu
+type deleteMenuRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeleteMenu godoc
+//
+//	@Summary		Delete a menu
+//	@Description	Delete a menu by id, after which its channel sells every product
+//	@Tags			Menus
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Menu ID"
+//	@Success		200	{object}	response		"Menu deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/menus/{id} [delete]
+//	@Security		BearerAuth
+func (mh *MenuHandler) DeleteMenu(ctx *gin.Context) {
+	var req deleteMenuRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	err := mh.svc.DeleteMenu(ctx, req.ID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	cmhttp.HandleSuccess(ctx, nil)
+}
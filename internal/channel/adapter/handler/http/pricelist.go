@@ -0,0 +1,273 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-restaurant/internal/channel/domain"
+	"go-restaurant/internal/channel/port"
+	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+	cmutil "go-restaurant/internal/common/util"
+)
+
+// PriceListHandler represents the HTTP handler for price list-related requests
+type PriceListHandler struct {
+	svc port.PriceListService
+}
+
+// NewPriceListHandler creates a new PriceListHandler instance
+func NewPriceListHandler(svc port.PriceListService) *PriceListHandler {
+	return &PriceListHandler{
+		svc,
+	}
+}
+
+// priceListItemRequest represents a price list item request body
+type priceListItemRequest struct {
+	ProductID uint64  `json:"product_id" binding:"required,min=1" example:"1"`
+	Price     float64 `json:"price" binding:"min=0" example:"6000"`
+}
+
+// createPriceListRequest represents a request body for creating a new price list
+type createPriceListRequest struct {
+	Name    string                 `json:"name" binding:"required" example:"Delivery apps"`
+	Channel domain.Channel         `json:"channel" binding:"required,channel" example:"delivery"`
+	Items   []priceListItemRequest `json:"items" binding:"required,dive"`
+}
+
+// CreatePriceList godoc
+//
+//	@Summary		Create a new price list
+//	@Description	create a new price list of a channel, overriding the prices of the products it lists for the orders taken through it
+//	@Tags			PriceLists
+//	@Accept			json
+//	@Produce		json
+//	@Param			createPriceListRequest	body		createPriceListRequest	true	"Create price list request"
+//	@Success		200						{object}	priceListResponse		"Price list created"
+//	@Failure		400						{object}	errorResponse			"Validation error"
+//	@Failure		401						{object}	errorResponse			"Unauthorized error"
+//	@Failure		403						{object}	errorResponse			"Forbidden error"
+//	@Failure		404						{object}	errorResponse			"Data not found error"
+//	@Failure		409						{object}	errorResponse			"Data conflict error"
+//	@Failure		500						{object}	errorResponse			"Internal server error"
+//	@Router			/price-lists [post]
+//	@Security		BearerAuth
+func (ph *PriceListHandler) CreatePriceList(ctx *gin.Context) {
+	var req createPriceListRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	priceList := domain.PriceList{
+		Name:    req.Name,
+		Channel: req.Channel,
+		Items:   newPriceListItems(req.Items),
+	}
+
+	_, err := ph.svc.CreatePriceList(ctx, &priceList)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newPriceListResponse(&priceList)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// getPriceListRequest represents a request body for retrieving a price list
+type getPriceListRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetPriceList godoc
+//
+//	@Summary		Get a price list
+//	@Description	get a price list by id with its prices
+//	@Tags			PriceLists
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64				true	"Price list ID"
+//	@Success		200	{object}	priceListResponse	"Price list retrieved"
+//	@Failure		400	{object}	errorResponse		"Validation error"
+//	@Failure		401	{object}	errorResponse		"Unauthorized error"
+//	@Failure		403	{object}	errorResponse		"Forbidden error"
+//	@Failure		404	{object}	errorResponse		"Data not found error"
+//	@Failure		500	{object}	errorResponse		"Internal server error"
+//	@Router			/price-lists/{id} [get]
+//	@Security		BearerAuth
+func (ph *PriceListHandler) GetPriceList(ctx *gin.Context) {
+	var req getPriceListRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	priceList, err := ph.svc.GetPriceList(ctx, req.ID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newPriceListResponse(priceList)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// listPriceListsRequest represents a request body for listing price lists
+type listPriceListsRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListPriceLists godoc
+//
+//	@Summary		List price lists
+//	@Description	List price lists with pagination
+//	@Tags			PriceLists
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Price lists displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/price-lists [get]
+//	@Security		BearerAuth
+func (ph *PriceListHandler) ListPriceLists(ctx *gin.Context) {
+	var req listPriceListsRequest
+	var priceListsList []priceListResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	priceLists, err := ph.svc.ListPriceLists(ctx, req.Skip, req.Limit)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	for _, priceList := range priceLists {
+		priceListsList = append(priceListsList, newPriceListResponse(&priceList))
+	}
+
+	total := uint64(len(priceListsList))
+	meta := cmhttp.NewMeta(total, req.Limit, req.Skip)
+	rsp := cmutil.ToMap(meta, priceListsList, "price_lists")
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// updatePriceListRequest represents a request body for updating a price list
+type updatePriceListRequest struct {
+	Name    string                 `json:"name" binding:"omitempty,required" example:"Delivery apps"`
+	Channel domain.Channel         `json:"channel" binding:"omitempty,channel" example:"delivery"`
+	Items   []priceListItemRequest `json:"items" binding:"omitempty,dive"`
+}
+
+// UpdatePriceList godoc
+//
+//	@Summary		Update a price list
+//	@Description	update a price list's name and channel and, if given, replace its prices by id
+//	@Tags			PriceLists
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path		uint64					true	"Price list ID"
+//	@Param			updatePriceListRequest	body		updatePriceListRequest	true	"Update price list request"
+//	@Success		200						{object}	priceListResponse		"Price list updated"
+//	@Failure		400						{object}	errorResponse			"Validation error"
+//	@Failure		401						{object}	errorResponse			"Unauthorized error"
+//	@Failure		403						{object}	errorResponse			"Forbidden error"
+//	@Failure		404						{object}	errorResponse			"Data not found error"
+//	@Failure		409						{object}	errorResponse			"Data conflict error"
+//	@Failure		500						{object}	errorResponse			"Internal server error"
+//	@Router			/price-lists/{id} [put]
+//	@Security		BearerAuth
+func (ph *PriceListHandler) UpdatePriceList(ctx *gin.Context) {
+	var uri getPriceListRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	var req updatePriceListRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	priceList := domain.PriceList{
+		ID:      uri.ID,
+		Name:    req.Name,
+		Channel: req.Channel,
+		Items:   newPriceListItems(req.Items),
+	}
+
+	_, err := ph.svc.UpdatePriceList(ctx, &priceList)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newPriceListResponse(&priceList)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// deletePriceListRequest represents a request body for deleting a price list
+type deletePriceListRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeletePriceList godoc
+//
+//	@Summary		Delete a price list
+//	@Description	Delete a price list by id, after which its channel charges the products' own prices
+//	@Tags			PriceLists
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Price list ID"
+//	@Success		200	{object}	response		"Price list deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/price-lists/{id} [delete]
+//	@Security		BearerAuth
+func (ph *PriceListHandler) DeletePriceList(ctx *gin.Context) {
+	var req deletePriceListRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	err := ph.svc.DeletePriceList(ctx, req.ID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	cmhttp.HandleSuccess(ctx, nil)
+}
+
+// newPriceListItems converts validated item requests to price list items, keeping nil as nil
+// so an update without items leaves them unchanged
+func newPriceListItems(reqs []priceListItemRequest) []domain.PriceListItem {
+	if reqs == nil {
+		return nil
+	}
+
+	items := make([]domain.PriceListItem, 0, len(reqs))
+	for _, req := range reqs {
+		items = append(items, domain.PriceListItem{
+			ProductID: req.ProductID,
+			Price:     req.Price,
+		})
+	}
+
+	return items
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"go-restaurant/internal/channel/domain"
+	"time"
+)
+
+// priceListItemResponse represents a price list item Response body
+type priceListItemResponse struct {
+	ProductID uint64  `json:"product_id" example:"1"`
+	Price     float64 `json:"price" example:"6000"`
+}
+
+// priceListResponse represents a price list Response body
+type priceListResponse struct {
+	ID        uint64                  `json:"id" example:"1"`
+	Name      string                  `json:"name" example:"Delivery apps"`
+	Channel   domain.Channel          `json:"channel" example:"delivery"`
+	Items     []priceListItemResponse `json:"items"`
+	CreatedAt time.Time               `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt time.Time               `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newPriceListResponse is a helper function to create a Response body for handling price list data
+func newPriceListResponse(priceList *domain.PriceList) priceListResponse {
+	items := make([]priceListItemResponse, 0, len(priceList.Items))
+	for _, item := range priceList.Items {
+		items = append(items, priceListItemResponse{
+			ProductID: item.ProductID,
+			Price:     item.Price,
+		})
+	}
+
+	return priceListResponse{
+		ID:        priceList.ID,
+		Name:      priceList.Name,
+		Channel:   priceList.Channel,
+		Items:     items,
+		CreatedAt: priceList.CreatedAt,
+		UpdatedAt: priceList.UpdatedAt,
+	}
+}
+
+// menuResponse represents a menu Response body
+type menuResponse struct {
+	ID         uint64         `json:"id" example:"1"`
+	Name       string         `json:"name" example:"Takeaway"`
+	Channel    domain.Channel `json:"channel" example:"takeaway"`
+	ProductIDs []uint64       `json:"product_ids" example:"1"`
+	CreatedAt  time.Time      `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt  time.Time      `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newMenuResponse is a helper function to create a Response body for handling menu data
+func newMenuResponse(menu *domain.Menu) menuResponse {
+	return menuResponse{
+		ID:         menu.ID,
+		Name:       menu.Name,
+		Channel:    menu.Channel,
+		ProductIDs: menu.ProductIDs,
+		CreatedAt:  menu.CreatedAt,
+		UpdatedAt:  menu.UpdatedAt,
+	}
+}
@@ -0,0 +1,18 @@
+package http
+
+import (
+	"github.com/go-playground/validator/v10"
+	"go-restaurant/internal/channel/domain"
+)
+
+// ChannelValidator is a custom validator for validating the channels orders are taken through
+var ChannelValidator validator.Func = func(fl validator.FieldLevel) bool {
+	channel := fl.Field().Interface().(domain.Channel)
+
+	switch channel {
+	case "dine_in", "takeaway", "delivery":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,333 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-restaurant/internal/channel/domain"
+	"go-restaurant/internal/common/adapter/storage/postgres"
+	cmdomain "go-restaurant/internal/common/domain"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/*MenuRepository implements port.MenuRepository interface
+ * and provides access to the postgres database
+ */
+type MenuRepository struct {
+	db *postgres.DB
+}
+
+// NewMenuRepository creates a new menu repository instance
+func NewMenuRepository(db *postgres.DB) *MenuRepository {
+	return &MenuRepository{
+		db,
+	}
+}
+
+// insertProducts inserts the products of a menu inside a transaction
+func (mr *MenuRepository) insertProducts(ctx context.Context, tx pgx.Tx, menu *domain.Menu) error {
+	if len(menu.ProductIDs) == 0 {
+		return nil
+	}
+
+	query := mr.db.QueryBuilder.Insert("menu_products").
+		Columns("menu_id", "product_id").
+		Suffix("ON CONFLICT DO NOTHING")
+
+	for _, productID := range menu.ProductIDs {
+		query = query.Values(menu.ID, productID)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		if errCode := mr.db.ErrorCode(err); errCode == "23503" {
+			return cmdomain.ErrDataNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// getProductIDs retrieves the ids of the products of a menu inside a transaction, in order
+func (mr *MenuRepository) getProductIDs(ctx context.Context, tx pgx.Tx, menuID uint64) ([]uint64, error) {
+	var productID uint64
+	productIDs := []uint64{}
+
+	query := mr.db.QueryBuilder.Select("product_id").
+		From("menu_products").
+		Where(sq.Eq{"menu_id": menuID}).
+		OrderBy("product_id")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(&productID)
+		if err != nil {
+			return nil, err
+		}
+
+		productIDs = append(productIDs, productID)
+	}
+
+	return productIDs, rows.Err()
+}
+
+// CreateMenu creates a new menu record and its products in the database
+func (mr *MenuRepository) CreateMenu(ctx context.Context, menu *domain.Menu) (*domain.Menu, error) {
+	tx, err := mr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := mr.db.QueryBuilder.Insert("menus").
+		Columns("name", "channel").
+		Values(menu.Name, menu.Channel).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(
+		&menu.ID,
+		&menu.Name,
+		&menu.Channel,
+		&menu.CreatedAt,
+		&menu.UpdatedAt,
+	)
+	if err != nil {
+		if errCode := mr.db.ErrorCode(err); errCode == "23505" {
+			return nil, cmdomain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	err = mr.insertProducts(ctx, tx, menu)
+	if err != nil {
+		return nil, err
+	}
+
+	menu.ProductIDs, err = mr.getProductIDs(ctx, tx, menu.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return menu, nil
+}
+
+// GetMenuByID retrieves a menu record and its products from the database by id
+func (mr *MenuRepository) GetMenuByID(ctx context.Context, id uint64) (*domain.Menu, error) {
+	return mr.getMenu(ctx, sq.Eq{"id": id})
+}
+
+// GetMenuByChannel retrieves the menu record of a channel and its products from the database
+func (mr *MenuRepository) GetMenuByChannel(ctx context.Context, channel domain.Channel) (*domain.Menu, error) {
+	return mr.getMenu(ctx, sq.Eq{"channel": channel})
+}
+
+// getMenu retrieves a menu record and its products from the database by a condition
+func (mr *MenuRepository) getMenu(ctx context.Context, where sq.Eq) (*domain.Menu, error) {
+	var menu domain.Menu
+
+	query := mr.db.QueryBuilder.Select("*").
+		From("menus").
+		Where(where).
+		Limit(1)
+
+	err := pgx.BeginFunc(ctx, mr.db, func(tx pgx.Tx) error {
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, sql, args...).Scan(
+			&menu.ID,
+			&menu.Name,
+			&menu.Channel,
+			&menu.CreatedAt,
+			&menu.UpdatedAt,
+		)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return cmdomain.ErrDataNotFound
+			}
+			return err
+		}
+
+		menu.ProductIDs, err = mr.getProductIDs(ctx, tx, menu.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &menu, nil
+}
+
+// ListMenus retrieves a list of menus and their products from the database
+func (mr *MenuRepository) ListMenus(ctx context.Context, skip, limit uint64) ([]domain.Menu, error) {
+	var menu domain.Menu
+	var menus []domain.Menu
+
+	query := mr.db.QueryBuilder.Select("*").
+		From("menus").
+		OrderBy("id").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	err := pgx.BeginFunc(ctx, mr.db, func(tx pgx.Tx) error {
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			err := rows.Scan(
+				&menu.ID,
+				&menu.Name,
+				&menu.Channel,
+				&menu.CreatedAt,
+				&menu.UpdatedAt,
+			)
+			if err != nil {
+				return err
+			}
+
+			menus = append(menus, menu)
+		}
+
+		for i, menu := range menus {
+			menus[i].ProductIDs, err = mr.getProductIDs(ctx, tx, menu.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return menus, nil
+}
+
+// UpdateMenu updates a menu record in the database and, if given, replaces its products
+func (mr *MenuRepository) UpdateMenu(ctx context.Context, menu *domain.Menu) (*domain.Menu, error) {
+	tx, err := mr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	name := sq.Expr("COALESCE(NULLIF(?, ''), name)", menu.Name)
+	channel := sq.Expr("COALESCE(NULLIF(?, ''), channel)", menu.Channel)
+
+	query := mr.db.QueryBuilder.Update("menus").
+		Set("name", name).
+		Set("channel", channel).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": menu.ID}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(
+		&menu.ID,
+		&menu.Name,
+		&menu.Channel,
+		&menu.CreatedAt,
+		&menu.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		if errCode := mr.db.ErrorCode(err); errCode == "23505" {
+			return nil, cmdomain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	if menu.ProductIDs != nil {
+		query := mr.db.QueryBuilder.Delete("menu_products").
+			Where(sq.Eq{"menu_id": menu.ID})
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		err = mr.insertProducts(ctx, tx, menu)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	menu.ProductIDs, err = mr.getProductIDs(ctx, tx, menu.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return menu, nil
+}
+
+// DeleteMenu deletes a menu record and its products from the database by id
+func (mr *MenuRepository) DeleteMenu(ctx context.Context, id uint64) error {
+	query := mr.db.QueryBuilder.Delete("menus").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = mr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
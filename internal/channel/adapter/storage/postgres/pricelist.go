@@ -0,0 +1,338 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-restaurant/internal/channel/domain"
+	"go-restaurant/internal/common/adapter/storage/postgres"
+	cmdomain "go-restaurant/internal/common/domain"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/*PriceListRepository implements port.PriceListRepository interface
+ * and provides access to the postgres database
+ */
+type PriceListRepository struct {
+	db *postgres.DB
+}
+
+// NewPriceListRepository creates a new price list repository instance
+func NewPriceListRepository(db *postgres.DB) *PriceListRepository {
+	return &PriceListRepository{
+		db,
+	}
+}
+
+// insertItems inserts the items of a price list inside a transaction
+func (pr *PriceListRepository) insertItems(ctx context.Context, tx pgx.Tx, priceList *domain.PriceList) error {
+	if len(priceList.Items) == 0 {
+		return nil
+	}
+
+	query := pr.db.QueryBuilder.Insert("price_list_items").
+		Columns("price_list_id", "product_id", "price")
+
+	for _, item := range priceList.Items {
+		query = query.Values(priceList.ID, item.ProductID, item.Price)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		if errCode := pr.db.ErrorCode(err); errCode == "23503" {
+			return cmdomain.ErrDataNotFound
+		}
+		if errCode := pr.db.ErrorCode(err); errCode == "23505" {
+			return cmdomain.ErrConflictingData
+		}
+		return err
+	}
+
+	return nil
+}
+
+// getItems retrieves the items of a price list inside a transaction, ordered by product
+func (pr *PriceListRepository) getItems(ctx context.Context, tx pgx.Tx, priceListID uint64) ([]domain.PriceListItem, error) {
+	var item domain.PriceListItem
+	items := []domain.PriceListItem{}
+
+	query := pr.db.QueryBuilder.Select("product_id", "price").
+		From("price_list_items").
+		Where(sq.Eq{"price_list_id": priceListID}).
+		OrderBy("product_id")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&item.ProductID,
+			&item.Price,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// CreatePriceList creates a new price list record and its items in the database
+func (pr *PriceListRepository) CreatePriceList(ctx context.Context, priceList *domain.PriceList) (*domain.PriceList, error) {
+	tx, err := pr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := pr.db.QueryBuilder.Insert("price_lists").
+		Columns("name", "channel").
+		Values(priceList.Name, priceList.Channel).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(
+		&priceList.ID,
+		&priceList.Name,
+		&priceList.Channel,
+		&priceList.CreatedAt,
+		&priceList.UpdatedAt,
+	)
+	if err != nil {
+		if errCode := pr.db.ErrorCode(err); errCode == "23505" {
+			return nil, cmdomain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	err = pr.insertItems(ctx, tx, priceList)
+	if err != nil {
+		return nil, err
+	}
+
+	priceList.Items, err = pr.getItems(ctx, tx, priceList.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return priceList, nil
+}
+
+// GetPriceListByID retrieves a price list record and its items from the database by id
+func (pr *PriceListRepository) GetPriceListByID(ctx context.Context, id uint64) (*domain.PriceList, error) {
+	return pr.getPriceList(ctx, sq.Eq{"id": id})
+}
+
+// GetPriceListByChannel retrieves the price list record of a channel and its items from the database
+func (pr *PriceListRepository) GetPriceListByChannel(ctx context.Context, channel domain.Channel) (*domain.PriceList, error) {
+	return pr.getPriceList(ctx, sq.Eq{"channel": channel})
+}
+
+// getPriceList retrieves a price list record and its items from the database by a condition
+func (pr *PriceListRepository) getPriceList(ctx context.Context, where sq.Eq) (*domain.PriceList, error) {
+	var priceList domain.PriceList
+
+	query := pr.db.QueryBuilder.Select("*").
+		From("price_lists").
+		Where(where).
+		Limit(1)
+
+	err := pgx.BeginFunc(ctx, pr.db, func(tx pgx.Tx) error {
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, sql, args...).Scan(
+			&priceList.ID,
+			&priceList.Name,
+			&priceList.Channel,
+			&priceList.CreatedAt,
+			&priceList.UpdatedAt,
+		)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return cmdomain.ErrDataNotFound
+			}
+			return err
+		}
+
+		priceList.Items, err = pr.getItems(ctx, tx, priceList.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &priceList, nil
+}
+
+// ListPriceLists retrieves a list of price lists and their items from the database
+func (pr *PriceListRepository) ListPriceLists(ctx context.Context, skip, limit uint64) ([]domain.PriceList, error) {
+	var priceList domain.PriceList
+	var priceLists []domain.PriceList
+
+	query := pr.db.QueryBuilder.Select("*").
+		From("price_lists").
+		OrderBy("id").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	err := pgx.BeginFunc(ctx, pr.db, func(tx pgx.Tx) error {
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			err := rows.Scan(
+				&priceList.ID,
+				&priceList.Name,
+				&priceList.Channel,
+				&priceList.CreatedAt,
+				&priceList.UpdatedAt,
+			)
+			if err != nil {
+				return err
+			}
+
+			priceLists = append(priceLists, priceList)
+		}
+
+		for i, priceList := range priceLists {
+			priceLists[i].Items, err = pr.getItems(ctx, tx, priceList.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return priceLists, nil
+}
+
+// UpdatePriceList updates a price list record in the database and, if given, replaces its items
+func (pr *PriceListRepository) UpdatePriceList(ctx context.Context, priceList *domain.PriceList) (*domain.PriceList, error) {
+	tx, err := pr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	name := sq.Expr("COALESCE(NULLIF(?, ''), name)", priceList.Name)
+	channel := sq.Expr("COALESCE(NULLIF(?, ''), channel)", priceList.Channel)
+
+	query := pr.db.QueryBuilder.Update("price_lists").
+		Set("name", name).
+		Set("channel", channel).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": priceList.ID}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(
+		&priceList.ID,
+		&priceList.Name,
+		&priceList.Channel,
+		&priceList.CreatedAt,
+		&priceList.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		if errCode := pr.db.ErrorCode(err); errCode == "23505" {
+			return nil, cmdomain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	if priceList.Items != nil {
+		query := pr.db.QueryBuilder.Delete("price_list_items").
+			Where(sq.Eq{"price_list_id": priceList.ID})
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		err = pr.insertItems(ctx, tx, priceList)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	priceList.Items, err = pr.getItems(ctx, tx, priceList.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return priceList, nil
+}
+
+// DeletePriceList deletes a price list record and its items from the database by id
+func (pr *PriceListRepository) DeletePriceList(ctx context.Context, id uint64) error {
+	query := pr.db.QueryBuilder.Delete("price_lists").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = pr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
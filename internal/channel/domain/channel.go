@@ -0,0 +1,14 @@
+package domain
+
+// Channel is an enum for the channels orders are taken through
+type Channel string
+
+// Channel enum values
+const (
+	DineIn   Channel = "dine_in"
+	Takeaway Channel = "takeaway"
+	Delivery Channel = "delivery"
+)
+
+// Channels lists every channel
+var Channels = []Channel{DineIn, Takeaway, Delivery}
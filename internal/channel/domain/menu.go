@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Menu is an entity that represents the products that appear in a channel.
+// A channel without a menu sells every product
+type Menu struct {
+	ID         uint64
+	Name       string
+	Channel    Channel
+	ProductIDs []uint64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Includes checks if a product appears on the menu
+func (m *Menu) Includes(productID uint64) bool {
+	return slices.Contains(m.ProductIDs, productID)
+}
+
+// HasProducts checks if exactly the given products appear on the menu, in any order
+func (m *Menu) HasProducts(productIDs []uint64) bool {
+	sorted := slices.Clone(productIDs)
+	slices.Sort(sorted)
+
+	existing := slices.Clone(m.ProductIDs)
+	slices.Sort(existing)
+
+	return slices.Equal(existing, sorted)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+)
+
+// PriceList is an entity that represents the prices a channel charges for products,
+// overriding their own prices
+type PriceList struct {
+	ID        uint64
+	Name      string
+	Channel   Channel
+	Items     []PriceListItem
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// PriceListItem is a value object that represents the price a price list charges for a product
+type PriceListItem struct {
+	ProductID uint64
+	Price     float64
+}
+
+// PriceOf returns the price the price list charges for a product, if it overrides it
+func (pl *PriceList) PriceOf(productID uint64) (float64, bool) {
+	for _, item := range pl.Items {
+		if item.ProductID == productID {
+			return item.Price, true
+		}
+	}
+
+	return 0, false
+}
+
+// HasItems checks if the price list charges exactly the given prices, in any order
+func (pl *PriceList) HasItems(items []PriceListItem) bool {
+	if len(items) != len(pl.Items) {
+		return false
+	}
+
+	for _, item := range items {
+		price, ok := pl.PriceOf(item.ProductID)
+		if !ok || price != item.Price {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,38 @@
+package port
+
+import (
+	"context"
+	"go-restaurant/internal/channel/domain"
+)
+
+//go:generate mockgen -source=menu.go -destination=mock/menu.go -package=mock
+
+// MenuRepository is an interface for interacting with menu-related data
+type MenuRepository interface {
+	// CreateMenu inserts a new menu and its products into the database
+	CreateMenu(ctx context.Context, menu *domain.Menu) (*domain.Menu, error)
+	// GetMenuByID selects a menu and its products by id
+	GetMenuByID(ctx context.Context, id uint64) (*domain.Menu, error)
+	// GetMenuByChannel selects the menu of a channel and its products
+	GetMenuByChannel(ctx context.Context, channel domain.Channel) (*domain.Menu, error)
+	// ListMenus selects a list of menus and their products with pagination
+	ListMenus(ctx context.Context, skip, limit uint64) ([]domain.Menu, error)
+	// UpdateMenu updates a menu and, if given, replaces its products
+	UpdateMenu(ctx context.Context, menu *domain.Menu) (*domain.Menu, error)
+	// DeleteMenu deletes a menu
+	DeleteMenu(ctx context.Context, id uint64) error
+}
+
+// MenuService is an interface for interacting with menu-related business logic
+type MenuService interface {
+	// CreateMenu creates a new menu
+	CreateMenu(ctx context.Context, menu *domain.Menu) (*domain.Menu, error)
+	// GetMenu returns a menu by id
+	GetMenu(ctx context.Context, id uint64) (*domain.Menu, error)
+	// ListMenus returns a list of menus with pagination
+	ListMenus(ctx context.Context, skip, limit uint64) ([]domain.Menu, error)
+	// UpdateMenu updates a menu
+	UpdateMenu(ctx context.Context, menu *domain.Menu) (*domain.Menu, error)
+	// DeleteMenu deletes a menu
+	DeleteMenu(ctx context.Context, id uint64) error
+}
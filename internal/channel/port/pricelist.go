@@ -0,0 +1,38 @@
+package port
+
+import (
+	"context"
+	"go-restaurant/internal/channel/domain"
+)
+
+//go:generate mockgen -source=pricelist.go -destination=mock/pricelist.go -package=mock
+
+// PriceListRepository is an interface for interacting with price list-related data
+type PriceListRepository interface {
+	// CreatePriceList inserts a new price list and its items into the database
+	CreatePriceList(ctx context.Context, priceList *domain.PriceList) (*domain.PriceList, error)
+	// GetPriceListByID selects a price list and its items by id
+	GetPriceListByID(ctx context.Context, id uint64) (*domain.PriceList, error)
+	// GetPriceListByChannel selects the price list of a channel and its items
+	GetPriceListByChannel(ctx context.Context, channel domain.Channel) (*domain.PriceList, error)
+	// ListPriceLists selects a list of price lists and their items with pagination
+	ListPriceLists(ctx context.Context, skip, limit uint64) ([]domain.PriceList, error)
+	// UpdatePriceList updates a price list and, if given, replaces its items
+	UpdatePriceList(ctx context.Context, priceList *domain.PriceList) (*domain.PriceList, error)
+	// DeletePriceList deletes a price list
+	DeletePriceList(ctx context.Context, id uint64) error
+}
+
+// PriceListService is an interface for interacting with price list-related business logic
+type PriceListService interface {
+	// CreatePriceList creates a new price list
+	CreatePriceList(ctx context.Context, priceList *domain.PriceList) (*domain.PriceList, error)
+	// GetPriceList returns a price list by id
+	GetPriceList(ctx context.Context, id uint64) (*domain.PriceList, error)
+	// ListPriceLists returns a list of price lists with pagination
+	ListPriceLists(ctx context.Context, skip, limit uint64) ([]domain.PriceList, error)
+	// UpdatePriceList updates a price list
+	UpdatePriceList(ctx context.Context, priceList *domain.PriceList) (*domain.PriceList, error)
+	// DeletePriceList deletes a price list
+	DeletePriceList(ctx context.Context, id uint64) error
+}
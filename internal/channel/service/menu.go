@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	auditdomain "go-restaurant/internal/audit/domain"
+	auditport "go-restaurant/internal/audit/port"
+	"go-restaurant/internal/channel/domain"
+	"go-restaurant/internal/channel/port"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
+	cmutil "go-restaurant/internal/common/util"
+)
+
+/*MenuService implements port.MenuService interface
+ * and provides access to the menu repository,
+ * transactor, cache service and audit service
+ */
+type MenuService struct {
+	repo       port.MenuRepository
+	transactor cmport.Transactor
+	cache      cmport.CacheRepository
+	audit      auditport.AuditService
+}
+
+// NewMenuService creates a new menu service instance
+func NewMenuService(repo port.MenuRepository, transactor cmport.Transactor, cache cmport.CacheRepository, audit auditport.AuditService) *MenuService {
+	return &MenuService{
+		repo,
+		transactor,
+		cache,
+		audit,
+	}
+}
+
+// CreateMenu creates a new menu for a channel that does not have one yet, after which
+// the channel only sells the products on it
+func (ms *MenuService) CreateMenu(ctx context.Context, menu *domain.Menu) (*domain.Menu, error) {
+	err := ms.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		menu, err = ms.repo.CreateMenu(ctx, menu)
+		if err != nil {
+			return err
+		}
+
+		return ms.audit.Record(ctx, auditdomain.ActionCreate, auditdomain.EntityMenu, menu.ID, nil, menu)
+	})
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrConflictingData) || errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("menu", menu.ID)
+	menuSerialized, err := cmutil.Serialize(menu)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = ms.cache.Set(ctx, cacheKey, menuSerialized, 0)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = ms.invalidateLists(ctx)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return menu, nil
+}
+
+// GetMenu retrieves a menu by id
+func (ms *MenuService) GetMenu(ctx context.Context, id uint64) (*domain.Menu, error) {
+	var menu *domain.Menu
+
+	cacheKey := cmutil.GenerateCacheKey("menu", id)
+	cachedMenu, err := ms.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := cmutil.Deserialize(cachedMenu, &menu)
+		if err != nil {
+			return nil, cmdomain.ErrInternal
+		}
+
+		return menu, nil
+	}
+
+	menu, err = ms.repo.GetMenuByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	menuSerialized, err := cmutil.Serialize(menu)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = ms.cache.Set(ctx, cacheKey, menuSerialized, 0)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return menu, nil
+}
+
+// ListMenus retrieves a list of menus
+func (ms *MenuService) ListMenus(ctx context.Context, skip, limit uint64) ([]domain.Menu, error) {
+	var menus []domain.Menu
+
+	params := cmutil.GenerateCacheKeyParams(skip, limit)
+	cacheKey := cmutil.GenerateCacheKey("menus", params)
+
+	cachedMenus, err := ms.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := cmutil.Deserialize(cachedMenus, &menus)
+		if err != nil {
+			return nil, cmdomain.ErrInternal
+		}
+
+		return menus, nil
+	}
+
+	menus, err = ms.repo.ListMenus(ctx, skip, limit)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	menusSerialized, err := cmutil.Serialize(menus)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = ms.cache.Set(ctx, cacheKey, menusSerialized, 0)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return menus, nil
+}
+
+// UpdateMenu updates the name and channel of a menu and, if given, replaces its products
+func (ms *MenuService) UpdateMenu(ctx context.Context, menu *domain.Menu) (*domain.Menu, error) {
+	existingMenu, err := ms.repo.GetMenuByID(ctx, menu.ID)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	emptyData := menu.Name == "" && menu.Channel == "" && menu.ProductIDs == nil
+	sameData := (menu.Name == "" || existingMenu.Name == menu.Name) &&
+		(menu.Channel == "" || existingMenu.Channel == menu.Channel) &&
+		(menu.ProductIDs == nil || existingMenu.HasProducts(menu.ProductIDs))
+	if emptyData || sameData {
+		return nil, cmdomain.ErrNoUpdatedData
+	}
+
+	err = ms.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := ms.repo.UpdateMenu(ctx, menu)
+		if err != nil {
+			return err
+		}
+
+		return ms.audit.Record(ctx, auditdomain.ActionUpdate, auditdomain.EntityMenu, menu.ID, existingMenu, menu)
+	})
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrConflictingData) || errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("menu", menu.ID)
+	_ = ms.cache.Delete(ctx, cacheKey)
+
+	menuSerialized, err := cmutil.Serialize(menu)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = ms.cache.Set(ctx, cacheKey, menuSerialized, 0)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = ms.invalidateLists(ctx)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return menu, nil
+}
+
+// DeleteMenu deletes a menu, after which its channel sells every product
+func (ms *MenuService) DeleteMenu(ctx context.Context, id uint64) error {
+	existingMenu, err := ms.repo.GetMenuByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return err
+		}
+		return cmdomain.ErrInternal
+	}
+
+	err = ms.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := ms.repo.DeleteMenu(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return ms.audit.Record(ctx, auditdomain.ActionDelete, auditdomain.EntityMenu, id, existingMenu, nil)
+	})
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return err
+		}
+		return cmdomain.ErrInternal
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("menu", id)
+	_ = ms.cache.Delete(ctx, cacheKey)
+
+	err = ms.invalidateLists(ctx)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// invalidateLists deletes the cached menus and the cached product lists,
+// which are filtered by the menus of their channels
+func (ms *MenuService) invalidateLists(ctx context.Context) error {
+	for _, prefix := range []string{"menus:*", "products:*"} {
+		err := ms.cache.DeleteByPrefix(ctx, prefix)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	auditdomain "go-restaurant/internal/audit/domain"
+	auditport "go-restaurant/internal/audit/port"
+	"go-restaurant/internal/channel/domain"
+	"go-restaurant/internal/channel/port"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
+	cmutil "go-restaurant/internal/common/util"
+)
+
+/*PriceListService implements port.PriceListService interface
+ * and provides access to the price list repository,
+ * transactor, cache service and audit service
+ */
+type PriceListService struct {
+	repo       port.PriceListRepository
+	transactor cmport.Transactor
+	cache      cmport.CacheRepository
+	audit      auditport.AuditService
+}
+
+// NewPriceListService creates a new price list service instance
+func NewPriceListService(repo port.PriceListRepository, transactor cmport.Transactor, cache cmport.CacheRepository, audit auditport.AuditService) *PriceListService {
+	return &PriceListService{
+		repo,
+		transactor,
+		cache,
+		audit,
+	}
+}
+
+// CreatePriceList creates a new price list for a channel that does not have one yet
+func (ps *PriceListService) CreatePriceList(ctx context.Context, priceList *domain.PriceList) (*domain.PriceList, error) {
+	err := ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		priceList, err = ps.repo.CreatePriceList(ctx, priceList)
+		if err != nil {
+			return err
+		}
+
+		return ps.audit.Record(ctx, auditdomain.ActionCreate, auditdomain.EntityPriceList, priceList.ID, nil, priceList)
+	})
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrConflictingData) || errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("price_list", priceList.ID)
+	priceListSerialized, err := cmutil.Serialize(priceList)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, priceListSerialized, 0)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = ps.invalidateLists(ctx)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return priceList, nil
+}
+
+// GetPriceList retrieves a price list by id
+func (ps *PriceListService) GetPriceList(ctx context.Context, id uint64) (*domain.PriceList, error) {
+	var priceList *domain.PriceList
+
+	cacheKey := cmutil.GenerateCacheKey("price_list", id)
+	cachedPriceList, err := ps.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := cmutil.Deserialize(cachedPriceList, &priceList)
+		if err != nil {
+			return nil, cmdomain.ErrInternal
+		}
+
+		return priceList, nil
+	}
+
+	priceList, err = ps.repo.GetPriceListByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	priceListSerialized, err := cmutil.Serialize(priceList)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, priceListSerialized, 0)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return priceList, nil
+}
+
+// ListPriceLists retrieves a list of price lists
+func (ps *PriceListService) ListPriceLists(ctx context.Context, skip, limit uint64) ([]domain.PriceList, error) {
+	var priceLists []domain.PriceList
+
+	params := cmutil.GenerateCacheKeyParams(skip, limit)
+	cacheKey := cmutil.GenerateCacheKey("price_lists", params)
+
+	cachedPriceLists, err := ps.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := cmutil.Deserialize(cachedPriceLists, &priceLists)
+		if err != nil {
+			return nil, cmdomain.ErrInternal
+		}
+
+		return priceLists, nil
+	}
+
+	priceLists, err = ps.repo.ListPriceLists(ctx, skip, limit)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	priceListsSerialized, err := cmutil.Serialize(priceLists)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, priceListsSerialized, 0)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return priceLists, nil
+}
+
+// UpdatePriceList updates the name and channel of a price list and, if given, replaces its prices
+func (ps *PriceListService) UpdatePriceList(ctx context.Context, priceList *domain.PriceList) (*domain.PriceList, error) {
+	existingPriceList, err := ps.repo.GetPriceListByID(ctx, priceList.ID)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	emptyData := priceList.Name == "" && priceList.Channel == "" && priceList.Items == nil
+	sameData := (priceList.Name == "" || existingPriceList.Name == priceList.Name) &&
+		(priceList.Channel == "" || existingPriceList.Channel == priceList.Channel) &&
+		(priceList.Items == nil || existingPriceList.HasItems(priceList.Items))
+	if emptyData || sameData {
+		return nil, cmdomain.ErrNoUpdatedData
+	}
+
+	err = ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := ps.repo.UpdatePriceList(ctx, priceList)
+		if err != nil {
+			return err
+		}
+
+		return ps.audit.Record(ctx, auditdomain.ActionUpdate, auditdomain.EntityPriceList, priceList.ID, existingPriceList, priceList)
+	})
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrConflictingData) || errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("price_list", priceList.ID)
+	_ = ps.cache.Delete(ctx, cacheKey)
+
+	priceListSerialized, err := cmutil.Serialize(priceList)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, priceListSerialized, 0)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = ps.invalidateLists(ctx)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return priceList, nil
+}
+
+// DeletePriceList deletes a price list, after which its channel charges the products' own prices
+func (ps *PriceListService) DeletePriceList(ctx context.Context, id uint64) error {
+	existingPriceList, err := ps.repo.GetPriceListByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return err
+		}
+		return cmdomain.ErrInternal
+	}
+
+	err = ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := ps.repo.DeletePriceList(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return ps.audit.Record(ctx, auditdomain.ActionDelete, auditdomain.EntityPriceList, id, existingPriceList, nil)
+	})
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return err
+		}
+		return cmdomain.ErrInternal
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("price_list", id)
+	_ = ps.cache.Delete(ctx, cacheKey)
+
+	err = ps.invalidateLists(ctx)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// invalidateLists deletes the cached price lists and the cached product lists,
+// which are priced from the price lists of their channels
+func (ps *PriceListService) invalidateLists(ctx context.Context) error {
+	for _, prefix := range []string{"price_lists:*", "products:*"} {
+		err := ps.cache.DeleteByPrefix(ctx, prefix)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -6,24 +6,79 @@ import (
 	"github.com/joho/godotenv"
 )
 
-// Container contains environment variables for the application, database, cache, token, and http server
+// Container contains environment variables for the application, store, auth, database, cache, token, single sign-on, mail, file storage, and http server
 type (
 	Container struct {
-		App   *App
-		Token *Token
-		Redis *Redis
-		DB    *DB
-		HTTP  *HTTP
+		App     *App
+		Store   *Store
+		Auth    *Auth
+		Token   *Token
+		OIDC    *OIDC
+		Mail    *Mail
+		Storage *Storage
+		Redis   *Redis
+		DB      *DB
+		HTTP    *HTTP
 	}
 	// App contains all the environment variables for the application
 	App struct {
 		Name string
 		Env  string
 	}
+	// Store contains all the environment variables for the restaurant's local time and discount settings
+	Store struct {
+		Timezone      string
+		DayCutoff     string
+		DiscountLimit string
+	}
+	// Auth contains all the environment variables for registration and the initial admin
+	Auth struct {
+		Registration  string
+		AdminName     string
+		AdminEmail    string
+		AdminPassword string
+	}
 	// Token contains all the environment variables for the token service
 	Token struct {
-		SymmetricKey string
-		Duration     string
+		Type             string
+		SymmetricKey     string
+		SigningSecret    string
+		Duration         string
+		RefreshDuration  string
+		TerminalDuration string
+		RotationInterval string
+	}
+	// OIDC contains all the environment variables for single sign-on with an OpenID Connect identity provider
+	OIDC struct {
+		Issuer       string
+		ClientID     string
+		ClientSecret string
+		RedirectURL  string
+		Scopes       string
+		GroupsClaim  string
+		RoleMapping  string
+	}
+	// Mail contains all the environment variables for the mailer
+	Mail struct {
+		Driver   string
+		Host     string
+		Port     string
+		Username string
+		Password string
+		From     string
+		File     string
+		ResetURL string
+	}
+	// Storage contains all the environment variables for the file storage of uploaded images
+	Storage struct {
+		Driver    string
+		Dir       string
+		Endpoint  string
+		Region    string
+		Bucket    string
+		AccessKey string
+		SecretKey string
+		PublicURL string
 	}
 	// Redis contains all the environment variables for the cache service
 	Redis struct {
@@ -45,6 +100,7 @@ type (
 		URL            string
 		Port           string
 		AllowedOrigins string
+		TrustedProxies string
 	}
 )
 
@@ -62,9 +118,59 @@ func New() (*Container, error) {
 		Env:  os.Getenv("APP_ENV"),
 	}
 
+	store := &Store{
+		Timezone:      os.Getenv("STORE_TIMEZONE"),
+		DayCutoff:     os.Getenv("STORE_DAY_CUTOFF"),
+		DiscountLimit: os.Getenv("STORE_DISCOUNT_LIMIT"),
+	}
+
+	auth := &Auth{
+		Registration:  os.Getenv("AUTH_REGISTRATION"),
+		AdminName:     os.Getenv("ADMIN_NAME"),
+		AdminEmail:    os.Getenv("ADMIN_EMAIL"),
+		AdminPassword: os.Getenv("ADMIN_PASSWORD"),
+	}
+
 	token := &Token{
-		SymmetricKey: os.Getenv("TOKEN_SYMMETRIC_KEY"),
-		Duration:     os.Getenv("TOKEN_DURATION"),
+		Type:             os.Getenv("TOKEN_TYPE"),
+		SymmetricKey:     os.Getenv("TOKEN_SYMMETRIC_KEY"),
+		SigningSecret:    os.Getenv("TOKEN_SIGNING_SECRET"),
+		Duration:         os.Getenv("TOKEN_DURATION"),
+		RefreshDuration:  os.Getenv("TOKEN_REFRESH_DURATION"),
+		TerminalDuration: os.Getenv("TOKEN_TERMINAL_DURATION"),
+		RotationInterval: os.Getenv("TOKEN_ROTATION_INTERVAL"),
+	}
+
+	oidc := &OIDC{
+		Issuer:       os.Getenv("OIDC_ISSUER"),
+		ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+		Scopes:       os.Getenv("OIDC_SCOPES"),
+		GroupsClaim:  os.Getenv("OIDC_GROUPS_CLAIM"),
+		RoleMapping:  os.Getenv("OIDC_ROLE_MAPPING"),
+	}
+
+	mail := &Mail{
+		Driver:   os.Getenv("MAIL_DRIVER"),
+		Host:     os.Getenv("MAIL_HOST"),
+		Port:     os.Getenv("MAIL_PORT"),
+		Username: os.Getenv("MAIL_USERNAME"),
+		Password: os.Getenv("MAIL_PASSWORD"),
+		From:     os.Getenv("MAIL_FROM"),
+		File:     os.Getenv("MAIL_FILE"),
+		ResetURL: os.Getenv("MAIL_RESET_URL"),
+	}
+
+	storage := &Storage{
+		Driver:    os.Getenv("STORAGE_DRIVER"),
+		Dir:       os.Getenv("STORAGE_DIR"),
+		Endpoint:  os.Getenv("STORAGE_S3_ENDPOINT"),
+		Region:    os.Getenv("STORAGE_S3_REGION"),
+		Bucket:    os.Getenv("STORAGE_S3_BUCKET"),
+		AccessKey: os.Getenv("STORAGE_S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("STORAGE_S3_SECRET_KEY"),
+		PublicURL: os.Getenv("STORAGE_PUBLIC_URL"),
 	}
 
 	redis := &Redis{
@@ -86,11 +192,17 @@ func New() (*Container, error) {
 		URL:            os.Getenv("HTTP_URL"),
 		Port:           os.Getenv("HTTP_PORT"),
 		AllowedOrigins: os.Getenv("HTTP_ALLOWED_ORIGINS"),
+		TrustedProxies: os.Getenv("HTTP_TRUSTED_PROXIES"),
 	}
 
 	return &Container{
 		app,
+		store,
+		auth,
 		token,
+		oidc,
+		mail,
+		storage,
 		redis,
 		db,
 		http,
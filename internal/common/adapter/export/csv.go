@@ -0,0 +1,55 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+)
+
+/*CSV implements port.ExportWriter interface
+ * and writes comma-separated values
+ */
+type CSV struct {
+	writer *csv.Writer
+}
+
+// NewCSV creates a new CSV export writer
+func NewCSV(w io.Writer) *CSV {
+	return &CSV{
+		csv.NewWriter(w),
+	}
+}
+
+// WriteHeader writes the column names as the first record
+func (c *CSV) WriteHeader(columns ...string) error {
+	return c.writer.Write(columns)
+}
+
+// WriteRow writes a single record, formatting each value as a string
+func (c *CSV) WriteRow(values ...any) error {
+	record := make([]string, len(values))
+
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+			record[i] = ""
+		case time.Time:
+			record[i] = v.Format(time.RFC3339)
+		case *time.Time:
+			if v != nil {
+				record[i] = v.Format(time.RFC3339)
+			}
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+
+	return c.writer.Write(record)
+}
+
+// Close flushes the buffered records
+func (c *CSV) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
@@ -0,0 +1,17 @@
+package export
+
+import (
+	"go-restaurant/internal/common/domain"
+	"go-restaurant/internal/common/port"
+	"io"
+)
+
+// New creates a new export writer of the given format that writes to w
+func New(format domain.ExportFormat, w io.Writer) (port.ExportWriter, error) {
+	switch format {
+	case domain.XLSX:
+		return NewXLSX(w)
+	default:
+		return NewCSV(w), nil
+	}
+}
@@ -0,0 +1,86 @@
+package export
+
+import (
+	"github.com/xuri/excelize/v2"
+	"io"
+	"time"
+)
+
+// sheetName is the name of the only worksheet of the exported workbook
+const sheetName = "Sheet1"
+
+/*XLSX implements port.ExportWriter interface
+ * and provides access to the excelize library.
+ * Rows are written through a stream writer, so they are not kept in memory
+ */
+type XLSX struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	out    io.Writer
+	row    int
+}
+
+// NewXLSX creates a new XLSX export writer
+func NewXLSX(w io.Writer) (*XLSX, error) {
+	file := excelize.NewFile()
+
+	stream, err := file.NewStreamWriter(sheetName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &XLSX{
+		file,
+		stream,
+		w,
+		0,
+	}, nil
+}
+
+// WriteHeader writes the column names in the first row
+func (x *XLSX) WriteHeader(columns ...string) error {
+	values := make([]any, len(columns))
+	for i, column := range columns {
+		values[i] = column
+	}
+
+	return x.WriteRow(values...)
+}
+
+// WriteRow writes a single row of cells
+func (x *XLSX) WriteRow(values ...any) error {
+	x.row++
+
+	cells := make([]any, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case time.Time:
+			cells[i] = v.Format(time.RFC3339)
+		case *time.Time:
+			if v != nil {
+				cells[i] = v.Format(time.RFC3339)
+			}
+		default:
+			cells[i] = v
+		}
+	}
+
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+
+	return x.stream.SetRow(cell, cells)
+}
+
+// Close finishes the worksheet and writes the workbook to the underlying writer
+func (x *XLSX) Close() error {
+	defer x.file.Close()
+
+	err := x.stream.Flush()
+	if err != nil {
+		return err
+	}
+
+	return x.file.Write(x.out)
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"go-restaurant/internal/common/adapter/export"
+	"go-restaurant/internal/common/domain"
+	"go-restaurant/internal/common/port"
+	"log/slog"
+	"net/http"
+)
+
+// NewExportWriter sets the response headers for a file download and returns an export writer
+// that writes straight to the response body in the given format
+func NewExportWriter(ctx *gin.Context, format domain.ExportFormat, name string) (port.ExportWriter, error) {
+	if format == "" {
+		format = domain.CSV
+	}
+
+	writer, err := export.New(format, ctx.Writer)
+	if err != nil {
+		return nil, err
+	}
+
+	filename := fmt.Sprintf("%s.%s", name, format)
+	ctx.Header("Content-Type", format.ContentType())
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Status(http.StatusOK)
+
+	return writer, nil
+}
+
+// HandleExport finishes an export, reporting the error if it failed before anything was sent
+func HandleExport(ctx *gin.Context, writer port.ExportWriter, err error) {
+	if err == nil {
+		err = writer.Close()
+	}
+
+	if err == nil {
+		return
+	}
+
+	if ctx.Writer.Written() {
+		slog.Error("Error writing export", "path", ctx.Request.URL.Path, "error", err)
+		return
+	}
+
+	ctx.Writer.Header().Del("Content-Type")
+	ctx.Writer.Header().Del("Content-Disposition")
+	HandleError(ctx, err)
+}
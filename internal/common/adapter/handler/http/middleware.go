@@ -2,24 +2,76 @@ package http
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	auditdomain "go-restaurant/internal/audit/domain"
+	auditutil "go-restaurant/internal/audit/util"
+	"go-restaurant/internal/auth/domain"
 	"go-restaurant/internal/auth/port"
 	"go-restaurant/internal/auth/util"
 	cmdomain "go-restaurant/internal/common/domain"
-	"go-restaurant/internal/user/domain"
+	roledomain "go-restaurant/internal/role/domain"
+	roleport "go-restaurant/internal/role/port"
 	"strings"
 )
 
 const (
 	// AuthorizationHeaderKey is the key for authorization header in the request
 	AuthorizationHeaderKey = "authorization"
-	// AuthorizationType is the accepted authorization type
+	// AuthorizationType is the accepted authorization type for access tokens
 	AuthorizationType = "bearer"
+	// APIKeyAuthorizationType is the accepted authorization type for API keys
+	APIKeyAuthorizationType = "apikey"
+	// TerminalKeyHeaderKey is the key for the header carrying the key of the terminal a request is sent from
+	TerminalKeyHeaderKey = "X-Terminal-Key"
 	// AuthorizationPayloadKey is the key for authorization payload in the context
 	AuthorizationPayloadKey = "authorization_payload"
+	// RequestIDHeaderKey is the key for the request ID header in the request and response
+	RequestIDHeaderKey = "X-Request-ID"
+	// maxRequestIDLength is the longest request ID accepted from a client or proxy
+	maxRequestIDLength = 64
 )
 
-// authMiddleware is a middleware to check if the user is authenticated
-func authMiddleware(token port.TokenService) gin.HandlerFunc {
+// requestMiddleware is a middleware to give every request an ID, reusing a valid one sent by a proxy,
+// and to record the IP and request ID that changes made by the request are audited with
+func requestMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(RequestIDHeaderKey)
+		if !isValidRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		ctx.Header(RequestIDHeaderKey, requestID)
+		ctx.Set(auditutil.ActorKey, auditdomain.Actor{
+			IP:        ctx.ClientIP(),
+			RequestID: requestID,
+		})
+		ctx.Next()
+	}
+}
+
+// isValidRequestID checks that a request ID is short and only uses characters safe to log
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, char := range requestID {
+		isSafe := char >= 'a' && char <= 'z' ||
+			char >= 'A' && char <= 'Z' ||
+			char >= '0' && char <= '9' ||
+			char == '-' || char == '_' || char == '.'
+		if !isSafe {
+			return false
+		}
+	}
+
+	return true
+}
+
+// authMiddleware is a middleware to check if the user is authenticated with an access token or an API key.
+// Access tokens given by a PIN login are only accepted from the terminal they were given to,
+// which sends its key with every request
+func authMiddleware(auth port.AuthService) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(AuthorizationHeaderKey)
 
@@ -38,32 +90,65 @@ func authMiddleware(token port.TokenService) gin.HandlerFunc {
 			return
 		}
 
+		var payload *domain.TokenPayload
+		var err error
+
 		currentAuthorizationType := strings.ToLower(fields[0])
-		if currentAuthorizationType != AuthorizationType {
-			err := cmdomain.ErrInvalidAuthorizationType
+		switch currentAuthorizationType {
+		case AuthorizationType:
+			accessToken := fields[1]
+			terminalKey := ctx.GetHeader(TerminalKeyHeaderKey)
+			payload, err = auth.VerifyToken(ctx, accessToken, terminalKey)
+		case APIKeyAuthorizationType:
+			apiKey := fields[1]
+			payload, err = auth.VerifyAPIKey(ctx, apiKey)
+		default:
+			err = cmdomain.ErrInvalidAuthorizationType
+		}
+		if err != nil {
 			HandleAbort(ctx, err)
 			return
 		}
 
-		accessToken := fields[1]
-		payload, err := token.VerifyToken(accessToken)
+		actor := auditutil.GetActor(ctx)
+		actor.UserID = payload.UserID
+
+		ctx.Set(AuthorizationPayloadKey, payload)
+		ctx.Set(auditutil.ActorKey, actor)
+		ctx.Next()
+	}
+}
+
+// requirePermission is a middleware to check if the role of the user allows the permission,
+// and if the request uses an API key, that the permission is one of its scopes
+func requirePermission(roles roleport.RoleService, permission roledomain.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		payload := util.GetAuthPayload(ctx, AuthorizationPayloadKey)
+
+		isAllowed, err := roles.HasPermission(ctx, payload.Role, permission)
 		if err != nil {
 			HandleAbort(ctx, err)
 			return
 		}
 
-		ctx.Set(AuthorizationPayloadKey, payload)
+		if !isAllowed || !payload.HasScope(permission) {
+			err := cmdomain.ErrForbidden
+			HandleAbort(ctx, err)
+			return
+		}
+
 		ctx.Next()
 	}
 }
 
-// adminMiddleware is a middleware to check if the user is an admin
-func adminMiddleware() gin.HandlerFunc {
+// requireSession is a middleware to check that the user is authenticated with an access token,
+// for actions on their own account that an API key must not take
+func requireSession() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		payload := util.GetAuthPayload(ctx, AuthorizationPayloadKey)
 
-		isAdmin := payload.Role == domain.Admin
-		if !isAdmin {
+		isAPIKey := payload.APIKeyID != 0
+		if isAPIKey {
 			err := cmdomain.ErrForbidden
 			HandleAbort(ctx, err)
 			return
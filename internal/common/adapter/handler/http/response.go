@@ -46,16 +46,59 @@ var errorStatusMap = map[error]int{
 	domain.ErrDataNotFound:               http.StatusNotFound,
 	domain.ErrConflictingData:            http.StatusConflict,
 	domain.ErrInvalidCredentials:         http.StatusUnauthorized,
+	domain.ErrInvalidResetToken:          http.StatusBadRequest,
+	domain.ErrInvalidPIN:                 http.StatusUnauthorized,
+	domain.ErrInvalidTerminal:            http.StatusUnauthorized,
+	domain.ErrTerminalMismatch:           http.StatusUnauthorized,
 	domain.ErrUnauthorized:               http.StatusUnauthorized,
 	domain.ErrEmptyAuthorizationHeader:   http.StatusUnauthorized,
 	domain.ErrInvalidAuthorizationHeader: http.StatusUnauthorized,
 	domain.ErrInvalidAuthorizationType:   http.StatusUnauthorized,
 	domain.ErrInvalidToken:               http.StatusUnauthorized,
 	domain.ErrExpiredToken:               http.StatusUnauthorized,
+	domain.ErrRevokedToken:               http.StatusUnauthorized,
+	domain.ErrInvalidRefreshToken:        http.StatusUnauthorized,
+	domain.ErrInvalidAPIKey:              http.StatusUnauthorized,
+	domain.ErrExpiredAPIKey:              http.StatusUnauthorized,
 	domain.ErrForbidden:                  http.StatusForbidden,
+	domain.ErrProtectedRole:              http.StatusForbidden,
+	domain.ErrRoleNotGrantable:           http.StatusForbidden,
+	domain.ErrRoleInUse:                  http.StatusConflict,
+	domain.ErrInvalidRole:                http.StatusBadRequest,
+	domain.ErrRegistrationDisabled:       http.StatusForbidden,
+	domain.ErrInvalidInvite:              http.StatusForbidden,
+	domain.ErrSSODisabled:                http.StatusNotFound,
+	domain.ErrInvalidSSOLogin:            http.StatusUnauthorized,
+	domain.ErrSSOAccessDenied:            http.StatusForbidden,
+	domain.ErrApprovalRequired:           http.StatusForbidden,
+	domain.ErrInvalidApproval:            http.StatusForbidden,
+	domain.ErrTooManyAttempts:            http.StatusTooManyRequests,
+	domain.ErrLoginThrottled:             http.StatusTooManyRequests,
+	domain.ErrAccountLocked:              http.StatusLocked,
+	domain.ErrOrderVoided:                http.StatusConflict,
+	domain.ErrLastAdmin:                  http.StatusConflict,
+	domain.ErrRefundExceedsTotal:         http.StatusConflict,
+	domain.ErrInvalidDiscount:            http.StatusBadRequest,
 	domain.ErrNoUpdatedData:              http.StatusBadRequest,
 	domain.ErrInsufficientStock:          http.StatusBadRequest,
+	domain.ErrProductUnavailable:         http.StatusBadRequest,
+	domain.ErrProductEightySixed:         http.StatusBadRequest,
+	domain.ErrProductNotOnMenu:           http.StatusBadRequest,
+	domain.ErrInvalidBundle:              http.StatusBadRequest,
+	domain.ErrInvalidBundleChoice:        http.StatusBadRequest,
+	domain.ErrInvalidCategoryParent:      http.StatusBadRequest,
+	domain.ErrCategoryHasChildren:        http.StatusConflict,
+	domain.ErrCategoryDeleted:            http.StatusConflict,
 	domain.ErrInsufficientPayment:        http.StatusBadRequest,
+	domain.ErrPriceChangeNotInFuture:     http.StatusBadRequest,
+	domain.ErrPriceChangeInEffect:        http.StatusConflict,
+	domain.ErrInvalidDayPartWindow:       http.StatusBadRequest,
+	domain.ErrInvalidDateRange:           http.StatusBadRequest,
+	domain.ErrInvalidImportFile:          http.StatusBadRequest,
+	domain.ErrMissingImage:               http.StatusBadRequest,
+	domain.ErrImageTooLarge:              http.StatusRequestEntityTooLarge,
+	domain.ErrUnsupportedImageType:       http.StatusUnsupportedMediaType,
+	domain.ErrInvalidImage:               http.StatusBadRequest,
 }
 
 // ValidationError sends an error response for some specific request validation error
@@ -8,13 +8,22 @@ import (
 	sloggin "github.com/samber/slog-gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	audithttp "go-restaurant/internal/audit/adapter/handler/http"
 	ahttp "go-restaurant/internal/auth/adapter/handler/http"
 	"go-restaurant/internal/auth/port"
 	chttp "go-restaurant/internal/category/adapter/handler/http"
+	chhttp "go-restaurant/internal/channel/adapter/handler/http"
 	cmconfig "go-restaurant/internal/common/adapter/config"
+	dphttp "go-restaurant/internal/daypart/adapter/handler/http"
+	imghttp "go-restaurant/internal/image/adapter/handler/http"
 	ohttp "go-restaurant/internal/order/adapter/handler/http"
 	payhttp "go-restaurant/internal/payment/adapter/handler/http"
 	phttp "go-restaurant/internal/product/adapter/handler/http"
+	rhttp "go-restaurant/internal/report/adapter/handler/http"
+	rolehttp "go-restaurant/internal/role/adapter/handler/http"
+	roledomain "go-restaurant/internal/role/domain"
+	roleport "go-restaurant/internal/role/port"
+	thttp "go-restaurant/internal/terminal/adapter/handler/http"
 	uhttp "go-restaurant/internal/user/adapter/handler/http"
 	"log/slog"
 	"strings"
@@ -28,13 +37,28 @@ type Router struct {
 // NewRouter creates a new HTTP router
 func NewRouter(
 	config *cmconfig.HTTP,
-	token port.TokenService,
+	auth port.AuthService,
+	roles roleport.RoleService,
 	userHandler uhttp.UserHandler,
+	inviteHandler uhttp.InviteHandler,
 	authHandler ahttp.AuthHandler,
+	approvalHandler ahttp.ApprovalHandler,
+	passwordHandler ahttp.PasswordHandler,
+	apiKeyHandler ahttp.APIKeyHandler,
+	oidcHandler ahttp.OIDCHandler,
+	auditHandler audithttp.AuditHandler,
 	paymentHandler payhttp.PaymentHandler,
 	categoryHandler chttp.CategoryHandler,
+	dayPartHandler dphttp.DayPartHandler,
+	priceListHandler chhttp.PriceListHandler,
+	menuHandler chhttp.MenuHandler,
+	imageHandler imghttp.ImageHandler,
 	productHandler phttp.ProductHandler,
+	productPriceHandler phttp.ProductPriceHandler,
 	orderHandler ohttp.OrderHandler,
+	reportHandler rhttp.ReportHandler,
+	roleHandler rolehttp.RoleHandler,
+	terminalHandler thttp.TerminalHandler,
 ) (*Router, error) {
 	// Disable debug mode in production
 	if config.Env == "production" {
@@ -48,19 +72,65 @@ func NewRouter(
 	ginConfig.AllowOrigins = originsList
 
 	router := gin.New()
-	router.Use(sloggin.New(slog.Default()), gin.Recovery(), cors.New(ginConfig))
+	router.Use(sloggin.New(slog.Default()), gin.Recovery(), cors.New(ginConfig), requestMiddleware())
+
+	// Client IPs are used to limit failed logins, so forwarded IPs are only trusted from known proxies
+	var trustedProxies []string
+	if config.TrustedProxies != "" {
+		trustedProxies = strings.Split(config.TrustedProxies, ",")
+	}
+
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
+	}
 
 	// Custom validators
 	v, ok := binding.Validator.Engine().(*validator.Validate)
 	if ok {
+		if err := v.RegisterValidation("export_format", ExportFormatValidator); err != nil {
+			return nil, err
+		}
+
 		if err := v.RegisterValidation("user_role", uhttp.UserRoleValidator); err != nil {
 			return nil, err
 		}
 
+		if err := v.RegisterValidation("permission", rolehttp.PermissionValidator); err != nil {
+			return nil, err
+		}
+
 		if err := v.RegisterValidation("payment_type", payhttp.PaymentTypeValidator); err != nil {
 			return nil, err
 		}
 
+		if err := v.RegisterValidation("report_interval", rhttp.ReportIntervalValidator); err != nil {
+			return nil, err
+		}
+
+		if err := v.RegisterValidation("sales_group", rhttp.SalesGroupValidator); err != nil {
+			return nil, err
+		}
+
+		if err := v.RegisterValidation("product_sort", rhttp.ProductSortValidator); err != nil {
+			return nil, err
+		}
+
+		if err := v.RegisterValidation("audit_action", audithttp.AuditActionValidator); err != nil {
+			return nil, err
+		}
+
+		if err := v.RegisterValidation("audit_entity_type", audithttp.AuditEntityTypeValidator); err != nil {
+			return nil, err
+		}
+
+		if err := v.RegisterValidation("clock", dphttp.ClockValidator); err != nil {
+			return nil, err
+		}
+
+		if err := v.RegisterValidation("channel", chhttp.ChannelValidator); err != nil {
+			return nil, err
+		}
+
 	}
 
 	// Swagger
@@ -72,60 +142,152 @@ func NewRouter(
 		{
 			user.POST("/", userHandler.Register)
 			user.POST("/login", authHandler.Login)
+			user.POST("/login/pin", authHandler.PINLogin)
 
-			authUser := user.Group("/").Use(authMiddleware(token))
+			authUser := user.Group("/").Use(authMiddleware(auth))
 			{
-				authUser.GET("/", userHandler.ListUsers)
-				authUser.GET("/:id", userHandler.GetUser)
-
-				admin := authUser.Use(adminMiddleware())
-				{
-					admin.PUT("/:id", userHandler.UpdateUser)
-					admin.DELETE("/:id", userHandler.DeleteUser)
-				}
+				authUser.PUT("/pin", requireSession(), userHandler.SetPIN)
+				authUser.PUT("/password", requireSession(), userHandler.ChangePassword)
+				authUser.GET("/", requirePermission(roles, roledomain.UserRead), userHandler.ListUsers)
+				authUser.GET("/:id", requirePermission(roles, roledomain.UserRead), userHandler.GetUser)
+				authUser.PUT("/:id", requirePermission(roles, roledomain.UserWrite), userHandler.UpdateUser)
+				authUser.DELETE("/:id", requirePermission(roles, roledomain.UserWrite), userHandler.DeleteUser)
+				authUser.POST("/:id/restore", requirePermission(roles, roledomain.RecordRestore), userHandler.RestoreUser)
+				authUser.POST("/:id/unlock", requirePermission(roles, roledomain.UserWrite), authHandler.UnlockUser)
 			}
 		}
-		payment := v1.Group("/payments").Use(authMiddleware(token))
+		invite := v1.Group("/invites").Use(authMiddleware(auth))
 		{
-			payment.GET("/", paymentHandler.ListPayments)
-			payment.GET("/:id", paymentHandler.GetPayment)
-
-			admin := payment.Use(adminMiddleware())
-			{
-				admin.POST("/", paymentHandler.CreatePayment)
-				admin.PUT("/:id", paymentHandler.UpdatePayment)
-				admin.DELETE("/:id", paymentHandler.DeletePayment)
-			}
+			invite.GET("/", requirePermission(roles, roledomain.UserRead), inviteHandler.ListInvites)
+			invite.POST("/", requirePermission(roles, roledomain.UserWrite), inviteHandler.CreateInvite)
+			invite.DELETE("/:id", requirePermission(roles, roledomain.UserWrite), inviteHandler.DeleteInvite)
 		}
-		category := v1.Group("/categories").Use(authMiddleware(token))
+		authGroup := v1.Group("/auth")
 		{
-			category.GET("/", categoryHandler.ListCategories)
-			category.GET("/:id", categoryHandler.GetCategory)
+			authGroup.GET("/keys", authHandler.ListPublicKeys)
+			authGroup.POST("/refresh", authHandler.Refresh)
+			authGroup.POST("/password/forgot", passwordHandler.ForgotPassword)
+			authGroup.POST("/password/reset", passwordHandler.ResetPassword)
+			authGroup.GET("/oidc/login", oidcHandler.StartLogin)
+			authGroup.GET("/oidc/callback", oidcHandler.Callback)
 
-			admin := category.Use(adminMiddleware())
+			authSession := authGroup.Group("/").Use(authMiddleware(auth))
 			{
-				admin.POST("/", categoryHandler.CreateCategory)
-				admin.PUT("/:id", categoryHandler.UpdateCategory)
-				admin.DELETE("/:id", categoryHandler.DeleteCategory)
+				authSession.POST("/logout", authHandler.Logout)
 			}
 		}
-		product := v1.Group("/products").Use(authMiddleware(token))
+		approval := v1.Group("/approvals").Use(authMiddleware(auth))
 		{
-			product.GET("/", productHandler.ListProducts)
-			product.GET("/:id", productHandler.GetProduct)
-
-			admin := product.Use(adminMiddleware())
-			{
-				admin.POST("/", productHandler.CreateProduct)
-				admin.PUT("/:id", productHandler.UpdateProduct)
-				admin.DELETE("/:id", productHandler.DeleteProduct)
-			}
+			approval.POST("/", requireSession(), approvalHandler.CreateApprovalToken)
+		}
+		apiKey := v1.Group("/api-keys").Use(authMiddleware(auth))
+		{
+			apiKey.GET("/", requirePermission(roles, roledomain.APIKeyRead), apiKeyHandler.ListAPIKeys)
+			apiKey.GET("/:id", requirePermission(roles, roledomain.APIKeyRead), apiKeyHandler.GetAPIKey)
+			apiKey.POST("/", requirePermission(roles, roledomain.APIKeyWrite), apiKeyHandler.CreateAPIKey)
+			apiKey.DELETE("/:id", requirePermission(roles, roledomain.APIKeyWrite), apiKeyHandler.DeleteAPIKey)
+		}
+		role := v1.Group("/roles").Use(authMiddleware(auth))
+		{
+			role.GET("/", requirePermission(roles, roledomain.RoleRead), roleHandler.ListRoles)
+			role.GET("/permissions", requirePermission(roles, roledomain.RoleRead), roleHandler.ListPermissions)
+			role.GET("/:name", requirePermission(roles, roledomain.RoleRead), roleHandler.GetRole)
+			role.POST("/", requirePermission(roles, roledomain.RoleWrite), roleHandler.CreateRole)
+			role.PUT("/:name", requirePermission(roles, roledomain.RoleWrite), roleHandler.UpdateRole)
+			role.DELETE("/:name", requirePermission(roles, roledomain.RoleWrite), roleHandler.DeleteRole)
+		}
+		audit := v1.Group("/audit").Use(authMiddleware(auth))
+		{
+			audit.GET("/", requirePermission(roles, roledomain.AuditRead), auditHandler.ListEntries)
+		}
+		terminal := v1.Group("/terminals").Use(authMiddleware(auth))
+		{
+			terminal.GET("/", requirePermission(roles, roledomain.TerminalRead), terminalHandler.ListTerminals)
+			terminal.GET("/:id", requirePermission(roles, roledomain.TerminalRead), terminalHandler.GetTerminal)
+			terminal.POST("/", requirePermission(roles, roledomain.TerminalWrite), terminalHandler.RegisterTerminal)
+			terminal.DELETE("/:id", requirePermission(roles, roledomain.TerminalWrite), terminalHandler.DeleteTerminal)
+		}
+		image := v1.Group("/images")
+		{
+			image.GET("/*key", imageHandler.GetImage)
+		}
+		payment := v1.Group("/payments").Use(authMiddleware(auth))
+		{
+			payment.GET("/", requirePermission(roles, roledomain.PaymentRead), paymentHandler.ListPayments)
+			payment.GET("/:id", requirePermission(roles, roledomain.PaymentRead), paymentHandler.GetPayment)
+			payment.POST("/", requirePermission(roles, roledomain.PaymentWrite), paymentHandler.CreatePayment)
+			payment.PUT("/:id", requirePermission(roles, roledomain.PaymentWrite), paymentHandler.UpdatePayment)
+			payment.PUT("/:id/logo", requirePermission(roles, roledomain.PaymentWrite), paymentHandler.UploadPaymentLogo)
+			payment.DELETE("/:id", requirePermission(roles, roledomain.PaymentWrite), paymentHandler.DeletePayment)
+			payment.POST("/:id/restore", requirePermission(roles, roledomain.RecordRestore), paymentHandler.RestorePayment)
+		}
+		category := v1.Group("/categories").Use(authMiddleware(auth))
+		{
+			category.GET("/", requirePermission(roles, roledomain.CategoryRead), categoryHandler.ListCategories)
+			category.GET("/tree", requirePermission(roles, roledomain.ProductRead), productHandler.GetMenu)
+			category.GET("/:id", requirePermission(roles, roledomain.CategoryRead), categoryHandler.GetCategory)
+			category.POST("/", requirePermission(roles, roledomain.CategoryWrite), categoryHandler.CreateCategory)
+			category.PUT("/:id", requirePermission(roles, roledomain.CategoryWrite), categoryHandler.UpdateCategory)
+			category.DELETE("/:id", requirePermission(roles, roledomain.CategoryWrite), categoryHandler.DeleteCategory)
+			category.POST("/:id/restore", requirePermission(roles, roledomain.RecordRestore), categoryHandler.RestoreCategory)
+		}
+		dayPart := v1.Group("/day-parts").Use(authMiddleware(auth))
+		{
+			dayPart.GET("/", requirePermission(roles, roledomain.ProductRead), dayPartHandler.ListDayParts)
+			dayPart.GET("/:id", requirePermission(roles, roledomain.ProductRead), dayPartHandler.GetDayPart)
+			dayPart.POST("/", requirePermission(roles, roledomain.ProductWrite), dayPartHandler.CreateDayPart)
+			dayPart.PUT("/:id", requirePermission(roles, roledomain.ProductWrite), dayPartHandler.UpdateDayPart)
+			dayPart.DELETE("/:id", requirePermission(roles, roledomain.ProductWrite), dayPartHandler.DeleteDayPart)
+		}
+		priceList := v1.Group("/price-lists").Use(authMiddleware(auth))
+		{
+			priceList.GET("/", requirePermission(roles, roledomain.ProductRead), priceListHandler.ListPriceLists)
+			priceList.GET("/:id", requirePermission(roles, roledomain.ProductRead), priceListHandler.GetPriceList)
+			priceList.POST("/", requirePermission(roles, roledomain.ProductWrite), priceListHandler.CreatePriceList)
+			priceList.PUT("/:id", requirePermission(roles, roledomain.ProductWrite), priceListHandler.UpdatePriceList)
+			priceList.DELETE("/:id", requirePermission(roles, roledomain.ProductWrite), priceListHandler.DeletePriceList)
+		}
+		menu := v1.Group("/menus").Use(authMiddleware(auth))
+		{
+			menu.GET("/", requirePermission(roles, roledomain.ProductRead), menuHandler.ListMenus)
+			menu.GET("/:id", requirePermission(roles, roledomain.ProductRead), menuHandler.GetMenu)
+			menu.POST("/", requirePermission(roles, roledomain.ProductWrite), menuHandler.CreateMenu)
+			menu.PUT("/:id", requirePermission(roles, roledomain.ProductWrite), menuHandler.UpdateMenu)
+			menu.DELETE("/:id", requirePermission(roles, roledomain.ProductWrite), menuHandler.DeleteMenu)
+		}
+		product := v1.Group("/products").Use(authMiddleware(auth))
+		{
+			product.GET("/", requirePermission(roles, roledomain.ProductRead), productHandler.ListProducts)
+			product.GET("/:id", requirePermission(roles, roledomain.ProductRead), productHandler.GetProduct)
+			product.GET("/export", requirePermission(roles, roledomain.ProductWrite), productHandler.ExportProducts)
+			product.POST("/import", requirePermission(roles, roledomain.ProductWrite), productHandler.ImportProducts)
+			product.POST("/", requirePermission(roles, roledomain.ProductWrite), productHandler.CreateProduct)
+			product.PUT("/:id", requirePermission(roles, roledomain.ProductWrite), productHandler.UpdateProduct)
+			product.PUT("/:id/image", requirePermission(roles, roledomain.ProductWrite), productHandler.UploadProductImage)
+			product.PUT("/:id/availability", requirePermission(roles, roledomain.ProductAvailability), productHandler.SetProductAvailability)
+			product.DELETE("/:id", requirePermission(roles, roledomain.ProductWrite), productHandler.DeleteProduct)
+			product.POST("/:id/restore", requirePermission(roles, roledomain.RecordRestore), productHandler.RestoreProduct)
+			product.GET("/:id/prices", requirePermission(roles, roledomain.ProductRead), productPriceHandler.ListProductPrices)
+			product.POST("/:id/prices", requirePermission(roles, roledomain.ProductWrite), productPriceHandler.ScheduleProductPrice)
+			product.DELETE("/:id/prices/:price_id", requirePermission(roles, roledomain.ProductWrite), productPriceHandler.CancelProductPrice)
+		}
+		order := v1.Group("/orders").Use(authMiddleware(auth))
+		{
+			order.POST("/", requirePermission(roles, roledomain.OrderCreate), orderHandler.CreateOrder)
+			order.GET("/", requirePermission(roles, roledomain.OrderRead), orderHandler.ListOrders)
+			order.GET("/:id", requirePermission(roles, roledomain.OrderRead), orderHandler.GetOrder)
+			order.GET("/export", requirePermission(roles, roledomain.OrderExport), orderHandler.ExportOrders)
+			order.POST("/:id/void", requirePermission(roles, roledomain.OrderCreate), orderHandler.VoidOrder)
+			order.POST("/:id/refund", requirePermission(roles, roledomain.OrderCreate), orderHandler.RefundOrder)
 		}
-		order := v1.Group("/orders").Use(authMiddleware(token))
+		report := v1.Group("/reports").Use(authMiddleware(auth), requirePermission(roles, roledomain.ReportRead))
 		{
-			order.POST("/", orderHandler.CreateOrder)
-			order.GET("/", orderHandler.ListOrders)
-			order.GET("/:id", orderHandler.GetOrder)
+			report.GET("/sales", reportHandler.GetSalesReport)
+			report.GET("/sales/export", reportHandler.ExportSalesReport)
+			report.GET("/products", reportHandler.GetProductPerformance)
+			report.GET("/products/export", reportHandler.ExportProductPerformance)
+			report.GET("/products/unsold", reportHandler.ListUnsoldProducts)
+			report.GET("/products/unsold/export", reportHandler.ExportUnsoldProducts)
 		}
 	}
 
@@ -0,0 +1,18 @@
+package http
+
+import (
+	"github.com/go-playground/validator/v10"
+	"go-restaurant/internal/common/domain"
+)
+
+// ExportFormatValidator is a custom validator for validating export formats
+var ExportFormatValidator validator.Func = func(fl validator.FieldLevel) bool {
+	format := fl.Field().Interface().(domain.ExportFormat)
+
+	switch format {
+	case "csv", "xlsx":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,51 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"go-restaurant/internal/common/domain"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+/*Log implements port.Mailer interface
+ * for local development, logging that emails were sent or appending them to a file instead of sending them.
+ * Only the file gets the body of an email, which may carry secrets such as password reset links
+ */
+type Log struct {
+	file string
+	mu   sync.Mutex
+}
+
+// NewLog creates a new log mailer. Emails are appended to the file if one is given
+func NewLog(file string) *Log {
+	return &Log{
+		file: file,
+	}
+}
+
+// Send logs the recipient and subject of the email, or appends the whole email to the file
+func (l *Log) Send(ctx context.Context, mail *domain.Mail) error {
+	if l.file == "" {
+		slog.Info("Sending email", "to", mail.To, "subject", mail.Subject)
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.file, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(f, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC1123Z), mail.To, mail.Subject, mail.Body)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,25 @@
+package mailer
+
+import (
+	"go-restaurant/internal/common/adapter/config"
+	"go-restaurant/internal/common/domain"
+	"go-restaurant/internal/common/port"
+)
+
+// New creates a new mailer of the driver selected by the config. The driver must be given
+// outside development, so that emails are never silently not sent
+func New(config *config.Mail, env string) (port.Mailer, error) {
+	switch config.Driver {
+	case "":
+		if env != "development" {
+			return nil, domain.ErrMissingMailDriver
+		}
+		return NewLog(config.File), nil
+	case "log":
+		return NewLog(config.File), nil
+	case "smtp":
+		return NewSMTP(config), nil
+	default:
+		return nil, domain.ErrInvalidMailDriver
+	}
+}
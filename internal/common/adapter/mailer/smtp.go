@@ -0,0 +1,113 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"go-restaurant/internal/common/adapter/config"
+	"go-restaurant/internal/common/domain"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// headerReplacer strips line breaks from header values, so they cannot add headers of their own
+var headerReplacer = strings.NewReplacer("\r", "", "\n", "")
+
+/*SMTP implements port.Mailer interface
+ * and sends emails through an SMTP server
+ */
+type SMTP struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// NewSMTP creates a new SMTP mailer
+func NewSMTP(config *config.Mail) *SMTP {
+	return &SMTP{
+		host:     config.Host,
+		port:     config.Port,
+		username: config.Username,
+		password: config.Password,
+		from:     config.From,
+	}
+}
+
+// Send sends the email, upgrading the connection with STARTTLS when the server supports it
+// and authenticating when a username is configured
+func (s *SMTP) Send(ctx context.Context, mail *domain.Mail) error {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.host, s.port))
+	if err != nil {
+		return err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if ok {
+		err = conn.SetDeadline(deadline)
+		if err != nil {
+			conn.Close()
+			return err
+		}
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	hasStartTLS, _ := client.Extension("STARTTLS")
+	if hasStartTLS {
+		err = client.StartTLS(&tls.Config{ServerName: s.host})
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.username != "" {
+		err = client.Auth(smtp.PlainAuth("", s.username, s.password, s.host))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.Mail(s.from)
+	if err != nil {
+		return err
+	}
+
+	err = client.Rcpt(mail.To)
+	if err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		headerReplacer.Replace(s.from),
+		headerReplacer.Replace(mail.To),
+		headerReplacer.Replace(mail.Subject),
+		time.Now().Format(time.RFC1123Z),
+		strings.ReplaceAll(mail.Body, "\n", "\r\n"),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,19 @@
+package file
+
+import (
+	"go-restaurant/internal/common/adapter/config"
+	"go-restaurant/internal/common/domain"
+	"go-restaurant/internal/common/port"
+)
+
+// New creates a new file storage of the driver selected by the config
+func New(config *config.Storage) (port.FileStorage, error) {
+	switch config.Driver {
+	case "", "local":
+		return NewLocal(config.Dir), nil
+	case "s3":
+		return NewS3(config)
+	default:
+		return nil, domain.ErrInvalidStorageDriver
+	}
+}
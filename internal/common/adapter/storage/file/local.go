@@ -0,0 +1,125 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"go-restaurant/internal/common/domain"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+)
+
+// defaultDir is the directory files are stored in when none is configured
+const defaultDir = "uploads"
+
+/*Local implements port.FileStorage interface
+ * and stores files in a directory of the local filesystem
+ */
+type Local struct {
+	dir string
+}
+
+// NewLocal creates a new local file storage. Files are stored in the uploads directory unless another one is given
+func NewLocal(dir string) *Local {
+	if dir == "" {
+		dir = defaultDir
+	}
+
+	return &Local{
+		dir: dir,
+	}
+}
+
+// Put writes the file to a temporary file next to its path first and renames it into place,
+// so a file being read is never seen half written
+func (l *Local) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = io.CopyN(f, r, size)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// Get opens the file, guessing its content type from its extension
+func (l *Local) Get(ctx context.Context, key string) (*domain.File, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if info.IsDir() {
+		f.Close()
+		return nil, domain.ErrDataNotFound
+	}
+
+	return &domain.File{
+		Body:        f,
+		ContentType: mime.TypeByExtension(filepath.Ext(path)),
+		Size:        info.Size(),
+		ModifiedAt:  info.ModTime(),
+	}, nil
+}
+
+// Delete removes the file, ignoring files that do not exist
+func (l *Local) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// path resolves a key to a path inside the storage directory, rejecting keys that would escape it
+func (l *Local) path(key string) (string, error) {
+	key = filepath.FromSlash(key)
+	if !filepath.IsLocal(key) {
+		return "", domain.ErrDataNotFound
+	}
+
+	return filepath.Join(l.dir, key), nil
+}
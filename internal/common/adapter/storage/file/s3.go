@@ -0,0 +1,227 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go-restaurant/internal/common/adapter/config"
+	"go-restaurant/internal/common/domain"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// defaultRegion is the region requests are signed for when none is configured, which MinIO accepts by default
+const defaultRegion = "us-east-1"
+
+/*S3 implements port.FileStorage interface
+ * and stores files in a bucket of an S3-compatible object storage, such as AWS S3 or MinIO,
+ * addressing it path-style and signing requests with AWS Signature Version 4
+ */
+type S3 struct {
+	client    *http.Client
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+}
+
+// NewS3 creates a new S3 file storage, failing when the endpoint is not an absolute http or https URL
+func NewS3(config *config.Storage) (*S3, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(config.Endpoint, "/"))
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, domain.ErrInvalidStorageEndpoint
+	}
+
+	region := config.Region
+	if region == "" {
+		region = defaultRegion
+	}
+
+	return &S3{
+		client:    &http.Client{Timeout: time.Minute},
+		endpoint:  endpoint,
+		region:    region,
+		bucket:    config.Bucket,
+		accessKey: config.AccessKey,
+		secretKey: config.SecretKey,
+	}, nil
+}
+
+// Put uploads the file as an object of the bucket
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	body := make([]byte, size)
+	_, err := io.ReadFull(r, body)
+	if err != nil {
+		return err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	rsp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+
+	return rsp.Body.Close()
+}
+
+// Get downloads the object of the bucket, streaming its body
+func (s *S3) Get(ctx context.Context, key string) (*domain.File, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	modifiedAt, _ := http.ParseTime(rsp.Header.Get("Last-Modified"))
+
+	return &domain.File{
+		Body:        rsp.Body,
+		ContentType: rsp.Header.Get("Content-Type"),
+		Size:        rsp.ContentLength,
+		ModifiedAt:  modifiedAt,
+	}, nil
+}
+
+// Delete deletes the object of the bucket, which S3 does not fail on when it does not exist
+func (s *S3) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+
+	return rsp.Body.Close()
+}
+
+// newRequest creates a request for an object of the bucket, signed with AWS Signature Version 4
+func (s *S3) newRequest(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	if !validKey(key) {
+		return nil, domain.ErrDataNotFound
+	}
+
+	objectURL := *s.endpoint
+	objectURL.Path = "/" + s.bucket + "/" + key
+	objectURL.RawPath = escapePath(objectURL.Path)
+
+	req, err := http.NewRequestWithContext(ctx, method, objectURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	s.sign(req, body, time.Now().UTC())
+
+	return req, nil
+}
+
+// do sends a request, mapping a missing object to domain.ErrDataNotFound and other error responses to errors
+// carrying the S3 error code. The body of a successful response must be closed by the caller
+func (s *S3) do(req *http.Request) (*http.Response, error) {
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.StatusCode >= http.StatusOK && rsp.StatusCode < http.StatusMultipleChoices {
+		return rsp, nil
+	}
+
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode == http.StatusNotFound {
+		return nil, domain.ErrDataNotFound
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(rsp.Body, 1024))
+	return nil, fmt.Errorf("s3 %s %s: %s: %s", req.Method, req.URL.Path, rsp.Status, msg)
+}
+
+// sign signs a request with AWS Signature Version 4, signing the host, payload hash and date headers
+func (s *S3) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", s.accessKey, scope, signedHeaders, signature))
+}
+
+// validKey reports whether a key is a clean relative path naming a file, so the object it addresses is in the bucket
+func validKey(key string) bool {
+	return key != "." && path.Clean(key) == key && filepath.IsLocal(key)
+}
+
+// escapePath escapes every segment of a path the way AWS Signature Version 4 expects,
+// leaving only unreserved characters and the slashes between segments as they are
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(url.QueryEscape(segment), "+", "%20")
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// sha256Hex returns the hex encoded SHA-256 hash of data
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data with a key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,172 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go-restaurant/internal/common/adapter/config"
+	"go-restaurant/internal/common/domain"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestValidKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"products/1/image.png", true},
+		{"thumbnails/products/1/image.png", true},
+		{"image.png", true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"../image.png", false},
+		{"products/../../image.png", false},
+		{"products/./1/image.png", false},
+		{"products//1/image.png", false},
+		{"products/1/", false},
+		{"/products/1/image.png", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got := validKey(tt.key)
+			if got != tt.want {
+				t.Fatalf("validKey(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewS3Endpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		wantErr  bool
+	}{
+		{endpoint: "http://localhost:9000"},
+		{endpoint: "https://s3.eu-central-1.amazonaws.com/"},
+		{endpoint: "", wantErr: true},
+		{endpoint: "localhost:9000", wantErr: true},
+		{endpoint: "ftp://localhost:9000", wantErr: true},
+		{endpoint: "http://", wantErr: true},
+		{endpoint: "http://local host:9000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			_, err := NewS3(&config.Storage{Driver: "s3", Endpoint: tt.endpoint, Bucket: "uploads"})
+
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidStorageEndpoint) {
+					t.Fatalf("got error %v, want %v", err, domain.ErrInvalidStorageEndpoint)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+		})
+	}
+}
+
+func TestNewInvalidS3Endpoint(t *testing.T) {
+	_, err := New(&config.Storage{Driver: "s3", Endpoint: "localhost:9000"})
+	if !errors.Is(err, domain.ErrInvalidStorageEndpoint) {
+		t.Fatalf("got error %v, want %v", err, domain.ErrInvalidStorageEndpoint)
+	}
+}
+
+// mockS3 is a local object storage that keeps the objects put into it by path
+// and checks that every request is signed
+type mockS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (ms *mockS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		ms.objects[r.URL.Path] = body
+	case http.MethodGet:
+		body, ok := ms.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(body)
+	case http.MethodDelete:
+		delete(ms.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3(t *testing.T) {
+	ms := &mockS3{objects: make(map[string][]byte)}
+	server := httptest.NewServer(ms)
+	t.Cleanup(server.Close)
+
+	s, err := NewS3(&config.Storage{
+		Driver:    "s3",
+		Endpoint:  server.URL,
+		Bucket:    "uploads",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	ctx := context.Background()
+	key := "products/1/image.png"
+
+	err = s.Put(ctx, key, bytes.NewReader([]byte("image")), 5, "image/png")
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if _, ok := ms.objects["/uploads/"+key]; !ok {
+		t.Fatal("object was not stored path-style in the bucket")
+	}
+
+	file, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	body, _ := io.ReadAll(file.Body)
+	file.Body.Close()
+
+	if string(body) != "image" {
+		t.Fatalf("got body %q, want %q", body, "image")
+	}
+
+	err = s.Delete(ctx, key)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	_, err = s.Get(ctx, key)
+	if !errors.Is(err, domain.ErrDataNotFound) {
+		t.Fatalf("got error %v for a deleted object, want %v", err, domain.ErrDataNotFound)
+	}
+
+	_, err = s.Get(ctx, "../other/image.png")
+	if !errors.Is(err, domain.ErrDataNotFound) {
+		t.Fatalf("got error %v for a key outside the bucket, want %v", err, domain.ErrDataNotFound)
+	}
+}
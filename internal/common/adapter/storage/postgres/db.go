@@ -8,6 +8,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go-restaurant/internal/common/adapter/config"
@@ -79,6 +80,49 @@ func (db *DB) Migrate() error {
 	return nil
 }
 
+// txKey is the context key of the transaction started by WithinTransaction
+type txKey struct{}
+
+// WithinTransaction runs fn in a transaction that the queries made with its context join,
+// committing it if fn succeeds and rolling it back otherwise
+func (db *DB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return pgx.BeginFunc(ctx, db, func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// Begin starts a transaction, or a savepoint if the context is already within a transaction
+func (db *DB) Begin(ctx context.Context) (pgx.Tx, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Begin(ctx)
+	}
+	return db.Pool.Begin(ctx)
+}
+
+// Exec executes the query in the transaction of the context if there is one
+func (db *DB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Exec(ctx, sql, args...)
+	}
+	return db.Pool.Exec(ctx, sql, args...)
+}
+
+// Query runs the query in the transaction of the context if there is one
+func (db *DB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Query(ctx, sql, args...)
+	}
+	return db.Pool.Query(ctx, sql, args...)
+}
+
+// QueryRow runs the query in the transaction of the context if there is one
+func (db *DB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.QueryRow(ctx, sql, args...)
+	}
+	return db.Pool.QueryRow(ctx, sql, args...)
+}
+
 // ErrorCode returns the error code of the given error
 func (db *DB) ErrorCode(err error) string {
 	var pgErr *pgconn.PgError
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "orders_created_at";
\ No newline at end of file
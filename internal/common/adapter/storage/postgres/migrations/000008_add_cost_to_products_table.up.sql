@@ -0,0 +1,4 @@
+ALTER TABLE
+    "products"
+ADD
+    COLUMN "cost" decimal(18, 2) NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1,26 @@
+CREATE TYPE "users_role_enum" AS ENUM ('admin', 'cashier');
+
+ALTER TABLE
+    "users" DROP CONSTRAINT "fk_roles_users";
+
+UPDATE
+    "users"
+SET
+    "role" = 'cashier'
+WHERE
+    "role" NOT IN ('admin', 'cashier');
+
+ALTER TABLE
+    "users"
+ALTER COLUMN
+    "role" DROP DEFAULT,
+ALTER COLUMN
+    "role" DROP NOT NULL,
+ALTER COLUMN
+    "role" TYPE users_role_enum USING "role"::users_role_enum,
+ALTER COLUMN
+    "role" SET DEFAULT 'cashier';
+
+DROP TABLE IF EXISTS "role_permissions";
+
+DROP TABLE IF EXISTS "roles";
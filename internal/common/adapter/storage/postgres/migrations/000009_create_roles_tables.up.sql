@@ -0,0 +1,87 @@
+CREATE TABLE "roles" (
+    "name" varchar PRIMARY KEY,
+    "description" varchar NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "role_permissions" (
+    "role" varchar NOT NULL,
+    "permission" varchar NOT NULL,
+    PRIMARY KEY ("role", "permission")
+);
+
+ALTER TABLE
+    "role_permissions"
+ADD
+    CONSTRAINT "fk_roles_role_permissions" FOREIGN KEY ("role") REFERENCES "roles" ("name") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+INSERT INTO
+    "roles" ("name", "description")
+VALUES
+    ('admin', 'Full access to every resource'),
+    ('manager', 'Runs the floor, the menu and the reports'),
+    ('cashier', 'Takes orders and payments'),
+    ('waiter', 'Takes orders at the tables'),
+    ('kitchen', 'Prepares orders'),
+    ('accountant', 'Reviews sales and exports');
+
+INSERT INTO
+    "role_permissions" ("role", "permission")
+VALUES
+    ('manager', 'user.read'),
+    ('manager', 'payment.read'),
+    ('manager', 'payment.write'),
+    ('manager', 'category.read'),
+    ('manager', 'category.write'),
+    ('manager', 'product.read'),
+    ('manager', 'product.write'),
+    ('manager', 'order.create'),
+    ('manager', 'order.read'),
+    ('manager', 'order.void'),
+    ('manager', 'order.export'),
+    ('manager', 'report.read'),
+    ('cashier', 'user.read'),
+    ('cashier', 'payment.read'),
+    ('cashier', 'category.read'),
+    ('cashier', 'product.read'),
+    ('cashier', 'order.create'),
+    ('cashier', 'order.read'),
+    ('waiter', 'category.read'),
+    ('waiter', 'product.read'),
+    ('waiter', 'order.create'),
+    ('waiter', 'order.read'),
+    ('kitchen', 'category.read'),
+    ('kitchen', 'product.read'),
+    ('kitchen', 'order.read'),
+    ('accountant', 'payment.read'),
+    ('accountant', 'category.read'),
+    ('accountant', 'product.read'),
+    ('accountant', 'order.read'),
+    ('accountant', 'order.export'),
+    ('accountant', 'report.read');
+
+UPDATE
+    "users"
+SET
+    "role" = 'cashier'
+WHERE
+    "role" IS NULL;
+
+ALTER TABLE
+    "users"
+ALTER COLUMN
+    "role" DROP DEFAULT,
+ALTER COLUMN
+    "role" TYPE varchar USING "role"::varchar,
+ALTER COLUMN
+    "role" SET NOT NULL,
+ALTER COLUMN
+    "role" SET DEFAULT 'cashier';
+
+ALTER TABLE
+    "users"
+ADD
+    CONSTRAINT "fk_roles_users" FOREIGN KEY ("role") REFERENCES "roles" ("name") ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+DROP TYPE IF EXISTS "users_role_enum";
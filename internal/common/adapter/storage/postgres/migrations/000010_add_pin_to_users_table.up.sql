@@ -0,0 +1,4 @@
+ALTER TABLE
+    "users"
+ADD
+    COLUMN "pin" varchar NOT NULL DEFAULT '';
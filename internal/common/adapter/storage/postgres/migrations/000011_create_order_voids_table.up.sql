@@ -0,0 +1,24 @@
+CREATE TABLE "order_voids" (
+    "order_id" bigint PRIMARY KEY,
+    "user_id" bigint NOT NULL,
+    "approved_by" bigint NOT NULL,
+    "reason" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "order_voids_approved_by" ON "order_voids" ("approved_by");
+
+ALTER TABLE
+    "order_voids"
+ADD
+    CONSTRAINT "fk_orders_order_voids" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "order_voids"
+ADD
+    CONSTRAINT "fk_users_order_voids" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "order_voids"
+ADD
+    CONSTRAINT "fk_approvers_order_voids" FOREIGN KEY ("approved_by") REFERENCES "users" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION;
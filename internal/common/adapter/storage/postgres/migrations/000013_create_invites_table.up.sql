@@ -0,0 +1,27 @@
+CREATE TABLE "invites" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "code_hash" varchar NOT NULL,
+    "role" varchar NOT NULL,
+    "created_by" bigint NOT NULL,
+    "used_by" bigint,
+    "used_at" timestamptz,
+    "expires_at" timestamptz NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "invite_code_hash" ON "invites" ("code_hash");
+
+ALTER TABLE
+    "invites"
+ADD
+    CONSTRAINT "fk_roles_invites" FOREIGN KEY ("role") REFERENCES "roles" ("name") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "invites"
+ADD
+    CONSTRAINT "fk_creators_invites" FOREIGN KEY ("created_by") REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "invites"
+ADD
+    CONSTRAINT "fk_users_invites" FOREIGN KEY ("used_by") REFERENCES "users" ("id") ON DELETE SET NULL ON UPDATE NO ACTION;
@@ -0,0 +1,20 @@
+CREATE TABLE "api_keys" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "prefix" varchar NOT NULL,
+    "key_hash" varchar NOT NULL,
+    "user_id" bigint NOT NULL,
+    "scopes" varchar[] NOT NULL DEFAULT '{}',
+    "expires_at" timestamptz,
+    "last_used_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "api_key_prefix" ON "api_keys" ("prefix");
+
+CREATE INDEX "api_keys_user_id" ON "api_keys" ("user_id");
+
+ALTER TABLE
+    "api_keys"
+ADD
+    CONSTRAINT "fk_users_api_keys" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
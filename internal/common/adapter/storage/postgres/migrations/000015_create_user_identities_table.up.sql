@@ -0,0 +1,17 @@
+CREATE TABLE "user_identities" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "issuer" varchar NOT NULL,
+    "subject" varchar NOT NULL,
+    "user_id" bigint NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "provisioned" boolean NOT NULL DEFAULT false
+);
+
+CREATE UNIQUE INDEX "user_identity_issuer_subject" ON "user_identities" ("issuer", "subject");
+
+CREATE INDEX "user_identities_user_id" ON "user_identities" ("user_id");
+
+ALTER TABLE
+    "user_identities"
+ADD
+    CONSTRAINT "fk_users_user_identities" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
@@ -0,0 +1,33 @@
+CREATE TABLE "audit_entries" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "actor_id" bigint,
+    "action" varchar NOT NULL,
+    "entity_type" varchar NOT NULL,
+    "entity_id" bigint NOT NULL,
+    "before" jsonb,
+    "after" jsonb,
+    "changes" jsonb NOT NULL DEFAULT '{}',
+    "ip" varchar NOT NULL DEFAULT '',
+    "request_id" varchar NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "audit_entries_entity" ON "audit_entries" ("entity_type", "entity_id");
+
+CREATE INDEX "audit_entries_actor_id" ON "audit_entries" ("actor_id");
+
+CREATE INDEX "audit_entries_created_at" ON "audit_entries" ("created_at");
+
+CREATE FUNCTION "audit_entries_append_only"() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit entries cannot be updated or deleted';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_entries_no_update_or_delete"
+    BEFORE UPDATE OR DELETE ON "audit_entries"
+    FOR EACH ROW EXECUTE FUNCTION "audit_entries_append_only"();
+
+CREATE TRIGGER "audit_entries_no_truncate"
+    BEFORE TRUNCATE ON "audit_entries"
+    FOR EACH STATEMENT EXECUTE FUNCTION "audit_entries_append_only"();
@@ -0,0 +1,71 @@
+UPDATE
+    "users"
+SET
+    "email" = "email" || '.deleted-' || "id"
+WHERE
+    "deleted_at" IS NOT NULL
+    AND EXISTS (
+        SELECT
+            1
+        FROM
+            "users" AS "other"
+        WHERE
+            "other"."email" = "users"."email"
+            AND "other"."id" <> "users"."id"
+    );
+
+DROP INDEX IF EXISTS "email";
+
+CREATE UNIQUE INDEX "email" ON "users" ("email");
+
+UPDATE
+    "payments"
+SET
+    "name" = "name" || ' (deleted ' || "id" || ')'
+WHERE
+    "deleted_at" IS NOT NULL
+    AND EXISTS (
+        SELECT
+            1
+        FROM
+            "payments" AS "other"
+        WHERE
+            "other"."name" = "payments"."name"
+            AND "other"."id" <> "payments"."id"
+    );
+
+DROP INDEX IF EXISTS "payment_name";
+
+CREATE UNIQUE INDEX "payment_name" ON "payments" ("name");
+
+UPDATE
+    "categories"
+SET
+    "name" = "name" || ' (deleted ' || "id" || ')'
+WHERE
+    "deleted_at" IS NOT NULL
+    AND EXISTS (
+        SELECT
+            1
+        FROM
+            "categories" AS "other"
+        WHERE
+            "other"."name" = "categories"."name"
+            AND "other"."id" <> "categories"."id"
+    );
+
+DROP INDEX IF EXISTS "category_name";
+
+CREATE UNIQUE INDEX "category_name" ON "categories" ("name");
+
+ALTER TABLE
+    IF EXISTS "users" DROP COLUMN IF EXISTS "deleted_at";
+
+ALTER TABLE
+    IF EXISTS "payments" DROP COLUMN IF EXISTS "deleted_at";
+
+ALTER TABLE
+    IF EXISTS "categories" DROP COLUMN IF EXISTS "deleted_at";
+
+ALTER TABLE
+    IF EXISTS "products" DROP COLUMN IF EXISTS "deleted_at";
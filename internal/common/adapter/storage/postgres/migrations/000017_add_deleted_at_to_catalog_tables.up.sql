@@ -0,0 +1,31 @@
+ALTER TABLE
+    "users"
+ADD
+    COLUMN "deleted_at" timestamptz;
+
+ALTER TABLE
+    "payments"
+ADD
+    COLUMN "deleted_at" timestamptz;
+
+ALTER TABLE
+    "categories"
+ADD
+    COLUMN "deleted_at" timestamptz;
+
+ALTER TABLE
+    "products"
+ADD
+    COLUMN "deleted_at" timestamptz;
+
+DROP INDEX IF EXISTS "email";
+
+CREATE UNIQUE INDEX "email" ON "users" ("email") WHERE "deleted_at" IS NULL;
+
+DROP INDEX IF EXISTS "payment_name";
+
+CREATE UNIQUE INDEX "payment_name" ON "payments" ("name") WHERE "deleted_at" IS NULL;
+
+DROP INDEX IF EXISTS "category_name";
+
+CREATE UNIQUE INDEX "category_name" ON "categories" ("name") WHERE "deleted_at" IS NULL;
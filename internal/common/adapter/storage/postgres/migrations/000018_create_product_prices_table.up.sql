@@ -0,0 +1,23 @@
+CREATE TABLE "product_prices" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "product_id" bigint NOT NULL,
+    "price" decimal(18, 2) NOT NULL,
+    "effective_from" timestamptz NOT NULL DEFAULT (now()),
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "product_price_effective_from" ON "product_prices" ("product_id", "effective_from");
+
+ALTER TABLE
+    "product_prices"
+ADD
+    CONSTRAINT "fk_products_product_prices" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+INSERT INTO
+    "product_prices" ("product_id", "price", "effective_from")
+SELECT
+    "id",
+    "price",
+    "created_at"
+FROM
+    "products";
@@ -0,0 +1,5 @@
+ALTER TABLE
+    IF EXISTS "order_products" DROP COLUMN IF EXISTS "product_sku",
+    DROP COLUMN IF EXISTS "product_name",
+    DROP COLUMN IF EXISTS "unit_price",
+    DROP COLUMN IF EXISTS "category_name";
@@ -0,0 +1,42 @@
+ALTER TABLE
+    "order_products"
+ADD
+    COLUMN "product_sku" uuid,
+ADD
+    COLUMN "product_name" varchar,
+ADD
+    COLUMN "unit_price" decimal(18, 2),
+ADD
+    COLUMN "category_name" varchar;
+
+UPDATE
+    "order_products"
+SET
+    "product_sku" = "products"."sku",
+    "product_name" = "products"."name",
+    "unit_price" = "order_products"."total_price" / "order_products"."quantity",
+    "category_name" = "categories"."name"
+FROM
+    "products"
+    JOIN "categories" ON "categories"."id" = "products"."category_id"
+WHERE
+    "products"."id" = "order_products"."product_id";
+
+ALTER TABLE
+    "order_products"
+ALTER COLUMN
+    "product_sku"
+SET
+    NOT NULL,
+ALTER COLUMN
+    "product_name"
+SET
+    NOT NULL,
+ALTER COLUMN
+    "unit_price"
+SET
+    NOT NULL,
+ALTER COLUMN
+    "category_name"
+SET
+    NOT NULL;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "category_day_parts";
+
+DROP TABLE IF EXISTS "product_day_parts";
+
+DROP TABLE IF EXISTS "day_part_windows";
+
+DROP TABLE IF EXISTS "day_parts";
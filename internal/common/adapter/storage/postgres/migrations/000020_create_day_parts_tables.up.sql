@@ -0,0 +1,80 @@
+CREATE TABLE "day_parts" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "day_part_name" ON "day_parts" ("name");
+
+CREATE TABLE "day_part_windows" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "day_part_id" bigint NOT NULL,
+    "weekday" smallint NOT NULL CHECK ("weekday" BETWEEN 0 AND 6),
+    "start_minute" smallint NOT NULL CHECK ("start_minute" >= 0),
+    "end_minute" smallint NOT NULL CHECK ("end_minute" <= 1440),
+    CHECK ("start_minute" < "end_minute")
+);
+
+CREATE INDEX "day_part_window_day_part_id" ON "day_part_windows" ("day_part_id");
+
+CREATE TABLE "product_day_parts" (
+    "product_id" bigint NOT NULL,
+    "day_part_id" bigint NOT NULL,
+    PRIMARY KEY ("product_id", "day_part_id")
+);
+
+CREATE TABLE "category_day_parts" (
+    "category_id" bigint NOT NULL,
+    "day_part_id" bigint NOT NULL,
+    PRIMARY KEY ("category_id", "day_part_id")
+);
+
+ALTER TABLE
+    "day_part_windows"
+ADD
+    CONSTRAINT "fk_day_parts_day_part_windows" FOREIGN KEY ("day_part_id") REFERENCES "day_parts" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "product_day_parts"
+ADD
+    CONSTRAINT "fk_products_product_day_parts" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "product_day_parts"
+ADD
+    CONSTRAINT "fk_day_parts_product_day_parts" FOREIGN KEY ("day_part_id") REFERENCES "day_parts" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "category_day_parts"
+ADD
+    CONSTRAINT "fk_categories_category_day_parts" FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "category_day_parts"
+ADD
+    CONSTRAINT "fk_day_parts_category_day_parts" FOREIGN KEY ("day_part_id") REFERENCES "day_parts" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+INSERT INTO
+    "day_parts" ("name")
+VALUES
+    ('Breakfast'),
+    ('Lunch'),
+    ('Dinner');
+
+INSERT INTO
+    "day_part_windows" ("day_part_id", "weekday", "start_minute", "end_minute")
+SELECT
+    "day_parts"."id",
+    "weekdays"."weekday",
+    "hours"."start_minute",
+    "hours"."end_minute"
+FROM
+    "day_parts"
+    JOIN (
+        VALUES
+            ('Breakfast', 360, 660),
+            ('Lunch', 660, 960),
+            ('Dinner', 960, 1380)
+    ) AS "hours" ("name", "start_minute", "end_minute") ON "hours"."name" = "day_parts"."name"
+    CROSS JOIN generate_series(0, 6) AS "weekdays" ("weekday");
@@ -0,0 +1,10 @@
+DELETE FROM
+    "role_permissions"
+WHERE
+    "permission" = 'product.availability';
+
+DROP INDEX IF EXISTS "products_unavailable_until";
+
+ALTER TABLE
+    IF EXISTS "products" DROP COLUMN IF EXISTS "unavailable_reason",
+    DROP COLUMN IF EXISTS "unavailable_until";
@@ -0,0 +1,18 @@
+ALTER TABLE
+    "products"
+ADD
+    COLUMN "unavailable_reason" varchar NOT NULL DEFAULT '',
+ADD
+    COLUMN "unavailable_until" timestamptz;
+
+CREATE INDEX "products_unavailable_until" ON "products" ("unavailable_until");
+
+INSERT INTO
+    "role_permissions" ("role", "permission")
+SELECT
+    "name",
+    'product.availability'
+FROM
+    "roles"
+WHERE
+    "name" IN ('manager', 'kitchen');
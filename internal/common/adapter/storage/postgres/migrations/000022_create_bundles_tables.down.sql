@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "order_product_components";
+
+DROP TABLE IF EXISTS "bundle_slot_choices";
+
+DROP TABLE IF EXISTS "bundle_slots";
+
+ALTER TABLE
+    IF EXISTS "products" DROP COLUMN IF EXISTS "type";
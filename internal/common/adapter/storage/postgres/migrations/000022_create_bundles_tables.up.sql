@@ -0,0 +1,64 @@
+ALTER TABLE
+    "products"
+ADD
+    COLUMN "type" varchar NOT NULL DEFAULT 'single' CHECK ("type" IN ('single', 'bundle'));
+
+CREATE TABLE "bundle_slots" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "bundle_id" bigint NOT NULL,
+    "name" varchar NOT NULL,
+    "quantity" bigint NOT NULL DEFAULT 1 CHECK ("quantity" > 0),
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "bundle_slot_bundle_id" ON "bundle_slots" ("bundle_id");
+
+CREATE TABLE "bundle_slot_choices" (
+    "slot_id" bigint NOT NULL,
+    "product_id" bigint NOT NULL,
+    "position" smallint NOT NULL,
+    PRIMARY KEY ("slot_id", "product_id")
+);
+
+CREATE INDEX "bundle_slot_choice_product_id" ON "bundle_slot_choices" ("product_id");
+
+CREATE TABLE "order_product_components" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "order_product_id" bigint NOT NULL,
+    "product_id" bigint NOT NULL,
+    "slot_name" varchar NOT NULL,
+    "product_sku" uuid NOT NULL,
+    "product_name" varchar NOT NULL,
+    "quantity" bigint NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "order_product_component_order_product_id" ON "order_product_components" ("order_product_id");
+
+CREATE INDEX "order_product_component_product_id" ON "order_product_components" ("product_id");
+
+ALTER TABLE
+    "bundle_slots"
+ADD
+    CONSTRAINT "fk_products_bundle_slots" FOREIGN KEY ("bundle_id") REFERENCES "products" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "bundle_slot_choices"
+ADD
+    CONSTRAINT "fk_bundle_slots_bundle_slot_choices" FOREIGN KEY ("slot_id") REFERENCES "bundle_slots" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "bundle_slot_choices"
+ADD
+    CONSTRAINT "fk_products_bundle_slot_choices" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "order_product_components"
+ADD
+    CONSTRAINT "fk_order_products_order_product_components" FOREIGN KEY ("order_product_id") REFERENCES "order_products" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "order_product_components"
+ADD
+    CONSTRAINT "fk_products_order_product_components" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION;
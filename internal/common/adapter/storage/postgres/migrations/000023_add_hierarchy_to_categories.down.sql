@@ -0,0 +1,9 @@
+ALTER TABLE
+    IF EXISTS "categories" DROP CONSTRAINT IF EXISTS "fk_categories_categories";
+
+DROP INDEX IF EXISTS "category_parent_id";
+
+ALTER TABLE
+    IF EXISTS "categories" DROP COLUMN IF EXISTS "parent_id",
+    DROP COLUMN IF EXISTS "sort_order",
+    DROP COLUMN IF EXISTS "image";
@@ -0,0 +1,15 @@
+ALTER TABLE
+    "categories"
+ADD
+    COLUMN "parent_id" bigint,
+ADD
+    COLUMN "sort_order" integer NOT NULL DEFAULT 0,
+ADD
+    COLUMN "image" varchar NOT NULL DEFAULT '';
+
+CREATE INDEX "category_parent_id" ON "categories" ("parent_id");
+
+ALTER TABLE
+    "categories"
+ADD
+    CONSTRAINT "fk_categories_categories" FOREIGN KEY ("parent_id") REFERENCES "categories" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION;
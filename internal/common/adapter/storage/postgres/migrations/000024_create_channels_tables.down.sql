@@ -0,0 +1,10 @@
+ALTER TABLE
+    IF EXISTS "orders" DROP COLUMN IF EXISTS "channel";
+
+DROP TABLE IF EXISTS "menu_products";
+
+DROP TABLE IF EXISTS "menus";
+
+DROP TABLE IF EXISTS "price_list_items";
+
+DROP TABLE IF EXISTS "price_lists";
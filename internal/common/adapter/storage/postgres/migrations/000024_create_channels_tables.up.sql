@@ -0,0 +1,63 @@
+CREATE TABLE "price_lists" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "channel" varchar NOT NULL CHECK ("channel" IN ('dine_in', 'takeaway', 'delivery')),
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "price_list_channel" ON "price_lists" ("channel");
+
+CREATE TABLE "price_list_items" (
+    "price_list_id" bigint NOT NULL,
+    "product_id" bigint NOT NULL,
+    "price" decimal(18, 2) NOT NULL CHECK ("price" >= 0),
+    PRIMARY KEY ("price_list_id", "product_id")
+);
+
+CREATE INDEX "price_list_item_product_id" ON "price_list_items" ("product_id");
+
+CREATE TABLE "menus" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "channel" varchar NOT NULL CHECK ("channel" IN ('dine_in', 'takeaway', 'delivery')),
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "menu_channel" ON "menus" ("channel");
+
+CREATE TABLE "menu_products" (
+    "menu_id" bigint NOT NULL,
+    "product_id" bigint NOT NULL,
+    PRIMARY KEY ("menu_id", "product_id")
+);
+
+CREATE INDEX "menu_product_product_id" ON "menu_products" ("product_id");
+
+ALTER TABLE
+    "price_list_items"
+ADD
+    CONSTRAINT "fk_price_lists_price_list_items" FOREIGN KEY ("price_list_id") REFERENCES "price_lists" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "price_list_items"
+ADD
+    CONSTRAINT "fk_products_price_list_items" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "menu_products"
+ADD
+    CONSTRAINT "fk_menus_menu_products" FOREIGN KEY ("menu_id") REFERENCES "menus" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "menu_products"
+ADD
+    CONSTRAINT "fk_products_menu_products" FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "orders"
+ADD
+    COLUMN "channel" varchar NOT NULL DEFAULT 'dine_in' CHECK ("channel" IN ('dine_in', 'takeaway', 'delivery'));
+
+CREATE INDEX "orders_channel" ON "orders" ("channel");
@@ -0,0 +1,11 @@
+DELETE FROM
+    "role_permissions"
+WHERE
+    "permission" IN ('order.refund', 'order.discount');
+
+DROP TABLE IF EXISTS "order_refunds";
+
+ALTER TABLE
+    IF EXISTS "orders" DROP CONSTRAINT IF EXISTS "fk_approvers_orders",
+    DROP COLUMN IF EXISTS "discount_approved_by",
+    DROP COLUMN IF EXISTS "discount";
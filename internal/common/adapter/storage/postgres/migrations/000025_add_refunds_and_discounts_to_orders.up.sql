@@ -0,0 +1,55 @@
+ALTER TABLE
+    "orders"
+ADD
+    COLUMN "discount" decimal(18, 2) NOT NULL DEFAULT 0,
+ADD
+    COLUMN "discount_approved_by" bigint;
+
+ALTER TABLE
+    "orders"
+ADD
+    CONSTRAINT "fk_approvers_orders" FOREIGN KEY ("discount_approved_by") REFERENCES "users" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+CREATE TABLE "order_refunds" (
+    "id" bigserial PRIMARY KEY,
+    "order_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "approved_by" bigint NOT NULL,
+    "amount" decimal(18, 2) NOT NULL CHECK ("amount" > 0),
+    "reason" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "order_refunds_order_id" ON "order_refunds" ("order_id");
+
+CREATE INDEX "order_refunds_approved_by" ON "order_refunds" ("approved_by");
+
+ALTER TABLE
+    "order_refunds"
+ADD
+    CONSTRAINT "fk_orders_order_refunds" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "order_refunds"
+ADD
+    CONSTRAINT "fk_users_order_refunds" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+ALTER TABLE
+    "order_refunds"
+ADD
+    CONSTRAINT "fk_approvers_order_refunds" FOREIGN KEY ("approved_by") REFERENCES "users" ("id") ON DELETE NO ACTION ON UPDATE NO ACTION;
+
+INSERT INTO
+    "role_permissions" ("role", "permission")
+SELECT
+    "name",
+    "permission"
+FROM
+    "roles"
+    CROSS JOIN (
+        VALUES
+            ('order.refund'),
+            ('order.discount')
+    ) AS "permissions" ("permission")
+WHERE
+    "name" = 'manager';
@@ -2,8 +2,10 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"github.com/redis/go-redis/v9"
 	"go-restaurant/internal/common/adapter/config"
+	"go-restaurant/internal/common/domain"
 	"go-restaurant/internal/common/port"
 	"time"
 )
@@ -36,13 +38,44 @@ func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Dura
 	return r.client.Set(ctx, key, value, ttl).Err()
 }
 
-// Get retrieves the value from the redis database
+// Get retrieves the value from the redis database, or returns domain.ErrDataNotFound if the key does not exist
 func (r *Redis) Get(ctx context.Context, key string) ([]byte, error) {
 	res, err := r.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, domain.ErrDataNotFound
+	}
 	bytes := []byte(res)
 	return bytes, err
 }
 
+// GetAndDelete retrieves and removes the value from the redis database atomically,
+// or returns domain.ErrDataNotFound if the key does not exist
+func (r *Redis) GetAndDelete(ctx context.Context, key string) ([]byte, error) {
+	res, err := r.client.GetDel(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, domain.ErrDataNotFound
+	}
+	bytes := []byte(res)
+	return bytes, err
+}
+
+// Incr increments the counter stored under the key in the redis database and sets it to expire after ttl
+// in one transaction, so that concurrent increments are all counted
+func (r *Redis) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
 // Delete removes the value from the redis database
 func (r *Redis) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
@@ -16,18 +16,128 @@ var (
 	ErrConflictingData = errors.New("data conflicts with existing data in unique column")
 	// ErrInsufficientStock is an error for when product stock is not enough
 	ErrInsufficientStock = errors.New("product stock is not enough")
+	// ErrProductUnavailable is an error for when a product is ordered outside the day-parts it is available in
+	ErrProductUnavailable = errors.New("product is not available at this time")
+	// ErrProductEightySixed is an error for when a product the kitchen has run out of for the business day is ordered
+	ErrProductEightySixed = errors.New("product has run out for the rest of the day")
+	// ErrProductNotOnMenu is an error for when a product is ordered through a channel whose menu it is not on
+	ErrProductNotOnMenu = errors.New("product is not on the menu of this channel")
+	// ErrInvalidBundle is an error for when a bundle has no slots, a slot has no choices or a product that is not a bundle has slots
+	ErrInvalidBundle = errors.New("bundle must have slots to choose single products from, and only bundles can have slots")
+	// ErrInvalidBundleChoice is an error for when a bundle is ordered with a choice of a product that is not in its slot
+	ErrInvalidBundleChoice = errors.New("chosen product is not one of the choices of the bundle slot")
+	// ErrInvalidCategoryParent is an error for when a category is moved under itself or one of its subcategories
+	ErrInvalidCategoryParent = errors.New("category cannot be a subcategory of itself or of its subcategories")
+	// ErrCategoryHasChildren is an error for when a category that still has subcategories is deleted
+	ErrCategoryHasChildren = errors.New("category has subcategories, delete or move them first")
+	// ErrCategoryDeleted is an error for when something is restored into a category that is deleted
+	ErrCategoryDeleted = errors.New("category is deleted, restore it first")
 	// ErrInsufficientPayment is an error for when total paid is less than total price
 	ErrInsufficientPayment = errors.New("total paid is less than total price")
+	// ErrPriceChangeNotInFuture is an error for when a price change is scheduled to take effect in the past
+	ErrPriceChangeNotInFuture = errors.New("price change must take effect in the future")
+	// ErrPriceChangeInEffect is an error for when a price change that already took effect is cancelled
+	ErrPriceChangeInEffect = errors.New("price change has already taken effect")
+	// ErrInvalidDayPartWindow is an error for when a day-part window does not start before it ends within a day
+	ErrInvalidDayPartWindow = errors.New("day-part window must start before it ends within the same day")
+	// ErrInvalidDateRange is an error for when the start date is after the end date
+	ErrInvalidDateRange = errors.New("start date must not be after end date")
+	// ErrInvalidImportFile is an error for when an import file cannot be read or misses required columns
+	ErrInvalidImportFile = errors.New("import file must be a CSV with category, name, price, stock, image and sku columns")
+	// ErrMissingImage is an error for when an upload request has no image in the expected multipart form field
+	ErrMissingImage = errors.New("image must be uploaded as a file in the image field of a multipart form")
+	// ErrImageTooLarge is an error for when an uploaded image is larger than the size limit
+	ErrImageTooLarge = errors.New("image must not be larger than 5 MB")
+	// ErrUnsupportedImageType is an error for when an uploaded file is not a JPEG, PNG or GIF image
+	ErrUnsupportedImageType = errors.New("image must be a JPEG, PNG or GIF")
+	// ErrInvalidImage is an error for when an uploaded image cannot be decoded or its dimensions are out of bounds
+	ErrInvalidImage = errors.New("image is corrupted or larger than 4096x4096 pixels")
+	// ErrInvalidDayCutoff is an error for when the business day cutoff is not a valid time of day
+	ErrInvalidDayCutoff = errors.New("day cutoff must be a time of day between 00:00 and 23:59")
+	// ErrInvalidDiscountLimit is an error for when the discount limit is not a percentage
+	ErrInvalidDiscountLimit = errors.New("discount limit must be a number between 0 and 100")
+	// ErrInvalidRole is an error for when a user is given a role that does not exist
+	ErrInvalidRole = errors.New("role does not exist")
+	// ErrRoleInUse is an error for when a role that is given to users is deleted
+	ErrRoleInUse = errors.New("role is given to users")
+	// ErrRoleNotGrantable is an error for when a user gives a role, or a scope, with permissions they do not hold themselves
+	ErrRoleNotGrantable = errors.New("role can only be given by users whose own role allows all of its permissions")
+	// ErrLastAdmin is an error for when the only admin would lose the admin role
+	ErrLastAdmin = errors.New("the last admin cannot lose the admin role")
+	// ErrProtectedRole is an error for when a built-in role is changed or deleted
+	ErrProtectedRole = errors.New("built-in role cannot be changed or deleted")
+	// ErrApprovalRequired is an error for when an action needs the approval of a user allowed to perform it
+	ErrApprovalRequired = errors.New("action requires the approval of a user allowed to perform it")
+	// ErrInvalidApproval is an error for when the approval PIN or token is wrong or does not allow the action
+	ErrInvalidApproval = errors.New("approval is invalid")
+	// ErrTooManyAttempts is an error for when a credential is locked after too many failed attempts
+	ErrTooManyAttempts = errors.New("too many failed attempts, try again later")
+	// ErrLoginThrottled is an error for when a login is retried too soon after failed logins
+	ErrLoginThrottled = errors.New("too many failed logins, wait before trying again")
+	// ErrAccountLocked is an error for when an account is locked after too many failed logins
+	ErrAccountLocked = errors.New("account is locked after too many failed logins")
+	// ErrOrderVoided is an error for when an order has already been voided
+	ErrOrderVoided = errors.New("order has already been voided")
+	// ErrRefundExceedsTotal is an error for when the refunds of an order add up to more than its total price
+	ErrRefundExceedsTotal = errors.New("refunds cannot add up to more than the total price of the order")
+	// ErrInvalidDiscount is an error for when the discount of an order is more than the price of its products
+	ErrInvalidDiscount = errors.New("discount cannot be more than the price of the order's products")
 	// ErrInvalidTokenSymmetricKey is an error for when the token symmetric key size is invalid
 	ErrInvalidTokenSymmetricKey = errors.New("invalid token key size")
+	// ErrInvalidTokenSigningSecret is an error for when the secret the token signing keys are derived from is too short
+	ErrInvalidTokenSigningSecret = errors.New("token signing secret must be at least 32 bytes")
+	// ErrInvalidTokenType is an error for when the configured token type is not supported
+	ErrInvalidTokenType = errors.New("token type must be local or public")
+	// ErrInvalidTokenRotation is an error for when the token key rotation interval is too short
+	ErrInvalidTokenRotation = errors.New("token rotation interval must be at least one minute")
+	// ErrInvalidRegistration is an error for when the configured registration mode is not supported
+	ErrInvalidRegistration = errors.New("registration must be invite or disabled")
+	// ErrRegistrationDisabled is an error for when users cannot register themselves
+	ErrRegistrationDisabled = errors.New("registration is disabled")
+	// ErrInvalidInvite is an error for when the invite code is missing, invalid, expired or already used
+	ErrInvalidInvite = errors.New("invite code is invalid")
+	// ErrInvalidOIDCConfig is an error for when single sign-on is configured without a client id or redirect URL
+	ErrInvalidOIDCConfig = errors.New("OIDC client id and redirect URL are required")
+	// ErrInvalidOIDCRoleMapping is an error for when the configured single sign-on role mapping is not a list of group=role pairs
+	ErrInvalidOIDCRoleMapping = errors.New("OIDC role mapping must be a comma separated list of group=role pairs")
+	// ErrSSODisabled is an error for when single sign-on is not configured
+	ErrSSODisabled = errors.New("single sign-on is not configured")
+	// ErrInvalidSSOLogin is an error for when the single sign-on login is unknown, expired or rejected by the identity provider
+	ErrInvalidSSOLogin = errors.New("single sign-on login is invalid")
+	// ErrSSOAccessDenied is an error for when the identity provider groups of a user do not map to a role
+	ErrSSOAccessDenied = errors.New("user is not in a group allowed to sign in")
+	// ErrInvalidMailDriver is an error for when the configured mail driver is not supported
+	ErrInvalidMailDriver = errors.New("mail driver must be log or smtp")
+	// ErrMissingMailDriver is an error for when no mail driver is configured outside development
+	ErrMissingMailDriver = errors.New("mail driver must be set outside development")
+	// ErrInvalidStorageDriver is an error for when the configured file storage driver is not supported
+	ErrInvalidStorageDriver = errors.New("storage driver must be local or s3")
+	// ErrInvalidStorageEndpoint is an error for when the configured S3 endpoint is not an absolute http or https URL
+	ErrInvalidStorageEndpoint = errors.New("s3 endpoint must be a URL such as http://localhost:9000")
 	// ErrTokenCreation is an error for when the token creation fails
 	ErrTokenCreation = errors.New("error creating token")
 	// ErrExpiredToken is an error for when the access token is expired
 	ErrExpiredToken = errors.New("access token has expired")
 	// ErrInvalidToken is an error for when the access token is invalid
 	ErrInvalidToken = errors.New("access token is invalid")
+	// ErrRevokedToken is an error for when the access token has been revoked
+	ErrRevokedToken = errors.New("access token has been revoked")
+	// ErrInvalidAPIKey is an error for when the API key is invalid
+	ErrInvalidAPIKey = errors.New("API key is invalid")
+	// ErrExpiredAPIKey is an error for when the API key is expired
+	ErrExpiredAPIKey = errors.New("API key has expired")
+	// ErrInvalidRefreshToken is an error for when the refresh token is invalid, expired or already used
+	ErrInvalidRefreshToken = errors.New("refresh token is invalid")
+	// ErrInvalidResetToken is an error for when the password reset token is invalid, expired or already used
+	ErrInvalidResetToken = errors.New("password reset token is invalid")
 	// ErrInvalidCredentials is an error for when the credentials are invalid
 	ErrInvalidCredentials = errors.New("invalid email or password")
+	// ErrInvalidPIN is an error for when the user or PIN of a PIN login is invalid
+	ErrInvalidPIN = errors.New("invalid user or PIN")
+	// ErrInvalidTerminal is an error for when the terminal key is invalid
+	ErrInvalidTerminal = errors.New("terminal key is invalid")
+	// ErrTerminalMismatch is an error for when an access token bound to a terminal is sent without that terminal's key
+	ErrTerminalMismatch = errors.New("access token is bound to another terminal")
 	// ErrEmptyAuthorizationHeader is an error for when the authorization header is empty
 	ErrEmptyAuthorizationHeader = errors.New("authorization header is not provided")
 	// ErrInvalidAuthorizationHeader is an error for when the authorization header is invalid
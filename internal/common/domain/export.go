@@ -0,0 +1,20 @@
+package domain
+
+// ExportFormat is an enum for export's file format
+type ExportFormat string
+
+// ExportFormat enum values
+const (
+	CSV  ExportFormat = "csv"
+	XLSX ExportFormat = "xlsx"
+)
+
+// ContentType returns the MIME type of the export format
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv"
+	}
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"io"
+	"time"
+)
+
+// File is an entity that represents a stored file opened for reading, which must be closed after use
+type File struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+	ModifiedAt  time.Time
+}
@@ -0,0 +1,8 @@
+package domain
+
+// Mail is an entity that represents a plain text email sent to a single recipient
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
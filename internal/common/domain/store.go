@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+)
+
+// Store is a value object that represents the restaurant's local time and discount settings.
+// A business day starts at DayCutoff in the store's location and lasts 24 hours,
+// so with a 06:00 cutoff an order placed at 02:00 belongs to the previous business day.
+// DiscountLimit is the largest discount, as a percentage of the price of an order's products,
+// that can be given without the approval of a user allowed to give discounts
+type Store struct {
+	Location      *time.Location
+	DayCutoff     time.Duration
+	DiscountLimit float64
+}
+
+// NewStore creates a new store from an IANA timezone name, a "15:04" day cutoff
+// and a discount limit percentage, which is 0 if not given
+func NewStore(timezone, dayCutoff, discountLimit string) (*Store, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	var cutoff time.Duration
+	if dayCutoff != "" {
+		clock, err := time.Parse("15:04", dayCutoff)
+		if err != nil {
+			return nil, ErrInvalidDayCutoff
+		}
+
+		cutoff = time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute
+	}
+
+	var limit float64
+	if discountLimit != "" {
+		limit, err = strconv.ParseFloat(discountLimit, 64)
+		if err != nil || limit < 0 || limit > 100 {
+			return nil, ErrInvalidDiscountLimit
+		}
+	}
+
+	return &Store{
+		location,
+		cutoff,
+		limit,
+	}, nil
+}
+
+// DayStart returns the instant the business day of the given date begins
+func (s *Store) DayStart(date time.Time) time.Time {
+	year, month, day := date.Date()
+	hour := int(s.DayCutoff / time.Hour)
+	minute := int(s.DayCutoff % time.Hour / time.Minute)
+
+	return time.Date(year, month, day, hour, minute, 0, 0, s.Location)
+}
+
+// DayEnd returns the instant the business day of the given date ends, exclusive
+func (s *Store) DayEnd(date time.Time) time.Time {
+	return s.DayStart(date.AddDate(0, 0, 1))
+}
+
+// BusinessDate returns the business date the given instant belongs to
+func (s *Store) BusinessDate(t time.Time) time.Time {
+	year, month, day := t.In(s.Location).Add(-s.DayCutoff).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Today returns the current business date
+func (s *Store) Today() time.Time {
+	return s.BusinessDate(time.Now())
+}
+
+// MaxDiscount returns the largest discount that can be given without approval on products of the given price
+func (s *Store) MaxDiscount(price float64) float64 {
+	return price * s.DiscountLimit / 100
+}
@@ -11,8 +11,14 @@ import (
 type CacheRepository interface {
 	// Set stores the value in the cache
 	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
-	// Get retrieves the value from the cache
+	// Get retrieves the value from the cache, or returns domain.ErrDataNotFound if it is not cached
 	Get(ctx context.Context, key string) ([]byte, error)
+	// GetAndDelete retrieves and removes the value from the cache in one step,
+	// or returns domain.ErrDataNotFound if it is not cached
+	GetAndDelete(ctx context.Context, key string) ([]byte, error)
+	// Incr increments the counter stored under the key by one in one step and sets it to expire after ttl,
+	// starting from zero if it is not cached, and returns the incremented count
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
 	// Delete removes the value from the cache
 	Delete(ctx context.Context, key string) error
 	// DeleteByPrefix removes the value from the cache with the given prefix
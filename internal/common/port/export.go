@@ -0,0 +1,13 @@
+package port
+
+//go:generate mockgen -source=export.go -destination=mock/export.go -package=mock
+
+// ExportWriter is an interface for writing tabular data to a spreadsheet file
+type ExportWriter interface {
+	// WriteHeader writes the column names
+	WriteHeader(columns ...string) error
+	// WriteRow writes a single row of values
+	WriteRow(values ...any) error
+	// Close flushes any buffered data to the underlying writer
+	Close() error
+}
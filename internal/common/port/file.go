@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"go-restaurant/internal/common/domain"
+	"io"
+)
+
+//go:generate mockgen -source=file.go -destination=mock/file.go -package=mock
+
+// FileStorage is an interface for storing files, such as uploaded images, by key
+type FileStorage interface {
+	// Put stores size bytes read from r under the key, replacing any file stored under it
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the file stored under the key
+	Get(ctx context.Context, key string) (*domain.File, error)
+	// Delete removes the file stored under the key, if any
+	Delete(ctx context.Context, key string) error
+}
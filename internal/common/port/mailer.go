@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+	"go-restaurant/internal/common/domain"
+)
+
+//go:generate mockgen -source=mailer.go -destination=mock/mailer.go -package=mock
+
+// Mailer is an interface for sending emails
+type Mailer interface {
+	// Send sends an email
+	Send(ctx context.Context, mail *domain.Mail) error
+}
@@ -0,0 +1,12 @@
+package port
+
+import "context"
+
+//go:generate mockgen -source=transactor.go -destination=mock/transactor.go -package=mock
+
+// Transactor is an interface for running repository calls in one database transaction
+type Transactor interface {
+	// WithinTransaction runs fn in a transaction that the repository calls made with its context join,
+	// committing it if fn succeeds and rolling it back otherwise
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,288 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+	cmutil "go-restaurant/internal/common/util"
+	"go-restaurant/internal/daypart/domain"
+	"go-restaurant/internal/daypart/port"
+	"time"
+)
+
+// DayPartHandler represents the HTTP handler for day-part-related requests
+type DayPartHandler struct {
+	svc port.DayPartService
+}
+
+// NewDayPartHandler creates a new DayPartHandler instance
+func NewDayPartHandler(svc port.DayPartService) *DayPartHandler {
+	return &DayPartHandler{
+		svc,
+	}
+}
+
+// dayPartWindowRequest represents a day-part window request body
+type dayPartWindowRequest struct {
+	Weekday int    `json:"weekday" binding:"min=0,max=6" example:"1"`
+	Start   string `json:"start" binding:"required,clock" example:"06:00"`
+	End     string `json:"end" binding:"required,clock" example:"11:00"`
+}
+
+// createDayPartRequest represents a request body for creating a new day-part
+type createDayPartRequest struct {
+	Name    string                 `json:"name" binding:"required" example:"Breakfast"`
+	Windows []dayPartWindowRequest `json:"windows" binding:"required,dive"`
+}
+
+// CreateDayPart godoc
+//
+//	@Summary		Create a new day-part
+//	@Description	create a new day-part with name and the windows of the weekdays it is open, in the store's timezone
+//	@Tags			DayParts
+//	@Accept			json
+//	@Produce		json
+//	@Param			createDayPartRequest	body		createDayPartRequest	true	"Create day-part request"
+//	@Success		200						{object}	dayPartResponse			"Day-part created"
+//	@Failure		400						{object}	errorResponse			"Validation error"
+//	@Failure		401						{object}	errorResponse			"Unauthorized error"
+//	@Failure		403						{object}	errorResponse			"Forbidden error"
+//	@Failure		409						{object}	errorResponse			"Data conflict error"
+//	@Failure		500						{object}	errorResponse			"Internal server error"
+//	@Router			/day-parts [post]
+//	@Security		BearerAuth
+func (dh *DayPartHandler) CreateDayPart(ctx *gin.Context) {
+	var req createDayPartRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	dayPart := domain.DayPart{
+		Name:    req.Name,
+		Windows: newDayPartWindows(req.Windows),
+	}
+
+	_, err := dh.svc.CreateDayPart(ctx, &dayPart)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newDayPartResponse(&dayPart)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// getDayPartRequest represents a request body for retrieving a day-part
+type getDayPartRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetDayPart godoc
+//
+//	@Summary		Get a day-part
+//	@Description	get a day-part by id with its windows
+//	@Tags			DayParts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Day-part ID"
+//	@Success		200	{object}	dayPartResponse	"Day-part retrieved"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/day-parts/{id} [get]
+//	@Security		BearerAuth
+func (dh *DayPartHandler) GetDayPart(ctx *gin.Context) {
+	var req getDayPartRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	dayPart, err := dh.svc.GetDayPart(ctx, req.ID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newDayPartResponse(dayPart)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// listDayPartsRequest represents a request body for listing day-parts
+type listDayPartsRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListDayParts godoc
+//
+//	@Summary		List day-parts
+//	@Description	List day-parts with pagination
+//	@Tags			DayParts
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Day-parts displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/day-parts [get]
+//	@Security		BearerAuth
+func (dh *DayPartHandler) ListDayParts(ctx *gin.Context) {
+	var req listDayPartsRequest
+	var dayPartsList []dayPartResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	dayParts, err := dh.svc.ListDayParts(ctx, req.Skip, req.Limit)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	for _, dayPart := range dayParts {
+		dayPartsList = append(dayPartsList, newDayPartResponse(&dayPart))
+	}
+
+	total := uint64(len(dayPartsList))
+	meta := cmhttp.NewMeta(total, req.Limit, req.Skip)
+	rsp := cmutil.ToMap(meta, dayPartsList, "day_parts")
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// updateDayPartRequest represents a request body for updating a day-part
+type updateDayPartRequest struct {
+	Name    string                 `json:"name" binding:"omitempty,required" example:"Brunch"`
+	Windows []dayPartWindowRequest `json:"windows" binding:"omitempty,dive"`
+}
+
+// UpdateDayPart godoc
+//
+//	@Summary		Update a day-part
+//	@Description	update a day-part's name and, if given, replace its windows by id
+//	@Tags			DayParts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path		uint64					true	"Day-part ID"
+//	@Param			updateDayPartRequest	body		updateDayPartRequest	true	"Update day-part request"
+//	@Success		200						{object}	dayPartResponse			"Day-part updated"
+//	@Failure		400						{object}	errorResponse			"Validation error"
+//	@Failure		401						{object}	errorResponse			"Unauthorized error"
+//	@Failure		403						{object}	errorResponse			"Forbidden error"
+//	@Failure		404						{object}	errorResponse			"Data not found error"
+//	@Failure		409						{object}	errorResponse			"Data conflict error"
+//	@Failure		500						{object}	errorResponse			"Internal server error"
+//	@Router			/day-parts/{id} [put]
+//	@Security		BearerAuth
+func (dh *DayPartHandler) UpdateDayPart(ctx *gin.Context) {
+	var uri getDayPartRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	var req updateDayPartRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	dayPart := domain.DayPart{
+		ID:      uri.ID,
+		Name:    req.Name,
+		Windows: newDayPartWindows(req.Windows),
+	}
+
+	_, err := dh.svc.UpdateDayPart(ctx, &dayPart)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newDayPartResponse(&dayPart)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// deleteDayPartRequest represents a request body for deleting a day-part
+type deleteDayPartRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeleteDayPart godoc
+//
+//	@Summary		Delete a day-part
+//	@Description	Delete a day-part by id, which lifts the restrictions of the products and categories to it
+//	@Tags			DayParts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Day-part ID"
+//	@Success		200	{object}	response		"Day-part deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/day-parts/{id} [delete]
+//	@Security		BearerAuth
+func (dh *DayPartHandler) DeleteDayPart(ctx *gin.Context) {
+	var req deleteDayPartRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	err := dh.svc.DeleteDayPart(ctx, req.ID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	cmhttp.HandleSuccess(ctx, nil)
+}
+
+// newDayPartWindows converts validated window requests to day-part windows, keeping nil as nil
+// so an update without windows leaves them unchanged
+func newDayPartWindows(reqs []dayPartWindowRequest) []domain.DayPartWindow {
+	if reqs == nil {
+		return nil
+	}
+
+	windows := make([]domain.DayPartWindow, 0, len(reqs))
+	for _, req := range reqs {
+		start, _ := parseClock(req.Start)
+		end, _ := parseClock(req.End)
+
+		windows = append(windows, domain.DayPartWindow{
+			Weekday: time.Weekday(req.Weekday),
+			Start:   start,
+			End:     end,
+		})
+	}
+
+	return windows
+}
+
+// parseClock parses a "15:04" time of day as an offset from midnight, where "24:00" is the end of the day
+func parseClock(clock string) (time.Duration, error) {
+	if clock == "24:00" {
+		return 24 * time.Hour, nil
+	}
+
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
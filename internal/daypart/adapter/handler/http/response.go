@@ -0,0 +1,48 @@
+package http
+
+import (
+	"fmt"
+	"go-restaurant/internal/daypart/domain"
+	"time"
+)
+
+// dayPartWindowResponse represents a day-part window Response body
+type dayPartWindowResponse struct {
+	Weekday int    `json:"weekday" example:"1"`
+	Start   string `json:"start" example:"06:00"`
+	End     string `json:"end" example:"11:00"`
+}
+
+// dayPartResponse represents a day-part Response body
+type dayPartResponse struct {
+	ID        uint64                  `json:"id" example:"1"`
+	Name      string                  `json:"name" example:"Breakfast"`
+	Windows   []dayPartWindowResponse `json:"windows"`
+	CreatedAt time.Time               `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt time.Time               `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newDayPartResponse is a helper function to create a Response body for handling day-part data
+func newDayPartResponse(dayPart *domain.DayPart) dayPartResponse {
+	windows := make([]dayPartWindowResponse, 0, len(dayPart.Windows))
+	for _, window := range dayPart.Windows {
+		windows = append(windows, dayPartWindowResponse{
+			Weekday: int(window.Weekday),
+			Start:   formatClock(window.Start),
+			End:     formatClock(window.End),
+		})
+	}
+
+	return dayPartResponse{
+		ID:        dayPart.ID,
+		Name:      dayPart.Name,
+		Windows:   windows,
+		CreatedAt: dayPart.CreatedAt,
+		UpdatedAt: dayPart.UpdatedAt,
+	}
+}
+
+// formatClock formats an offset from midnight as a "15:04" time of day
+func formatClock(offset time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(offset/time.Hour), int(offset%time.Hour/time.Minute))
+}
@@ -0,0 +1,13 @@
+package http
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// ClockValidator is a custom validator for validating "15:04" times of day, where "24:00" is the end of the day
+var ClockValidator validator.Func = func(fl validator.FieldLevel) bool {
+	clock := fl.Field().String()
+
+	_, err := parseClock(clock)
+	return err == nil
+}
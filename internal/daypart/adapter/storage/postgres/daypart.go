@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-restaurant/internal/common/adapter/storage/postgres"
+	cmdomain "go-restaurant/internal/common/domain"
+	"go-restaurant/internal/daypart/domain"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/*DayPartRepository implements port.DayPartRepository interface
+ * and provides access to the postgres database
+ */
+type DayPartRepository struct {
+	db *postgres.DB
+}
+
+// NewDayPartRepository creates a new day-part repository instance
+func NewDayPartRepository(db *postgres.DB) *DayPartRepository {
+	return &DayPartRepository{
+		db,
+	}
+}
+
+// insertWindows inserts the windows of a day-part inside a transaction
+func (dr *DayPartRepository) insertWindows(ctx context.Context, tx pgx.Tx, dayPart *domain.DayPart) error {
+	if len(dayPart.Windows) == 0 {
+		return nil
+	}
+
+	query := dr.db.QueryBuilder.Insert("day_part_windows").
+		Columns("day_part_id", "weekday", "start_minute", "end_minute")
+
+	for _, window := range dayPart.Windows {
+		query = query.Values(dayPart.ID, int(window.Weekday), int(window.Start/time.Minute), int(window.End/time.Minute))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, sql, args...)
+	return err
+}
+
+// getWindows retrieves the windows of a day-part inside a transaction, ordered by weekday and start
+func (dr *DayPartRepository) getWindows(ctx context.Context, tx pgx.Tx, dayPartID uint64) ([]domain.DayPartWindow, error) {
+	var weekday, startMinute, endMinute int
+	windows := []domain.DayPartWindow{}
+
+	query := dr.db.QueryBuilder.Select("weekday", "start_minute", "end_minute").
+		From("day_part_windows").
+		Where(sq.Eq{"day_part_id": dayPartID}).
+		OrderBy("weekday", "start_minute")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&weekday,
+			&startMinute,
+			&endMinute,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		windows = append(windows, domain.DayPartWindow{
+			Weekday: time.Weekday(weekday),
+			Start:   time.Duration(startMinute) * time.Minute,
+			End:     time.Duration(endMinute) * time.Minute,
+		})
+	}
+
+	return windows, rows.Err()
+}
+
+// CreateDayPart creates a new day-part record and its windows in the database
+func (dr *DayPartRepository) CreateDayPart(ctx context.Context, dayPart *domain.DayPart) (*domain.DayPart, error) {
+	tx, err := dr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := dr.db.QueryBuilder.Insert("day_parts").
+		Columns("name").
+		Values(dayPart.Name).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(
+		&dayPart.ID,
+		&dayPart.Name,
+		&dayPart.CreatedAt,
+		&dayPart.UpdatedAt,
+	)
+	if err != nil {
+		if errCode := dr.db.ErrorCode(err); errCode == "23505" {
+			return nil, cmdomain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	err = dr.insertWindows(ctx, tx, dayPart)
+	if err != nil {
+		return nil, err
+	}
+
+	dayPart.Windows, err = dr.getWindows(ctx, tx, dayPart.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return dayPart, nil
+}
+
+// GetDayPartByID retrieves a day-part record and its windows from the database by id
+func (dr *DayPartRepository) GetDayPartByID(ctx context.Context, id uint64) (*domain.DayPart, error) {
+	var dayPart domain.DayPart
+
+	query := dr.db.QueryBuilder.Select("*").
+		From("day_parts").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	err := pgx.BeginFunc(ctx, dr.db, func(tx pgx.Tx) error {
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, sql, args...).Scan(
+			&dayPart.ID,
+			&dayPart.Name,
+			&dayPart.CreatedAt,
+			&dayPart.UpdatedAt,
+		)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return cmdomain.ErrDataNotFound
+			}
+			return err
+		}
+
+		dayPart.Windows, err = dr.getWindows(ctx, tx, dayPart.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dayPart, nil
+}
+
+// ListDayParts retrieves a list of day-parts and their windows from the database
+func (dr *DayPartRepository) ListDayParts(ctx context.Context, skip, limit uint64) ([]domain.DayPart, error) {
+	var dayPart domain.DayPart
+	var dayParts []domain.DayPart
+
+	query := dr.db.QueryBuilder.Select("*").
+		From("day_parts").
+		OrderBy("id").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	err := pgx.BeginFunc(ctx, dr.db, func(tx pgx.Tx) error {
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			err := rows.Scan(
+				&dayPart.ID,
+				&dayPart.Name,
+				&dayPart.CreatedAt,
+				&dayPart.UpdatedAt,
+			)
+			if err != nil {
+				return err
+			}
+
+			dayParts = append(dayParts, dayPart)
+		}
+
+		for i, dayPart := range dayParts {
+			dayParts[i].Windows, err = dr.getWindows(ctx, tx, dayPart.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dayParts, nil
+}
+
+// UpdateDayPart updates a day-part record in the database and, if given, replaces its windows
+func (dr *DayPartRepository) UpdateDayPart(ctx context.Context, dayPart *domain.DayPart) (*domain.DayPart, error) {
+	tx, err := dr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	name := sq.Expr("COALESCE(NULLIF(?, ''), name)", dayPart.Name)
+
+	query := dr.db.QueryBuilder.Update("day_parts").
+		Set("name", name).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": dayPart.ID}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(
+		&dayPart.ID,
+		&dayPart.Name,
+		&dayPart.CreatedAt,
+		&dayPart.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		if errCode := dr.db.ErrorCode(err); errCode == "23505" {
+			return nil, cmdomain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	if dayPart.Windows != nil {
+		query := dr.db.QueryBuilder.Delete("day_part_windows").
+			Where(sq.Eq{"day_part_id": dayPart.ID})
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		err = dr.insertWindows(ctx, tx, dayPart)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dayPart.Windows, err = dr.getWindows(ctx, tx, dayPart.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return dayPart, nil
+}
+
+// DeleteDayPart deletes a day-part record, its windows and the restrictions to it from the database by id
+func (dr *DayPartRepository) DeleteDayPart(ctx context.Context, id uint64) error {
+	query := dr.db.QueryBuilder.Delete("day_parts").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = dr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+)
+
+// DayPart is an entity that represents a named part of the day, such as breakfast or dinner,
+// that products and categories can be restricted to
+type DayPart struct {
+	ID        uint64
+	Name      string
+	Windows   []DayPartWindow
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DayPartWindow is a value object that represents when a day-part is open on a weekday,
+// from Start until End as offsets from midnight in the store's timezone
+type DayPartWindow struct {
+	Weekday time.Weekday
+	Start   time.Duration
+	End     time.Duration
+}
+
+// IsValid checks if the window starts before it ends within the same day
+func (w *DayPartWindow) IsValid() bool {
+	return w.Weekday >= time.Sunday && w.Weekday <= time.Saturday &&
+		w.Start >= 0 && w.Start < w.End && w.End <= 24*time.Hour
+}
@@ -0,0 +1,36 @@
+package port
+
+import (
+	"context"
+	"go-restaurant/internal/daypart/domain"
+)
+
+//go:generate mockgen -source=daypart.go -destination=mock/daypart.go -package=mock
+
+// DayPartRepository is an interface for interacting with day-part-related data
+type DayPartRepository interface {
+	// CreateDayPart inserts a new day-part and its windows into the database
+	CreateDayPart(ctx context.Context, dayPart *domain.DayPart) (*domain.DayPart, error)
+	// GetDayPartByID selects a day-part and its windows by id
+	GetDayPartByID(ctx context.Context, id uint64) (*domain.DayPart, error)
+	// ListDayParts selects a list of day-parts and their windows with pagination
+	ListDayParts(ctx context.Context, skip, limit uint64) ([]domain.DayPart, error)
+	// UpdateDayPart updates a day-part and, if given, replaces its windows
+	UpdateDayPart(ctx context.Context, dayPart *domain.DayPart) (*domain.DayPart, error)
+	// DeleteDayPart deletes a day-part
+	DeleteDayPart(ctx context.Context, id uint64) error
+}
+
+// DayPartService is an interface for interacting with day-part-related business logic
+type DayPartService interface {
+	// CreateDayPart creates a new day-part
+	CreateDayPart(ctx context.Context, dayPart *domain.DayPart) (*domain.DayPart, error)
+	// GetDayPart returns a day-part by id
+	GetDayPart(ctx context.Context, id uint64) (*domain.DayPart, error)
+	// ListDayParts returns a list of day-parts with pagination
+	ListDayParts(ctx context.Context, skip, limit uint64) ([]domain.DayPart, error)
+	// UpdateDayPart updates a day-part
+	UpdateDayPart(ctx context.Context, dayPart *domain.DayPart) (*domain.DayPart, error)
+	// DeleteDayPart deletes a day-part
+	DeleteDayPart(ctx context.Context, id uint64) error
+}
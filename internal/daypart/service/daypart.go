@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	auditdomain "go-restaurant/internal/audit/domain"
+	auditport "go-restaurant/internal/audit/port"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
+	cmutil "go-restaurant/internal/common/util"
+	"go-restaurant/internal/daypart/domain"
+	"go-restaurant/internal/daypart/port"
+)
+
+/*DayPartService implements port.DayPartService interface
+ * and provides access to the day-part repository,
+ * transactor, cache service and audit service
+ */
+type DayPartService struct {
+	repo       port.DayPartRepository
+	transactor cmport.Transactor
+	cache      cmport.CacheRepository
+	audit      auditport.AuditService
+}
+
+// NewDayPartService creates a new day-part service instance
+func NewDayPartService(repo port.DayPartRepository, transactor cmport.Transactor, cache cmport.CacheRepository, audit auditport.AuditService) *DayPartService {
+	return &DayPartService{
+		repo,
+		transactor,
+		cache,
+		audit,
+	}
+}
+
+// CreateDayPart creates a new day-part
+func (ds *DayPartService) CreateDayPart(ctx context.Context, dayPart *domain.DayPart) (*domain.DayPart, error) {
+	err := validateWindows(dayPart.Windows)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ds.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		dayPart, err = ds.repo.CreateDayPart(ctx, dayPart)
+		if err != nil {
+			return err
+		}
+
+		return ds.audit.Record(ctx, auditdomain.ActionCreate, auditdomain.EntityDayPart, dayPart.ID, nil, dayPart)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("day_part", dayPart.ID)
+	dayPartSerialized, err := cmutil.Serialize(dayPart)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ds.cache.Set(ctx, cacheKey, dayPartSerialized, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ds.cache.DeleteByPrefix(ctx, "day_parts:*")
+	if err != nil {
+		return nil, err
+	}
+
+	return dayPart, nil
+}
+
+// GetDayPart retrieves a day-part by id
+func (ds *DayPartService) GetDayPart(ctx context.Context, id uint64) (*domain.DayPart, error) {
+	var dayPart *domain.DayPart
+
+	cacheKey := cmutil.GenerateCacheKey("day_part", id)
+	cachedDayPart, err := ds.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := cmutil.Deserialize(cachedDayPart, &dayPart)
+		if err != nil {
+			return nil, err
+		}
+
+		return dayPart, nil
+	}
+
+	dayPart, err = ds.repo.GetDayPartByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	dayPartSerialized, err := cmutil.Serialize(dayPart)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ds.cache.Set(ctx, cacheKey, dayPartSerialized, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return dayPart, nil
+}
+
+// ListDayParts retrieves a list of day-parts
+func (ds *DayPartService) ListDayParts(ctx context.Context, skip, limit uint64) ([]domain.DayPart, error) {
+	var dayParts []domain.DayPart
+
+	params := cmutil.GenerateCacheKeyParams(skip, limit)
+	cacheKey := cmutil.GenerateCacheKey("day_parts", params)
+
+	cachedDayParts, err := ds.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := cmutil.Deserialize(cachedDayParts, &dayParts)
+		if err != nil {
+			return nil, err
+		}
+
+		return dayParts, nil
+	}
+
+	dayParts, err = ds.repo.ListDayParts(ctx, skip, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	dayPartsSerialized, err := cmutil.Serialize(dayParts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ds.cache.Set(ctx, cacheKey, dayPartsSerialized, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return dayParts, nil
+}
+
+// UpdateDayPart updates the name of a day-part and, if given, replaces its windows
+func (ds *DayPartService) UpdateDayPart(ctx context.Context, dayPart *domain.DayPart) (*domain.DayPart, error) {
+	existingDayPart, err := ds.repo.GetDayPartByID(ctx, dayPart.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	emptyData := dayPart.Name == "" && dayPart.Windows == nil
+	if emptyData {
+		return nil, cmdomain.ErrNoUpdatedData
+	}
+
+	err = validateWindows(dayPart.Windows)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ds.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := ds.repo.UpdateDayPart(ctx, dayPart)
+		if err != nil {
+			return err
+		}
+
+		return ds.audit.Record(ctx, auditdomain.ActionUpdate, auditdomain.EntityDayPart, dayPart.ID, existingDayPart, dayPart)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("day_part", dayPart.ID)
+	_ = ds.cache.Delete(ctx, cacheKey)
+
+	dayPartSerialized, err := cmutil.Serialize(dayPart)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ds.cache.Set(ctx, cacheKey, dayPartSerialized, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ds.cache.DeleteByPrefix(ctx, "day_parts:*")
+	if err != nil {
+		return nil, err
+	}
+
+	return dayPart, nil
+}
+
+// DeleteDayPart deletes a day-part, which lifts the restrictions of the products and categories to it
+func (ds *DayPartService) DeleteDayPart(ctx context.Context, id uint64) error {
+	existingDayPart, err := ds.repo.GetDayPartByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = ds.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := ds.repo.DeleteDayPart(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return ds.audit.Record(ctx, auditdomain.ActionDelete, auditdomain.EntityDayPart, id, existingDayPart, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("day_part", id)
+	_ = ds.cache.Delete(ctx, cacheKey)
+
+	for _, prefix := range []string{"day_parts:*", "products:*", "categories:*"} {
+		err = ds.cache.DeleteByPrefix(ctx, prefix)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateWindows checks that every window starts before it ends within the same day
+func validateWindows(windows []domain.DayPartWindow) error {
+	for _, window := range windows {
+		if !window.IsValid() {
+			return cmdomain.ErrInvalidDayPartWindow
+		}
+	}
+
+	return nil
+}
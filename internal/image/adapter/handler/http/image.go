@@ -0,0 +1,84 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+	cmdomain "go-restaurant/internal/common/domain"
+	"go-restaurant/internal/image/domain"
+	"go-restaurant/internal/image/port"
+	"mime/multipart"
+	"net/http"
+)
+
+// maxUploadSize is the size limit of an image upload request body, leaving room for the multipart encoding
+const maxUploadSize = domain.MaxSize + 1<<20
+
+// ImageHandler represents the HTTP handler for image-related requests
+type ImageHandler struct {
+	svc port.ImageService
+}
+
+// NewImageHandler creates a new ImageHandler instance
+func NewImageHandler(svc port.ImageService) *ImageHandler {
+	return &ImageHandler{
+		svc,
+	}
+}
+
+// getImageRequest represents a request body for retrieving an image
+type getImageRequest struct {
+	Key string `uri:"key" binding:"required" example:"products/1/0c2b7d5e-5d6f-4c1a-9f0e-2f8f3c1e4b6a.png"`
+}
+
+// GetImage godoc
+//
+//	@Summary		Get an image
+//	@Description	Get an uploaded image or thumbnail by key. Images never change once uploaded, so they can be cached for good
+//	@Tags			Images
+//	@Produce		image/jpeg,image/png,image/gif
+//	@Param			key	path		string			true	"Image key"
+//	@Success		200	{file}		binary			"Image"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/images/{key} [get]
+func (ih *ImageHandler) GetImage(ctx *gin.Context) {
+	var req getImageRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	file, err := ih.svc.GetImage(ctx, req.Key)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+	defer file.Body.Close()
+
+	ctx.DataFromReader(http.StatusOK, file.Size, file.ContentType, file.Body, map[string]string{
+		"Cache-Control":          "public, max-age=31536000, immutable",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// FormImage opens the image uploaded in the image field of a multipart form, limiting the size of the request body
+// so an oversized upload is rejected before it is read in full
+func FormImage(ctx *gin.Context) (multipart.File, error) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxUploadSize)
+
+	header, err := ctx.FormFile("image")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, cmdomain.ErrImageTooLarge
+		}
+		return nil, cmdomain.ErrMissingImage
+	}
+
+	if header.Size > domain.MaxSize {
+		return nil, cmdomain.ErrImageTooLarge
+	}
+
+	return header.Open()
+}
@@ -0,0 +1,27 @@
+package http
+
+import (
+	"go-restaurant/internal/image/domain"
+)
+
+// ImageResponse represents an uploaded image Response body
+type ImageResponse struct {
+	URL          string `json:"url" example:"/v1/images/products/1/0c2b7d5e-5d6f-4c1a-9f0e-2f8f3c1e4b6a.png"`
+	ThumbnailURL string `json:"thumbnail_url" example:"/v1/images/thumbnails/products/1/0c2b7d5e-5d6f-4c1a-9f0e-2f8f3c1e4b6a.png"`
+	ContentType  string `json:"content_type" example:"image/png"`
+	Size         int64  `json:"size" example:"48213"`
+	Width        int    `json:"width" example:"1024"`
+	Height       int    `json:"height" example:"768"`
+}
+
+// NewImageResponse is a helper function to create a Response body for handling uploaded image data
+func NewImageResponse(image *domain.Image) ImageResponse {
+	return ImageResponse{
+		URL:          image.URL,
+		ThumbnailURL: image.ThumbnailURL,
+		ContentType:  image.ContentType,
+		Size:         image.Size,
+		Width:        image.Width,
+		Height:       image.Height,
+	}
+}
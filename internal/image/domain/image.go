@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"path"
+	"strings"
+)
+
+// MaxSize is the size limit of an uploaded image in bytes
+const MaxSize = 5 << 20
+
+// MaxDimension is the limit of the width and height of an uploaded image in pixels
+const MaxDimension = 4096
+
+// ThumbnailSize is the size of the square a thumbnail of an image fits in, in pixels
+const ThumbnailSize = 320
+
+// ProductsPrefix is the prefix the images of products are stored under
+const ProductsPrefix = "products"
+
+// PaymentsPrefix is the prefix the logos of payments are stored under
+const PaymentsPrefix = "payments"
+
+// ThumbnailsPrefix is the prefix the thumbnails of images are stored under, followed by the key of their image
+const ThumbnailsPrefix = "thumbnails"
+
+// prefixes are the prefixes images can be uploaded under
+var prefixes = []string{ProductsPrefix, PaymentsPrefix}
+
+// Image is an entity that represents an uploaded image and its thumbnail,
+// stored by key and served at their URLs
+type Image struct {
+	Key          string
+	ThumbnailKey string
+	URL          string
+	ThumbnailURL string
+	ContentType  string
+	Size         int64
+	Width        int
+	Height       int
+}
+
+// ThumbnailKey returns the key the thumbnail of the image stored under a key is stored under,
+// which has the same format as the image
+func ThumbnailKey(key string) string {
+	return path.Join(ThumbnailsPrefix, strings.TrimPrefix(key, "/"))
+}
+
+// ValidKey reports whether a key is one images are stored under, which is a clean relative path
+// under the prefix images are uploaded under, or the thumbnail of one
+func ValidKey(key string) bool {
+	if path.Clean(key) != key || path.IsAbs(key) {
+		return false
+	}
+
+	key = strings.TrimPrefix(key, ThumbnailsPrefix+"/")
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
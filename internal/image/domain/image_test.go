@@ -0,0 +1,45 @@
+package domain
+
+import "testing"
+
+func TestValidKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"products/1/image.png", true},
+		{"payments/1/image.png", true},
+		{"thumbnails/products/1/image.png", true},
+		{"thumbnails/payments/1/image.png", true},
+		{"products", false},
+		{"products/", false},
+		{"thumbnails/thumbnails/products/1/image.png", false},
+		{"thumbnails/image.png", false},
+		{"uploads/image.png", false},
+		{"products/../uploads/image.png", false},
+		{"/products/1/image.png", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got := ValidKey(tt.key)
+			if got != tt.want {
+				t.Fatalf("ValidKey(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThumbnailKey(t *testing.T) {
+	key := "products/1/image.png"
+
+	thumbnailKey := ThumbnailKey(key)
+	if thumbnailKey != "thumbnails/products/1/image.png" {
+		t.Fatalf("got thumbnail key %q", thumbnailKey)
+	}
+
+	if !ValidKey(thumbnailKey) {
+		t.Fatalf("thumbnail key %q is not valid", thumbnailKey)
+	}
+}
@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	cmdomain "go-restaurant/internal/common/domain"
+	"go-restaurant/internal/image/domain"
+	"io"
+)
+
+//go:generate mockgen -source=image.go -destination=mock/image.go -package=mock
+
+// ImageService is an interface for interacting with image-related business logic
+type ImageService interface {
+	// UploadImage checks an uploaded image and stores it and its thumbnail under a key prefix
+	UploadImage(ctx context.Context, prefix string, r io.Reader) (*domain.Image, error)
+	// GetImage opens a stored image or thumbnail by key
+	GetImage(ctx context.Context, key string) (*cmdomain.File, error)
+	// DeleteImage deletes a stored image and its thumbnail by URL, ignoring URLs of images stored elsewhere
+	DeleteImage(ctx context.Context, url string) error
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
+	"go-restaurant/internal/image/domain"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// imageExtensions maps the content types of the supported image formats to the extensions they are stored with
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+/*ImageService implements port.ImageService interface
+ * and provides access to the file storage and
+ * the base URL the images are served at
+ */
+type ImageService struct {
+	files   cmport.FileStorage
+	baseURL string
+}
+
+// NewImageService creates a new image service instance. Images are served by the API itself,
+// at URLs relative to it unless its public URL is given
+func NewImageService(files cmport.FileStorage, publicURL string) *ImageService {
+	return &ImageService{
+		files,
+		strings.TrimSuffix(publicURL, "/") + "/v1/images/",
+	}
+}
+
+// UploadImage checks that an uploaded image is a JPEG, PNG or GIF by sniffing its content, that it fits the size
+// and dimension limits and that it can be decoded, then stores it as it is under a random key with the prefix
+// and a thumbnail of it in the same format
+func (is *ImageService) UploadImage(ctx context.Context, prefix string, r io.Reader) (*domain.Image, error) {
+	data, err := io.ReadAll(io.LimitReader(r, domain.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > domain.MaxSize {
+		return nil, cmdomain.ErrImageTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return nil, cmdomain.ErrUnsupportedImageType
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, cmdomain.ErrInvalidImage
+	}
+
+	if config.Width <= 0 || config.Height <= 0 || config.Width > domain.MaxDimension || config.Height > domain.MaxDimension {
+		return nil, cmdomain.ErrInvalidImage
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, cmdomain.ErrInvalidImage
+	}
+
+	var thumbnail bytes.Buffer
+	err = encode(&thumbnail, contentType, scaleDown(src, domain.ThumbnailSize))
+	if err != nil {
+		return nil, err
+	}
+
+	key := path.Join(prefix, uuid.NewString()+ext)
+	if !domain.ValidKey(key) {
+		return nil, fmt.Errorf("images cannot be uploaded under %q", prefix)
+	}
+
+	img := &domain.Image{
+		Key:          key,
+		ThumbnailKey: domain.ThumbnailKey(key),
+		URL:          is.baseURL + key,
+		ThumbnailURL: is.baseURL + domain.ThumbnailKey(key),
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+		Width:        config.Width,
+		Height:       config.Height,
+	}
+
+	err = is.files.Put(ctx, img.Key, bytes.NewReader(data), img.Size, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = is.files.Put(ctx, img.ThumbnailKey, &thumbnail, int64(thumbnail.Len()), contentType)
+	if err != nil {
+		_ = is.files.Delete(ctx, img.Key)
+		return nil, err
+	}
+
+	return img, nil
+}
+
+// GetImage opens a stored image or thumbnail by key, so other files in the storage cannot be read
+func (is *ImageService) GetImage(ctx context.Context, key string) (*cmdomain.File, error) {
+	key = strings.TrimPrefix(key, "/")
+	if !domain.ValidKey(key) {
+		return nil, cmdomain.ErrDataNotFound
+	}
+
+	return is.files.Get(ctx, key)
+}
+
+// DeleteImage deletes a stored image and its thumbnail by URL, ignoring URLs of images stored elsewhere,
+// such as the ones entered before images could be uploaded
+func (is *ImageService) DeleteImage(ctx context.Context, url string) error {
+	key, ok := strings.CutPrefix(url, is.baseURL)
+	if !ok || !domain.ValidKey(key) {
+		return nil
+	}
+
+	err := is.files.Delete(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	return is.files.Delete(ctx, domain.ThumbnailKey(key))
+}
+
+// encode encodes an image in the format of a content type
+func encode(w io.Writer, contentType string, img image.Image) error {
+	switch contentType {
+	case "image/jpeg":
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: 85})
+	case "image/gif":
+		return gif.Encode(w, img, nil)
+	default:
+		return png.Encode(w, img)
+	}
+}
+
+// scaleDown scales an image down to fit in a square of size pixels, keeping its aspect ratio,
+// where every pixel is the average of the pixels of the image it covers. Images that already fit are copied as they are
+func scaleDown(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width > size || height > size {
+		if width >= height {
+			width, height = size, max(1, height*size/width)
+		} else {
+			width, height = max(1, width*size/height), size
+		}
+	}
+
+	dst := image.NewRGBA64(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := bounds.Min.Y + (y+1)*bounds.Dy()/height
+
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := bounds.Min.X + (x+1)*bounds.Dx()/width
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+
+	return dst
+}
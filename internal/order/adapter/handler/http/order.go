@@ -2,12 +2,16 @@ package http
 
 import (
 	"github.com/gin-gonic/gin"
+	adomain "go-restaurant/internal/auth/domain"
 	autil "go-restaurant/internal/auth/util"
+	chdomain "go-restaurant/internal/channel/domain"
 	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+	cmdomain "go-restaurant/internal/common/domain"
 	cmutil "go-restaurant/internal/common/util"
 	"go-restaurant/internal/order/domain"
 	"go-restaurant/internal/order/port"
 	opdomain "go-restaurant/internal/orderproduct/domain"
+	"time"
 )
 
 // OrderHandler represents the HTTP handler for order-related requests
@@ -22,32 +26,45 @@ func NewOrderHandler(svc port.OrderService) *OrderHandler {
 	}
 }
 
-// orderProductRequest represents an order product request body
+// orderProductChoiceRequest represents a request body for the product chosen for a slot of an ordered bundle
+type orderProductChoiceRequest struct {
+	SlotID    uint64 `json:"slot_id" binding:"required,min=1" example:"1"`
+	ProductID uint64 `json:"product_id" binding:"required,min=1" example:"2"`
+}
+
+// orderProductRequest represents an order product request body. The slots of a bundle
+// that are not chosen for are filled with their default choice
 type orderProductRequest struct {
-	ProductID uint64 `json:"product_id" binding:"required,min=1" example:"1"`
-	Quantity  int64  `json:"qty" binding:"required,number" example:"1"`
+	ProductID uint64                      `json:"product_id" binding:"required,min=1" example:"1"`
+	Quantity  int64                       `json:"qty" binding:"required,number" example:"1"`
+	Choices   []orderProductChoiceRequest `json:"choices" binding:"omitempty,dive"`
 }
 
 // createOrderRequest represents a request body for creating a new order
 type createOrderRequest struct {
 	PaymentID    uint64                `json:"payment_id" binding:"required" example:"1"`
 	CustomerName string                `json:"customer_name" binding:"required" example:"John Doe"`
+	Channel      chdomain.Channel      `json:"channel" binding:"omitempty,channel" example:"dine_in"`
+	Discount     int64                 `json:"discount" binding:"omitempty,min=0" example:"0"`
 	TotalPaid    int64                 `json:"total_paid" binding:"required" example:"100000"`
 	Products     []orderProductRequest `json:"products" binding:"required"`
+	Approval     *approvalRequest      `json:"approval" binding:"omitempty"`
 }
 
 // CreateOrder godoc
 //
 //	@Summary		Create a new order
-//	@Description	Create a new order and return the order data with purchase details
+//	@Description	Create a new order through a channel, dine-in unless given, and return the order data with purchase details. Products are charged the prices of the channel's price list and must be on its menu. A bundle is charged as a unit and ordered with a choice of product for each of its slots. Users whose role does not allow giving discounts must send the PIN or an approval token of a user whose role does for a discount larger than the store's discount limit, and API keys need the order.discount scope for it
 //	@Tags			Orders
 //	@Accept			json
 //	@Produce		json
 //	@Param			createOrderRequest	body		createOrderRequest	true	"Create order request"
 //	@Success		200					{object}	orderResponse		"Order created"
 //	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
 //	@Failure		404					{object}	errorResponse		"Data not found error"
 //	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		429					{object}	errorResponse		"Too many attempts error"
 //	@Failure		500					{object}	errorResponse		"Internal server error"
 //	@Router			/orders [post]
 //	@Security		BearerAuth
@@ -61,9 +78,18 @@ func (oh *OrderHandler) CreateOrder(ctx *gin.Context) {
 	}
 
 	for _, product := range req.Products {
+		var components []opdomain.OrderProductComponent
+		for _, choice := range product.Choices {
+			components = append(components, opdomain.OrderProductComponent{
+				SlotID:    choice.SlotID,
+				ProductID: choice.ProductID,
+			})
+		}
+
 		products = append(products, opdomain.OrderProduct{
-			ProductID: product.ProductID,
-			Quantity:  product.Quantity,
+			ProductID:  product.ProductID,
+			Quantity:   product.Quantity,
+			Components: components,
 		})
 	}
 
@@ -73,11 +99,13 @@ func (oh *OrderHandler) CreateOrder(ctx *gin.Context) {
 		UserID:       authPayload.UserID,
 		PaymentID:    req.PaymentID,
 		CustomerName: req.CustomerName,
+		Channel:      req.Channel,
+		Discount:     float64(req.Discount),
 		TotalPaid:    float64(req.TotalPaid),
 		Products:     products,
 	}
 
-	_, err := oh.svc.CreateOrder(ctx, &order)
+	_, err := oh.svc.CreateOrder(ctx, &order, authPayload, newApprovalRequest(req.Approval))
 	if err != nil {
 		cmhttp.HandleError(ctx, err)
 		return
@@ -125,25 +153,166 @@ func (oh *OrderHandler) GetOrder(ctx *gin.Context) {
 	cmhttp.HandleSuccess(ctx, rsp)
 }
 
+// approvalRequest represents the approval of a sensitive request, either the ID and PIN
+// of the approving user or an approval token they created
+type approvalRequest struct {
+	ApproverID uint64 `json:"approver_id" binding:"required_with=PIN" example:"2"`
+	PIN        string `json:"pin" binding:"omitempty,numeric,min=4,max=8" example:"4821"`
+	Token      string `json:"token" binding:"omitempty" example:"Q2hpa2kgQmFsbCBhcHByb3ZhbCB0b2tlbg"`
+}
+
+// newApprovalRequest is a helper function to convert the approval of a request body, if it carries one
+func newApprovalRequest(req *approvalRequest) *adomain.ApprovalRequest {
+	if req == nil {
+		return nil
+	}
+
+	return &adomain.ApprovalRequest{
+		ApproverID: req.ApproverID,
+		PIN:        req.PIN,
+		Token:      req.Token,
+	}
+}
+
+// voidOrderRequest represents a request body for voiding an order
+type voidOrderRequest struct {
+	Reason   string           `json:"reason" binding:"required" example:"Customer changed their mind"`
+	Approval *approvalRequest `json:"approval" binding:"omitempty"`
+}
+
+// VoidOrder godoc
+//
+//	@Summary		Void an order
+//	@Description	Void an order and put its products back in stock. Users whose role does not allow voiding orders must send the PIN or an approval token of a user whose role does. API keys need the order.void scope
+//	@Tags			Orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		uint64				true	"Order ID"
+//	@Param			voidOrderRequest	body		voidOrderRequest	true	"Void order request"
+//	@Success		200					{object}	orderResponse		"Order voided"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		429					{object}	errorResponse		"Too many attempts error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/orders/{id}/void [post]
+//	@Security		BearerAuth
+func (oh *OrderHandler) VoidOrder(ctx *gin.Context) {
+	var uri getOrderRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	var req voidOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	authPayload := autil.GetAuthPayload(ctx, cmhttp.AuthorizationPayloadKey)
+
+	void := domain.OrderVoid{
+		OrderID: uri.ID,
+		UserID:  authPayload.UserID,
+		Reason:  req.Reason,
+	}
+
+	order, err := oh.svc.VoidOrder(ctx, &void, authPayload, newApprovalRequest(req.Approval))
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := NewOrderResponse(order)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// refundOrderRequest represents a request body for refunding an order
+type refundOrderRequest struct {
+	Amount   int64            `json:"amount" binding:"required,min=1" example:"25000"`
+	Reason   string           `json:"reason" binding:"required" example:"Dish arrived cold"`
+	Approval *approvalRequest `json:"approval" binding:"omitempty"`
+}
+
+// RefundOrder godoc
+//
+//	@Summary		Refund an order
+//	@Description	Give back part or all of the total price of an order without putting its products back in stock. The refunds of an order cannot add up to more than its total price. Users whose role does not allow refunding orders must send the PIN or an approval token of a user whose role does. API keys need the order.refund scope
+//	@Tags			Orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		uint64				true	"Order ID"
+//	@Param			refundOrderRequest	body		refundOrderRequest	true	"Refund order request"
+//	@Success		200					{object}	orderResponse		"Order refunded"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		429					{object}	errorResponse		"Too many attempts error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/orders/{id}/refund [post]
+//	@Security		BearerAuth
+func (oh *OrderHandler) RefundOrder(ctx *gin.Context) {
+	var uri getOrderRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	var req refundOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	authPayload := autil.GetAuthPayload(ctx, cmhttp.AuthorizationPayloadKey)
+
+	refund := domain.OrderRefund{
+		OrderID: uri.ID,
+		UserID:  authPayload.UserID,
+		Amount:  float64(req.Amount),
+		Reason:  req.Reason,
+	}
+
+	order, err := oh.svc.RefundOrder(ctx, &refund, authPayload, newApprovalRequest(req.Approval))
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := NewOrderResponse(order)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
 // listOrdersRequest represents a request body for listing orders
 type listOrdersRequest struct {
-	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
-	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+	StartDate time.Time `form:"start_date" binding:"omitempty" time_format:"2006-01-02" example:"2024-01-01"`
+	EndDate   time.Time `form:"end_date" binding:"omitempty" time_format:"2006-01-02" example:"2024-01-31"`
+	Skip      uint64    `form:"skip" binding:"required,min=0" example:"0"`
+	Limit     uint64    `form:"limit" binding:"required,min=5" example:"5"`
 }
 
 // ListOrders godoc
 //
 //	@Summary		List orders
-//	@Description	List orders and return an array of order data with purchase details
+//	@Description	List orders of a business date range and return an array of order data with purchase details
 //	@Tags			Orders
 //	@Accept			json
 //	@Produce		json
-//	@Param			skip	query		uint64			true	"Skip records"
-//	@Param			limit	query		uint64			true	"Limit records"
-//	@Success		200		{object}	meta			"Orders displayed"
-//	@Failure		400		{object}	errorResponse	"Validation error"
-//	@Failure		401		{object}	errorResponse	"Unauthorized error"
-//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Param			start_date	query		string			false	"Start business date (YYYY-MM-DD)"
+//	@Param			end_date	query		string			false	"End business date, inclusive (YYYY-MM-DD)"
+//	@Param			skip		query		uint64			true	"Skip records"
+//	@Param			limit		query		uint64			true	"Limit records"
+//	@Success		200			{object}	meta			"Orders displayed"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
 //	@Router			/orders [get]
 //	@Security		BearerAuth
 func (oh *OrderHandler) ListOrders(ctx *gin.Context) {
@@ -155,7 +324,7 @@ func (oh *OrderHandler) ListOrders(ctx *gin.Context) {
 		return
 	}
 
-	orders, err := oh.svc.ListOrders(ctx, req.Skip, req.Limit)
+	orders, err := oh.svc.ListOrders(ctx, req.StartDate, req.EndDate, req.Skip, req.Limit)
 	if err != nil {
 		cmhttp.HandleError(ctx, err)
 		return
@@ -171,3 +340,85 @@ func (oh *OrderHandler) ListOrders(ctx *gin.Context) {
 
 	cmhttp.HandleSuccess(ctx, rsp)
 }
+
+// exportOrdersRequest represents a request body for exporting orders
+type exportOrdersRequest struct {
+	StartDate time.Time             `form:"start_date" binding:"omitempty" time_format:"2006-01-02" example:"2024-01-01"`
+	EndDate   time.Time             `form:"end_date" binding:"omitempty" time_format:"2006-01-02" example:"2024-01-31"`
+	Format    cmdomain.ExportFormat `form:"format" binding:"omitempty,export_format" example:"csv"`
+}
+
+// ExportOrders godoc
+//
+//	@Summary		Export orders
+//	@Description	Export the orders of a business date range with one row per line item as a CSV or XLSX file
+//	@Tags			Orders
+//	@Accept			json
+//	@Produce		text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			start_date	query		string			false	"Start business date (YYYY-MM-DD)"
+//	@Param			end_date	query		string			false	"End business date, inclusive (YYYY-MM-DD)"
+//	@Param			format		query		string			false	"File format"	Enums(csv, xlsx)
+//	@Success		200			{file}		file			"Orders exported"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/orders/export [get]
+//	@Security		BearerAuth
+func (oh *OrderHandler) ExportOrders(ctx *gin.Context) {
+	var req exportOrdersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	writer, err := cmhttp.NewExportWriter(ctx, req.Format, "orders")
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	err = writer.WriteHeader(
+		"Order ID",
+		"Receipt Code",
+		"Business Date",
+		"Created At",
+		"User ID",
+		"Payment ID",
+		"Customer Name",
+		"Product ID",
+		"SKU",
+		"Product Name",
+		"Quantity",
+		"Line Total",
+		"Order Total",
+		"Total Paid",
+		"Total Return",
+	)
+	if err != nil {
+		cmhttp.HandleExport(ctx, writer, err)
+		return
+	}
+
+	err = oh.svc.ExportOrders(ctx, req.StartDate, req.EndDate, func(order *domain.Order, orderProduct *opdomain.OrderProduct) error {
+		return writer.WriteRow(
+			order.ID,
+			order.ReceiptCode.String(),
+			order.BusinessDate.Format(time.DateOnly),
+			order.CreatedAt,
+			order.UserID,
+			order.PaymentID,
+			order.CustomerName,
+			orderProduct.ProductID,
+			orderProduct.ProductSKU.String(),
+			orderProduct.ProductName,
+			orderProduct.Quantity,
+			orderProduct.TotalPrice,
+			order.TotalPrice,
+			order.TotalPaid,
+			order.TotalReturn,
+		)
+	})
+
+	cmhttp.HandleExport(ctx, writer, err)
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	chdomain "go-restaurant/internal/channel/domain"
 	"go-restaurant/internal/order/domain"
 	ophttp "go-restaurant/internal/orderproduct/adapter/handler/http"
 	phttp "go-restaurant/internal/payment/adapter/handler/http"
@@ -13,12 +14,19 @@ type OrderResponse struct {
 	UserID       uint64                        `json:"user_id" example:"1"`
 	PaymentID    uint64                        `json:"payment_type_id" example:"1"`
 	CustomerName string                        `json:"customer_name" example:"John Doe"`
+	Channel      chdomain.Channel              `json:"channel" example:"dine_in"`
+	Discount     float64                       `json:"discount" example:"0"`
+	DiscountBy   *uint64                       `json:"discount_approved_by,omitempty" example:"2"`
 	TotalPrice   float64                       `json:"total_price" example:"100000"`
 	TotalPaid    float64                       `json:"total_paid" example:"100000"`
 	TotalReturn  float64                       `json:"total_return" example:"0"`
+	TotalRefund  float64                       `json:"total_refund" example:"0"`
 	ReceiptCode  string                        `json:"receipt_id" example:"4979cf6e-d215-4ff8-9d0d-b3e99bcc7750"`
+	BusinessDate string                        `json:"business_date" example:"1970-01-01"`
 	Products     []ophttp.OrderProductResponse `json:"products"`
 	PaymentType  phttp.PaymentResponse         `json:"payment_type"`
+	Void         *orderVoidResponse            `json:"void,omitempty"`
+	Refunds      []orderRefundResponse         `json:"refunds"`
 	CreatedAt    time.Time                     `json:"created_at" example:"1970-01-01T00:00:00Z"`
 	UpdatedAt    time.Time                     `json:"updated_at" example:"1970-01-01T00:00:00Z"`
 }
@@ -30,13 +38,69 @@ func NewOrderResponse(order *domain.Order) OrderResponse {
 		UserID:       order.UserID,
 		PaymentID:    order.PaymentID,
 		CustomerName: order.CustomerName,
+		Channel:      order.Channel,
+		Discount:     order.Discount,
+		DiscountBy:   order.DiscountApprovedBy,
 		TotalPrice:   order.TotalPrice,
 		TotalPaid:    order.TotalPaid,
 		TotalReturn:  order.TotalReturn,
+		TotalRefund:  order.TotalRefunded(),
 		ReceiptCode:  order.ReceiptCode.String(),
+		BusinessDate: order.BusinessDate.Format(time.DateOnly),
 		Products:     ophttp.NewOrderProductResponse(order.Products),
 		PaymentType:  phttp.NewPaymentResponse(order.Payment),
+		Void:         newOrderVoidResponse(order.Void),
+		Refunds:      newOrderRefundResponse(order.Refunds),
 		CreatedAt:    order.CreatedAt,
 		UpdatedAt:    order.UpdatedAt,
 	}
 }
+
+// orderVoidResponse represents an order void Response body
+type orderVoidResponse struct {
+	UserID     uint64    `json:"user_id" example:"3"`
+	ApprovedBy uint64    `json:"approved_by" example:"2"`
+	Reason     string    `json:"reason" example:"Customer changed their mind"`
+	CreatedAt  time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newOrderVoidResponse is a helper function to create a Response body for handling order void data
+func newOrderVoidResponse(void *domain.OrderVoid) *orderVoidResponse {
+	if void == nil {
+		return nil
+	}
+
+	return &orderVoidResponse{
+		UserID:     void.UserID,
+		ApprovedBy: void.ApprovedBy,
+		Reason:     void.Reason,
+		CreatedAt:  void.CreatedAt,
+	}
+}
+
+// orderRefundResponse represents an order refund Response body
+type orderRefundResponse struct {
+	ID         uint64    `json:"id" example:"1"`
+	UserID     uint64    `json:"user_id" example:"3"`
+	ApprovedBy uint64    `json:"approved_by" example:"2"`
+	Amount     float64   `json:"amount" example:"25000"`
+	Reason     string    `json:"reason" example:"Dish arrived cold"`
+	CreatedAt  time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newOrderRefundResponse is a helper function to create a Response body for handling order refund data
+func newOrderRefundResponse(refunds []domain.OrderRefund) []orderRefundResponse {
+	rsp := make([]orderRefundResponse, 0, len(refunds))
+	for _, refund := range refunds {
+		rsp = append(rsp, orderRefundResponse{
+			ID:         refund.ID,
+			UserID:     refund.UserID,
+			ApprovedBy: refund.ApprovedBy,
+			Amount:     refund.Amount,
+			Reason:     refund.Reason,
+			CreatedAt:  refund.CreatedAt,
+		})
+	}
+
+	return rsp
+}
@@ -34,8 +34,8 @@ func (or *OrderRepository) CreateOrder(ctx context.Context, order *domain.Order)
 	var products []opdomain.OrderProduct
 
 	orderQuery := or.db.QueryBuilder.Insert("orders").
-		Columns("user_id", "payment_id", "customer_name", "total_price", "total_paid", "total_return").
-		Values(order.UserID, order.PaymentID, order.CustomerName, order.TotalPrice, order.TotalPaid, order.TotalReturn).
+		Columns("user_id", "payment_id", "customer_name", "total_price", "total_paid", "total_return", "channel", "discount", "discount_approved_by").
+		Values(order.UserID, order.PaymentID, order.CustomerName, order.TotalPrice, order.TotalPaid, order.TotalReturn, order.Channel, order.Discount, order.DiscountApprovedBy).
 		Suffix("RETURNING *")
 
 	err := pgx.BeginFunc(ctx, or.db, func(tx pgx.Tx) error {
@@ -55,6 +55,9 @@ func (or *OrderRepository) CreateOrder(ctx context.Context, order *domain.Order)
 			&order.ReceiptCode,
 			&order.CreatedAt,
 			&order.UpdatedAt,
+			&order.Channel,
+			&order.Discount,
+			&order.DiscountApprovedBy,
 		)
 		if err != nil {
 			return err
@@ -62,8 +65,8 @@ func (or *OrderRepository) CreateOrder(ctx context.Context, order *domain.Order)
 
 		for _, orderProduct := range order.Products {
 			orderProductQuery := or.db.QueryBuilder.Insert("order_products").
-				Columns("order_id", "product_id", "quantity", "total_price").
-				Values(order.ID, orderProduct.ProductID, orderProduct.Quantity, orderProduct.TotalPrice).
+				Columns("order_id", "product_id", "quantity", "total_price", "product_sku", "product_name", "unit_price", "category_name").
+				Values(order.ID, orderProduct.ProductID, orderProduct.Quantity, orderProduct.TotalPrice, orderProduct.ProductSKU, orderProduct.ProductName, orderProduct.UnitPrice, orderProduct.CategoryName).
 				Suffix("RETURNING *")
 
 			sql, args, err := orderProductQuery.ToSql()
@@ -79,34 +82,59 @@ func (or *OrderRepository) CreateOrder(ctx context.Context, order *domain.Order)
 				&orderProduct.TotalPrice,
 				&orderProduct.CreatedAt,
 				&orderProduct.UpdatedAt,
+				&orderProduct.ProductSKU,
+				&orderProduct.ProductName,
+				&orderProduct.UnitPrice,
+				&orderProduct.CategoryName,
 			)
 			if err != nil {
 				return err
 			}
 
-			products = append(products, orderProduct)
-
-			productQuery := or.db.QueryBuilder.Update("products").
-				Set("stock", sq.Expr("stock - ?", orderProduct.Quantity)).
-				Set("updated_at", time.Now()).
-				Where(sq.Eq{"id": orderProduct.ProductID}).
-				Suffix("RETURNING stock")
+			if len(orderProduct.Components) == 0 {
+				product.Stock, err = or.takeStock(ctx, tx, orderProduct.ProductID, orderProduct.Quantity)
+				if err != nil {
+					return err
+				}
 
-			sql, args, err = productQuery.ToSql()
-			if err != nil {
-				return err
+				if product.Stock < 0 {
+					return cmdomain.ErrInsufficientStock
+				}
 			}
 
-			err = tx.QueryRow(ctx, sql, args...).Scan(
-				&product.Stock,
-			)
-			if err != nil {
-				return err
-			}
+			for i := range orderProduct.Components {
+				component := &orderProduct.Components[i]
+
+				componentQuery := or.db.QueryBuilder.Insert("order_product_components").
+					Columns("order_product_id", "product_id", "slot_name", "product_sku", "product_name", "quantity").
+					Values(orderProduct.ID, component.ProductID, component.SlotName, component.ProductSKU, component.ProductName, component.Quantity).
+					Suffix("RETURNING id, order_product_id, created_at")
 
-			if product.Stock < 0 {
-				return tx.Rollback(ctx)
+				sql, args, err := componentQuery.ToSql()
+				if err != nil {
+					return err
+				}
+
+				err = tx.QueryRow(ctx, sql, args...).Scan(
+					&component.ID,
+					&component.OrderProductID,
+					&component.CreatedAt,
+				)
+				if err != nil {
+					return err
+				}
+
+				product.Stock, err = or.takeStock(ctx, tx, component.ProductID, component.Quantity)
+				if err != nil {
+					return err
+				}
+
+				if product.Stock < 0 {
+					return cmdomain.ErrInsufficientStock
+				}
 			}
+
+			products = append(products, orderProduct)
 		}
 
 		order.Products = products
@@ -120,6 +148,82 @@ func (or *OrderRepository) CreateOrder(ctx context.Context, order *domain.Order)
 	return order, err
 }
 
+// takeStock takes a quantity of a product from its stock inside a transaction and returns the stock left
+func (or *OrderRepository) takeStock(ctx context.Context, tx pgx.Tx, productID uint64, quantity int64) (int64, error) {
+	var stock int64
+
+	query := or.db.QueryBuilder.Update("products").
+		Set("stock", sq.Expr("stock - ?", quantity)).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": productID}).
+		Suffix("RETURNING stock")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(&stock)
+	if err != nil {
+		return 0, err
+	}
+
+	return stock, nil
+}
+
+// getOrderComponents gets the components of the bundles ordered in an order inside a transaction,
+// grouped by the id of their order product
+func (or *OrderRepository) getOrderComponents(ctx context.Context, tx pgx.Tx, orderID uint64) (map[uint64][]opdomain.OrderProductComponent, error) {
+	var component opdomain.OrderProductComponent
+	components := make(map[uint64][]opdomain.OrderProductComponent)
+
+	query := or.db.QueryBuilder.Select(
+		"order_product_components.id",
+		"order_product_components.order_product_id",
+		"order_product_components.product_id",
+		"order_product_components.slot_name",
+		"order_product_components.product_sku",
+		"order_product_components.product_name",
+		"order_product_components.quantity",
+		"order_product_components.created_at",
+	).
+		From("order_product_components").
+		Join("order_products ON order_products.id = order_product_components.order_product_id").
+		Where(sq.Eq{"order_products.order_id": orderID}).
+		OrderBy("order_product_components.id")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&component.ID,
+			&component.OrderProductID,
+			&component.ProductID,
+			&component.SlotName,
+			&component.ProductSKU,
+			&component.ProductName,
+			&component.Quantity,
+			&component.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		components[component.OrderProductID] = append(components[component.OrderProductID], component)
+	}
+
+	return components, rows.Err()
+}
+
 // GetOrderByID gets an order by ID from the database
 func (or *OrderRepository) GetOrderByID(ctx context.Context, id uint64) (*domain.Order, error) {
 	var order domain.Order
@@ -152,6 +256,9 @@ func (or *OrderRepository) GetOrderByID(ctx context.Context, id uint64) (*domain
 			&order.ReceiptCode,
 			&order.CreatedAt,
 			&order.UpdatedAt,
+			&order.Channel,
+			&order.Discount,
+			&order.DiscountApprovedBy,
 		)
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
@@ -179,6 +286,10 @@ func (or *OrderRepository) GetOrderByID(ctx context.Context, id uint64) (*domain
 				&orderProduct.TotalPrice,
 				&orderProduct.CreatedAt,
 				&orderProduct.UpdatedAt,
+				&orderProduct.ProductSKU,
+				&orderProduct.ProductName,
+				&orderProduct.UnitPrice,
+				&orderProduct.CategoryName,
 			)
 			if err != nil {
 				return err
@@ -187,6 +298,25 @@ func (or *OrderRepository) GetOrderByID(ctx context.Context, id uint64) (*domain
 			order.Products = append(order.Products, orderProduct)
 		}
 
+		components, err := or.getOrderComponents(ctx, tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		for i, orderProduct := range order.Products {
+			order.Products[i].Components = components[orderProduct.ID]
+		}
+
+		order.Void, err = or.getOrderVoid(ctx, tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		order.Refunds, err = or.getOrderRefunds(ctx, tx, order.ID)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -196,8 +326,8 @@ func (or *OrderRepository) GetOrderByID(ctx context.Context, id uint64) (*domain
 	return &order, nil
 }
 
-// ListOrders lists all orders from the database
-func (or *OrderRepository) ListOrders(ctx context.Context, skip, limit uint64) ([]domain.Order, error) {
+// ListOrders lists all orders created in the given time range from the database
+func (or *OrderRepository) ListOrders(ctx context.Context, startDate, endDate time.Time, skip, limit uint64) ([]domain.Order, error) {
 	var order domain.Order
 	var orderProduct opdomain.OrderProduct
 	var orders []domain.Order
@@ -208,6 +338,8 @@ func (or *OrderRepository) ListOrders(ctx context.Context, skip, limit uint64) (
 		Limit(limit).
 		Offset((skip - 1) * limit)
 
+	ordersQuery = filterOrders(ordersQuery, startDate, endDate)
+
 	err := pgx.BeginFunc(ctx, or.db, func(tx pgx.Tx) error {
 		sql, args, err := ordersQuery.ToSql()
 		if err != nil {
@@ -231,6 +363,9 @@ func (or *OrderRepository) ListOrders(ctx context.Context, skip, limit uint64) (
 				&order.ReceiptCode,
 				&order.CreatedAt,
 				&order.UpdatedAt,
+				&order.Channel,
+				&order.Discount,
+				&order.DiscountApprovedBy,
 			)
 			if err != nil {
 				return err
@@ -263,6 +398,10 @@ func (or *OrderRepository) ListOrders(ctx context.Context, skip, limit uint64) (
 					&orderProduct.TotalPrice,
 					&orderProduct.CreatedAt,
 					&orderProduct.UpdatedAt,
+					&orderProduct.ProductSKU,
+					&orderProduct.ProductName,
+					&orderProduct.UnitPrice,
+					&orderProduct.CategoryName,
 				)
 				if err != nil {
 					return err
@@ -270,6 +409,25 @@ func (or *OrderRepository) ListOrders(ctx context.Context, skip, limit uint64) (
 
 				orders[i].Products = append(orders[i].Products, orderProduct)
 			}
+
+			components, err := or.getOrderComponents(ctx, tx, order.ID)
+			if err != nil {
+				return err
+			}
+
+			for j, orderProduct := range orders[i].Products {
+				orders[i].Products[j].Components = components[orderProduct.ID]
+			}
+
+			orders[i].Void, err = or.getOrderVoid(ctx, tx, order.ID)
+			if err != nil {
+				return err
+			}
+
+			orders[i].Refunds, err = or.getOrderRefunds(ctx, tx, order.ID)
+			if err != nil {
+				return err
+			}
 		}
 
 		return nil
@@ -280,3 +438,303 @@ func (or *OrderRepository) ListOrders(ctx context.Context, skip, limit uint64) (
 
 	return orders, nil
 }
+
+// StreamOrderLines retrieves every line item of the orders created in the given time range
+// together with its order, and passes them one by one to fn
+// without loading the whole result set into memory
+func (or *OrderRepository) StreamOrderLines(ctx context.Context, startDate, endDate time.Time, fn func(order *domain.Order, orderProduct *opdomain.OrderProduct) error) error {
+	var order domain.Order
+	var orderProduct opdomain.OrderProduct
+
+	query := or.db.QueryBuilder.Select(
+		"orders.id",
+		"orders.user_id",
+		"orders.payment_id",
+		"orders.customer_name",
+		"orders.total_price",
+		"orders.total_paid",
+		"orders.total_return",
+		"orders.receipt_code",
+		"orders.created_at",
+		"orders.updated_at",
+		"order_products.id",
+		"order_products.product_id",
+		"order_products.quantity",
+		"order_products.total_price",
+		"order_products.product_sku",
+		"order_products.product_name",
+		"order_products.unit_price",
+		"order_products.category_name",
+	).
+		From("orders").
+		Join("order_products ON order_products.order_id = orders.id").
+		OrderBy("orders.id", "order_products.id")
+
+	query = filterOrders(query, startDate, endDate)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := or.db.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&order.ID,
+			&order.UserID,
+			&order.PaymentID,
+			&order.CustomerName,
+			&order.TotalPrice,
+			&order.TotalPaid,
+			&order.TotalReturn,
+			&order.ReceiptCode,
+			&order.CreatedAt,
+			&order.UpdatedAt,
+			&orderProduct.ID,
+			&orderProduct.ProductID,
+			&orderProduct.Quantity,
+			&orderProduct.TotalPrice,
+			&orderProduct.ProductSKU,
+			&orderProduct.ProductName,
+			&orderProduct.UnitPrice,
+			&orderProduct.CategoryName,
+		)
+		if err != nil {
+			return err
+		}
+
+		orderProduct.OrderID = order.ID
+
+		err = fn(&order, &orderProduct)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// VoidOrder records the void of an order and puts the stock of its products, or of the components of its bundles, back in the database
+func (or *OrderRepository) VoidOrder(ctx context.Context, void *domain.OrderVoid) (*domain.OrderVoid, error) {
+	voidQuery := or.db.QueryBuilder.Insert("order_voids").
+		Columns("order_id", "user_id", "approved_by", "reason").
+		Values(void.OrderID, void.UserID, void.ApprovedBy, void.Reason).
+		Suffix("RETURNING created_at")
+
+	singlesQuery := sq.Select("product_id", "quantity").
+		From("order_products").
+		Where(sq.Eq{"order_id": void.OrderID}).
+		Where("NOT EXISTS (SELECT 1 FROM order_product_components WHERE order_product_id = order_products.id)")
+
+	componentsQuery := sq.Select("order_product_components.product_id", "order_product_components.quantity").
+		From("order_product_components").
+		Join("order_products ON order_products.id = order_product_components.order_product_id").
+		Where(sq.Eq{"order_products.order_id": void.OrderID})
+
+	linesQuery := sq.Select("product_id", "SUM(quantity) AS quantity").
+		FromSelect(singlesQuery.SuffixExpr(sq.ConcatExpr("UNION ALL ", componentsQuery)), "taken").
+		GroupBy("product_id")
+
+	stockQuery := or.db.QueryBuilder.Update("products").
+		Set("stock", sq.Expr("products.stock + lines.quantity")).
+		Set("updated_at", time.Now()).
+		FromSelect(linesQuery, "lines").
+		Where("lines.product_id = products.id")
+
+	err := pgx.BeginFunc(ctx, or.db, func(tx pgx.Tx) error {
+		sql, args, err := voidQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, sql, args...).Scan(
+			&void.CreatedAt,
+		)
+		if err != nil {
+			if errCode := or.db.ErrorCode(err); errCode == "23505" {
+				return cmdomain.ErrOrderVoided
+			}
+			return err
+		}
+
+		sql, args, err = stockQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, sql, args...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return void, nil
+}
+
+// getOrderVoid gets the void of an order inside a transaction, or nil if the order is not voided
+func (or *OrderRepository) getOrderVoid(ctx context.Context, tx pgx.Tx, orderID uint64) (*domain.OrderVoid, error) {
+	var void domain.OrderVoid
+
+	query := or.db.QueryBuilder.Select("*").
+		From("order_voids").
+		Where(sq.Eq{"order_id": orderID}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(
+		&void.OrderID,
+		&void.UserID,
+		&void.ApprovedBy,
+		&void.Reason,
+		&void.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &void, nil
+}
+
+// RefundOrder records a refund of an order in the database, as long as the order is not voided
+// and its refunds do not add up to more than its total price
+func (or *OrderRepository) RefundOrder(ctx context.Context, refund *domain.OrderRefund) (*domain.OrderRefund, error) {
+	lockQuery := or.db.QueryBuilder.Select("id").
+		From("orders").
+		Where(sq.Eq{"id": refund.OrderID}).
+		Suffix("FOR UPDATE")
+
+	refundableQuery := or.db.QueryBuilder.Select().
+		Column(sq.Expr("orders.total_price - COALESCE(SUM(order_refunds.amount), 0) >= ?", refund.Amount)).
+		From("orders").
+		LeftJoin("order_refunds ON order_refunds.order_id = orders.id").
+		Where(sq.Eq{"orders.id": refund.OrderID}).
+		GroupBy("orders.id")
+
+	refundQuery := or.db.QueryBuilder.Insert("order_refunds").
+		Columns("order_id", "user_id", "approved_by", "amount", "reason").
+		Values(refund.OrderID, refund.UserID, refund.ApprovedBy, refund.Amount, refund.Reason).
+		Suffix("RETURNING id, created_at")
+
+	err := pgx.BeginFunc(ctx, or.db, func(tx pgx.Tx) error {
+		var orderID uint64
+		var isRefundable bool
+
+		sql, args, err := lockQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, sql, args...).Scan(&orderID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return cmdomain.ErrDataNotFound
+			}
+			return err
+		}
+
+		void, err := or.getOrderVoid(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+
+		if void != nil {
+			return cmdomain.ErrOrderVoided
+		}
+
+		sql, args, err = refundableQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, sql, args...).Scan(&isRefundable)
+		if err != nil {
+			return err
+		}
+
+		if !isRefundable {
+			return cmdomain.ErrRefundExceedsTotal
+		}
+
+		sql, args, err = refundQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		return tx.QueryRow(ctx, sql, args...).Scan(
+			&refund.ID,
+			&refund.CreatedAt,
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+// getOrderRefunds gets the refunds of an order inside a transaction, oldest first
+func (or *OrderRepository) getOrderRefunds(ctx context.Context, tx pgx.Tx, orderID uint64) ([]domain.OrderRefund, error) {
+	var refund domain.OrderRefund
+	var refunds []domain.OrderRefund
+
+	query := or.db.QueryBuilder.Select("*").
+		From("order_refunds").
+		Where(sq.Eq{"order_id": orderID}).
+		OrderBy("id")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&refund.ID,
+			&refund.OrderID,
+			&refund.UserID,
+			&refund.ApprovedBy,
+			&refund.Amount,
+			&refund.Reason,
+			&refund.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		refunds = append(refunds, refund)
+	}
+
+	return refunds, rows.Err()
+}
+
+// filterOrders applies the order list filters to a query on the orders table
+func filterOrders(query sq.SelectBuilder, startDate, endDate time.Time) sq.SelectBuilder {
+	if !startDate.IsZero() {
+		query = query.Where(sq.GtOrEq{"orders.created_at": startDate})
+	}
+
+	if !endDate.IsZero() {
+		query = query.Where(sq.Lt{"orders.created_at": endDate})
+	}
+
+	return query
+}
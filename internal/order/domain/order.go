@@ -1,6 +1,7 @@
 package domain
 
 import (
+	chdomain "go-restaurant/internal/channel/domain"
 	opdomain "go-restaurant/internal/orderproduct/domain"
 	pdomain "go-restaurant/internal/payment/domain"
 	udomain "go-restaurant/internal/user/domain"
@@ -11,17 +12,33 @@ import (
 
 // Order is an entity that represents an order
 type Order struct {
-	ID           uint64
-	UserID       uint64
-	PaymentID    uint64
-	CustomerName string
-	TotalPrice   float64
-	TotalPaid    float64
-	TotalReturn  float64
-	ReceiptCode  uuid.UUID
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	User         *udomain.User
-	Payment      *pdomain.Payment
-	Products     []opdomain.OrderProduct
+	ID                 uint64
+	UserID             uint64
+	PaymentID          uint64
+	CustomerName       string
+	Channel            chdomain.Channel
+	Discount           float64
+	DiscountApprovedBy *uint64
+	TotalPrice         float64
+	TotalPaid          float64
+	TotalReturn        float64
+	ReceiptCode        uuid.UUID
+	BusinessDate       time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	User               *udomain.User
+	Payment            *pdomain.Payment
+	Products           []opdomain.OrderProduct
+	Void               *OrderVoid
+	Refunds            []OrderRefund
+}
+
+// TotalRefunded returns how much of the total price of the order has been refunded
+func (o *Order) TotalRefunded() float64 {
+	var total float64
+	for _, refund := range o.Refunds {
+		total += refund.Amount
+	}
+
+	return total
 }
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"time"
+)
+
+// OrderRefund is an entity that represents money given back to the customer of an order
+// by a user and the user who approved it
+type OrderRefund struct {
+	ID         uint64
+	OrderID    uint64
+	UserID     uint64
+	ApprovedBy uint64
+	Amount     float64
+	Reason     string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+)
+
+// OrderVoid is an entity that represents the cancellation of an order
+// by a user and the user who approved it
+type OrderVoid struct {
+	OrderID    uint64
+	UserID     uint64
+	ApprovedBy uint64
+	Reason     string
+	CreatedAt  time.Time
+}
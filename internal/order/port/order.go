@@ -2,7 +2,10 @@ package port
 
 import (
 	"context"
+	adomain "go-restaurant/internal/auth/domain"
 	"go-restaurant/internal/order/domain"
+	opdomain "go-restaurant/internal/orderproduct/domain"
+	"time"
 )
 
 //go:generate mockgen -source=order.go -destination=mock/order.go -package=mock
@@ -13,16 +16,29 @@ type OrderRepository interface {
 	CreateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error)
 	// GetOrderByID selects an order by id
 	GetOrderByID(ctx context.Context, id uint64) (*domain.Order, error)
-	// ListOrders selects a list of orders with pagination
-	ListOrders(ctx context.Context, skip, limit uint64) ([]domain.Order, error)
+	// ListOrders selects a list of orders created in the given time range with pagination
+	ListOrders(ctx context.Context, startDate, endDate time.Time, skip, limit uint64) ([]domain.Order, error)
+	// StreamOrderLines selects the line items of all orders created in the given time range one by one
+	StreamOrderLines(ctx context.Context, startDate, endDate time.Time, fn func(order *domain.Order, orderProduct *opdomain.OrderProduct) error) error
+	// VoidOrder inserts the void of an order and restocks its products
+	VoidOrder(ctx context.Context, void *domain.OrderVoid) (*domain.OrderVoid, error)
+	// RefundOrder inserts a refund of an order
+	RefundOrder(ctx context.Context, refund *domain.OrderRefund) (*domain.OrderRefund, error)
 }
 
 // OrderService is an interface for interacting with order-related business logic
 type OrderService interface {
-	// CreateOrder creates a new order
-	CreateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error)
+	// CreateOrder creates a new order, with the approval of another user if its discount
+	// is larger than the role of the acting user allows
+	CreateOrder(ctx context.Context, order *domain.Order, payload *adomain.TokenPayload, approval *adomain.ApprovalRequest) (*domain.Order, error)
 	// GetOrder returns an order by id
 	GetOrder(ctx context.Context, id uint64) (*domain.Order, error)
-	// ListOrders returns a list of orders with pagination
-	ListOrders(ctx context.Context, skip, limit uint64) ([]domain.Order, error)
+	// ListOrders returns a list of orders of the given business dates with pagination
+	ListOrders(ctx context.Context, startDate, endDate time.Time, skip, limit uint64) ([]domain.Order, error)
+	// ExportOrders passes the line items of all orders of the given business dates one by one to fn
+	ExportOrders(ctx context.Context, startDate, endDate time.Time, fn func(order *domain.Order, orderProduct *opdomain.OrderProduct) error) error
+	// VoidOrder voids an order, with the approval of another user if the role of the acting user does not allow it
+	VoidOrder(ctx context.Context, void *domain.OrderVoid, payload *adomain.TokenPayload, approval *adomain.ApprovalRequest) (*domain.Order, error)
+	// RefundOrder refunds part or all of an order, with the approval of another user if the role of the acting user does not allow it
+	RefundOrder(ctx context.Context, refund *domain.OrderRefund, payload *adomain.TokenPayload, approval *adomain.ApprovalRequest) (*domain.Order, error)
 }
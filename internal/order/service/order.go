@@ -2,46 +2,93 @@ package service
 
 import (
 	"context"
+	"errors"
+	auditdomain "go-restaurant/internal/audit/domain"
+	auditport "go-restaurant/internal/audit/port"
+	adomain "go-restaurant/internal/auth/domain"
+	aport "go-restaurant/internal/auth/port"
 	caport "go-restaurant/internal/category/port"
+	chdomain "go-restaurant/internal/channel/domain"
+	chport "go-restaurant/internal/channel/port"
 	cmdomain "go-restaurant/internal/common/domain"
 	cport "go-restaurant/internal/common/port"
 	cmutil "go-restaurant/internal/common/util"
 	"go-restaurant/internal/order/domain"
 	"go-restaurant/internal/order/port"
+	opdomain "go-restaurant/internal/orderproduct/domain"
 	payport "go-restaurant/internal/payment/port"
+	pdomain "go-restaurant/internal/product/domain"
 	pport "go-restaurant/internal/product/port"
+	rdomain "go-restaurant/internal/role/domain"
 	uport "go-restaurant/internal/user/port"
+	"time"
 )
 
 /*
 OrderService implements port.OrderService, port.ProductService,
 port.UserService and port.PaymentService interfaces and provides
-access to the order, product, user and payment repositories
-and cache service
+access to the order, product, product price, category, price list, menu,
+user and payment repositories, transactor,
+cache service, approval service, audit service
+and the store's business day settings
 */
 type OrderService struct {
-	orderRepo    port.OrderRepository
-	productRepo  pport.ProductRepository
-	categoryRepo caport.CategoryRepository
-	userRepo     uport.UserRepository
-	paymentRepo  payport.PaymentRepository
-	cache        cport.CacheRepository
+	orderRepo     port.OrderRepository
+	productRepo   pport.ProductRepository
+	priceRepo     pport.ProductPriceRepository
+	categoryRepo  caport.CategoryRepository
+	priceListRepo chport.PriceListRepository
+	menuRepo      chport.MenuRepository
+	userRepo      uport.UserRepository
+	paymentRepo   payport.PaymentRepository
+	transactor    cport.Transactor
+	cache         cport.CacheRepository
+	approvals     aport.ApprovalService
+	audit         auditport.AuditService
+	store         *cmdomain.Store
 }
 
 // NewOrderService creates a new order service instance
-func NewOrderService(orderRepo port.OrderRepository, productRepo pport.ProductRepository, categoryRepo caport.CategoryRepository, userRepo uport.UserRepository, paymentRepo payport.PaymentRepository, cache cport.CacheRepository) *OrderService {
+func NewOrderService(orderRepo port.OrderRepository, productRepo pport.ProductRepository, priceRepo pport.ProductPriceRepository, categoryRepo caport.CategoryRepository, priceListRepo chport.PriceListRepository, menuRepo chport.MenuRepository, userRepo uport.UserRepository, paymentRepo payport.PaymentRepository, transactor cport.Transactor, cache cport.CacheRepository, approvals aport.ApprovalService, audit auditport.AuditService, store *cmdomain.Store) *OrderService {
 	return &OrderService{
 		orderRepo,
 		productRepo,
+		priceRepo,
 		categoryRepo,
+		priceListRepo,
+		menuRepo,
 		userRepo,
 		paymentRepo,
+		transactor,
 		cache,
+		approvals,
+		audit,
+		store,
 	}
 }
 
-// CreateOrder creates a new order
-func (os *OrderService) CreateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+// CreateOrder creates a new order through a channel, dine-in unless given, of products that are on its menu, are not 86'd
+// and are available in the current day-part, charging the prices of the channel's price list or else their prices in effect
+// at the time it is created, and snapshotting the products on its lines as they are sold.
+// A bundle is charged as a unit, while the stock of the products chosen for its slots is taken instead of its own.
+// A discount larger than the store's discount limit needs the approval of a user whose role allows giving discounts
+func (os *OrderService) CreateOrder(ctx context.Context, order *domain.Order, payload *adomain.TokenPayload, approvalReq *adomain.ApprovalRequest) (*domain.Order, error) {
+	orderedAt := time.Now()
+
+	if order.Channel == "" {
+		order.Channel = chdomain.DineIn
+	}
+
+	menu, err := os.menuRepo.GetMenuByChannel(ctx, order.Channel)
+	if err != nil && !errors.Is(err, cmdomain.ErrDataNotFound) {
+		return nil, err
+	}
+
+	priceList, err := os.priceListRepo.GetPriceListByChannel(ctx, order.Channel)
+	if err != nil && !errors.Is(err, cmdomain.ErrDataNotFound) {
+		return nil, err
+	}
+
 	var totalPrice float64
 	for i, orderProduct := range order.Products {
 		product, err := os.productRepo.GetProductByID(ctx, orderProduct.ProductID)
@@ -49,54 +96,96 @@ func (os *OrderService) CreateOrder(ctx context.Context, order *domain.Order) (*
 			return nil, err
 		}
 
-		if product.Stock < orderProduct.Quantity {
-			return nil, cmdomain.ErrInsufficientStock
+		if menu != nil && !menu.Includes(product.ID) {
+			return nil, cmdomain.ErrProductNotOnMenu
 		}
 
-		order.Products[i].TotalPrice = product.Price * float64(orderProduct.Quantity)
-		totalPrice += order.Products[i].TotalPrice
-	}
+		err = os.checkAvailable(ctx, product, orderedAt)
+		if err != nil {
+			return nil, err
+		}
 
-	if order.TotalPaid < totalPrice {
-		return nil, cmdomain.ErrInsufficientPayment
-	}
+		if product.IsBundle() {
+			order.Products[i].Components, err = os.chooseComponents(ctx, product, &orderProduct, orderedAt)
+			if err != nil {
+				return nil, err
+			}
+		} else if len(orderProduct.Components) > 0 {
+			return nil, cmdomain.ErrInvalidBundleChoice
+		}
 
-	order.TotalPrice = totalPrice
-	order.TotalReturn = order.TotalPaid - order.TotalPrice
+		price, err := os.priceOf(ctx, product, priceList, orderedAt)
+		if err != nil {
+			return nil, err
+		}
 
-	order, err := os.orderRepo.CreateOrder(ctx, order)
-	if err != nil {
-		return nil, err
+		category, err := os.categoryRepo.GetCategoryByIDIncludingDeleted(ctx, product.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+
+		order.Products[i].ProductSKU = product.SKU
+		order.Products[i].ProductName = product.Name
+		order.Products[i].UnitPrice = price
+		order.Products[i].CategoryName = category.Name
+		order.Products[i].TotalPrice = price * float64(orderProduct.Quantity)
+		totalPrice += order.Products[i].TotalPrice
 	}
 
-	user, err := os.userRepo.GetUserByID(ctx, order.UserID)
+	err = os.checkStock(ctx, order.Products)
 	if err != nil {
 		return nil, err
 	}
 
+	if order.Discount > totalPrice {
+		return nil, cmdomain.ErrInvalidDiscount
+	}
+
+	order.TotalPrice = totalPrice - order.Discount
+
+	if order.TotalPaid < order.TotalPrice {
+		return nil, cmdomain.ErrInsufficientPayment
+	}
+
 	payment, err := os.paymentRepo.GetPaymentByID(ctx, order.PaymentID)
 	if err != nil {
 		return nil, err
 	}
 
-	order.User = user
-	order.Payment = payment
-
-	for i, orderProduct := range order.Products {
-		product, err := os.productRepo.GetProductByID(ctx, orderProduct.ProductID)
+	if order.Discount > os.store.MaxDiscount(totalPrice) {
+		approval, err := os.approvals.Authorize(ctx, payload, rdomain.OrderDiscount, 0, approvalReq)
 		if err != nil {
 			return nil, err
 		}
 
-		category, err := os.categoryRepo.GetCategoryByID(ctx, product.CategoryID)
+		order.DiscountApprovedBy = &approval.ApproverID
+	}
+
+	order.TotalReturn = order.TotalPaid - order.TotalPrice
+
+	err = os.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		order, err = os.orderRepo.CreateOrder(ctx, order)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		order.Products[i].Product = product
-		order.Products[i].Product.Category = category
+		return os.audit.Record(ctx, auditdomain.ActionCreate, auditdomain.EntityOrder, order.ID, nil, auditOrder(order))
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	os.localize(order)
+
+	user, err := os.userRepo.GetUserByIDIncludingDeleted(ctx, order.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	order.User = user
+	order.Payment = payment
+
 	err = os.cache.DeleteByPrefix(ctx, "orders:*")
 	if err != nil {
 		return nil, err
@@ -116,7 +205,7 @@ func (os *OrderService) CreateOrder(ctx context.Context, order *domain.Order) (*
 	return order, nil
 }
 
-// GetOrder gets an order by ID
+// GetOrder gets an order by ID, with its lines as their products were sold
 func (os *OrderService) GetOrder(ctx context.Context, id uint64) (*domain.Order, error) {
 	var order *domain.Order
 
@@ -136,12 +225,14 @@ func (os *OrderService) GetOrder(ctx context.Context, id uint64) (*domain.Order,
 		return nil, err
 	}
 
-	user, err := os.userRepo.GetUserByID(ctx, order.UserID)
+	os.localize(order)
+
+	user, err := os.userRepo.GetUserByIDIncludingDeleted(ctx, order.UserID)
 	if err != nil {
 		return nil, err
 	}
 
-	payment, err := os.paymentRepo.GetPaymentByID(ctx, order.PaymentID)
+	payment, err := os.paymentRepo.GetPaymentByIDIncludingDeleted(ctx, order.PaymentID)
 	if err != nil {
 		return nil, err
 	}
@@ -149,21 +240,6 @@ func (os *OrderService) GetOrder(ctx context.Context, id uint64) (*domain.Order,
 	order.User = user
 	order.Payment = payment
 
-	for i, orderProduct := range order.Products {
-		product, err := os.productRepo.GetProductByID(ctx, orderProduct.ProductID)
-		if err != nil {
-			return nil, err
-		}
-
-		category, err := os.categoryRepo.GetCategoryByID(ctx, product.CategoryID)
-		if err != nil {
-			return nil, err
-		}
-
-		order.Products[i].Product = product
-		order.Products[i].Product.Category = category
-	}
-
 	orderSerialized, err := cmutil.Serialize(order)
 	if err != nil {
 		return nil, err
@@ -177,11 +253,16 @@ func (os *OrderService) GetOrder(ctx context.Context, id uint64) (*domain.Order,
 	return order, nil
 }
 
-// ListOrders lists all orders
-func (os *OrderService) ListOrders(ctx context.Context, skip, limit uint64) ([]domain.Order, error) {
+// ListOrders lists all orders of the given business dates, both inclusive and optional
+func (os *OrderService) ListOrders(ctx context.Context, startDate, endDate time.Time, skip, limit uint64) ([]domain.Order, error) {
 	var orders []domain.Order
 
-	params := cmutil.GenerateCacheKeyParams(skip, limit)
+	startTime, endTime, err := os.businessDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	params := cmutil.GenerateCacheKeyParams(skip, limit, startDate.Format(time.DateOnly), endDate.Format(time.DateOnly))
 	cacheKey := cmutil.GenerateCacheKey("orders", params)
 
 	cachedOrders, err := os.cache.Get(ctx, cacheKey)
@@ -194,18 +275,20 @@ func (os *OrderService) ListOrders(ctx context.Context, skip, limit uint64) ([]d
 		return orders, nil
 	}
 
-	orders, err = os.orderRepo.ListOrders(ctx, skip, limit)
+	orders, err = os.orderRepo.ListOrders(ctx, startTime, endTime, skip, limit)
 	if err != nil {
 		return nil, err
 	}
 
 	for i, order := range orders {
-		user, err := os.userRepo.GetUserByID(ctx, order.UserID)
+		os.localize(&orders[i])
+
+		user, err := os.userRepo.GetUserByIDIncludingDeleted(ctx, order.UserID)
 		if err != nil {
 			return nil, err
 		}
 
-		payment, err := os.paymentRepo.GetPaymentByID(ctx, order.PaymentID)
+		payment, err := os.paymentRepo.GetPaymentByIDIncludingDeleted(ctx, order.PaymentID)
 		if err != nil {
 			return nil, err
 		}
@@ -214,32 +297,332 @@ func (os *OrderService) ListOrders(ctx context.Context, skip, limit uint64) ([]d
 		orders[i].Payment = payment
 	}
 
-	for i, order := range orders {
-		for j, orderProduct := range order.Products {
-			product, err := os.productRepo.GetProductByID(ctx, orderProduct.ProductID)
-			if err != nil {
-				return nil, err
-			}
+	ordersSerialized, err := cmutil.Serialize(orders)
+	if err != nil {
+		return nil, err
+	}
 
-			category, err := os.categoryRepo.GetCategoryByID(ctx, product.CategoryID)
-			if err != nil {
-				return nil, err
-			}
+	err = os.cache.Set(ctx, cacheKey, ordersSerialized, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
 
-			orders[i].Products[j].Product = product
-			orders[i].Products[j].Product.Category = category
+// ExportOrders streams the line items of all orders of the given business dates to fn, bypassing the cache
+func (os *OrderService) ExportOrders(ctx context.Context, startDate, endDate time.Time, fn func(order *domain.Order, orderProduct *opdomain.OrderProduct) error) error {
+	startTime, endTime, err := os.businessDateRange(startDate, endDate)
+	if err != nil {
+		return err
+	}
+
+	return os.orderRepo.StreamOrderLines(ctx, startTime, endTime, func(order *domain.Order, orderProduct *opdomain.OrderProduct) error {
+		os.localize(order)
+		return fn(order, orderProduct)
+	})
+}
+
+// VoidOrder voids an order and puts its products back in stock. Users whose role does not allow
+// voiding orders need the approval of a user whose role does, who is recorded with the void
+func (os *OrderService) VoidOrder(ctx context.Context, void *domain.OrderVoid, payload *adomain.TokenPayload, approvalReq *adomain.ApprovalRequest) (*domain.Order, error) {
+	order, err := os.orderRepo.GetOrderByID(ctx, void.OrderID)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
 		}
+		return nil, cmdomain.ErrInternal
 	}
 
-	ordersSerialized, err := cmutil.Serialize(orders)
+	if order.Void != nil {
+		return nil, cmdomain.ErrOrderVoided
+	}
+
+	approval, err := os.approvals.Authorize(ctx, payload, rdomain.OrderVoid, void.OrderID, approvalReq)
 	if err != nil {
 		return nil, err
 	}
 
-	err = os.cache.Set(ctx, cacheKey, ordersSerialized, 0)
+	void.ApprovedBy = approval.ApproverID
+
+	err = os.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := os.orderRepo.VoidOrder(ctx, void)
+		if err != nil {
+			return err
+		}
+
+		voidedOrder, err := os.orderRepo.GetOrderByID(ctx, void.OrderID)
+		if err != nil {
+			return err
+		}
+
+		return os.audit.Record(ctx, auditdomain.ActionVoid, auditdomain.EntityOrder, void.OrderID, auditOrder(order), auditOrder(voidedOrder))
+	})
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrOrderVoided) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	for _, orderProduct := range order.Products {
+		cacheKey := cmutil.GenerateCacheKey("product", orderProduct.ProductID)
+
+		err = os.cache.Delete(ctx, cacheKey)
+		if err != nil {
+			return nil, cmdomain.ErrInternal
+		}
+	}
+
+	err = os.cache.DeleteByPrefix(ctx, "products:*")
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return os.refreshOrder(ctx, void.OrderID)
+}
+
+// RefundOrder gives back part or all of the total price of an order that is not voided, without restocking its products.
+// Users whose role does not allow refunding orders need the approval of a user whose role does, who is recorded with the refund
+func (os *OrderService) RefundOrder(ctx context.Context, refund *domain.OrderRefund, payload *adomain.TokenPayload, approvalReq *adomain.ApprovalRequest) (*domain.Order, error) {
+	order, err := os.orderRepo.GetOrderByID(ctx, refund.OrderID)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	if order.Void != nil {
+		return nil, cmdomain.ErrOrderVoided
+	}
+
+	if order.TotalRefunded()+refund.Amount > order.TotalPrice {
+		return nil, cmdomain.ErrRefundExceedsTotal
+	}
+
+	approval, err := os.approvals.Authorize(ctx, payload, rdomain.OrderRefund, refund.OrderID, approvalReq)
 	if err != nil {
 		return nil, err
 	}
 
-	return orders, nil
+	refund.ApprovedBy = approval.ApproverID
+
+	err = os.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := os.orderRepo.RefundOrder(ctx, refund)
+		if err != nil {
+			return err
+		}
+
+		refundedOrder, err := os.orderRepo.GetOrderByID(ctx, refund.OrderID)
+		if err != nil {
+			return err
+		}
+
+		return os.audit.Record(ctx, auditdomain.ActionRefund, auditdomain.EntityOrder, refund.OrderID, auditOrder(order), auditOrder(refundedOrder))
+	})
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) || errors.Is(err, cmdomain.ErrOrderVoided) || errors.Is(err, cmdomain.ErrRefundExceedsTotal) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	return os.refreshOrder(ctx, refund.OrderID)
+}
+
+// refreshOrder drops the cached lists of orders and the cached order, then gets the order again
+func (os *OrderService) refreshOrder(ctx context.Context, id uint64) (*domain.Order, error) {
+	err := os.cache.DeleteByPrefix(ctx, "orders:*")
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = os.cache.Delete(ctx, cmutil.GenerateCacheKey("order", id))
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	order, err := os.GetOrder(ctx, id)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return order, nil
+}
+
+// priceOf returns the price a product is charged at a time, which is the one of the price list of the order's channel
+// if it overrides it, or else the price of the product in effect at that time
+func (os *OrderService) priceOf(ctx context.Context, product *pdomain.Product, priceList *chdomain.PriceList, at time.Time) (float64, error) {
+	if priceList != nil {
+		if price, ok := priceList.PriceOf(product.ID); ok {
+			return price, nil
+		}
+	}
+
+	effectivePrice, err := os.priceRepo.GetEffectiveProductPrice(ctx, product.ID, at)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return product.Price, nil
+		}
+		return 0, err
+	}
+
+	return effectivePrice.Price, nil
+}
+
+// chooseComponents fills every slot of an ordered bundle with the product chosen for it, or the slot's default choice,
+// checking the products are not deleted, not 86'd and available in the current day-part. Their stock is checked
+// together with the other lines of the order
+func (os *OrderService) chooseComponents(ctx context.Context, bundle *pdomain.Product, orderProduct *opdomain.OrderProduct, orderedAt time.Time) ([]opdomain.OrderProductComponent, error) {
+	chosen := make(map[uint64]uint64, len(orderProduct.Components))
+	for _, component := range orderProduct.Components {
+		chosen[component.SlotID] = component.ProductID
+	}
+
+	components := make([]opdomain.OrderProductComponent, 0, len(bundle.Slots))
+	for _, slot := range bundle.Slots {
+		productID, ok := chosen[slot.ID]
+		if !ok {
+			productID, ok = slot.DefaultChoice()
+			if !ok {
+				return nil, cmdomain.ErrProductUnavailable
+			}
+		} else if !slot.HasChoice(productID) {
+			return nil, cmdomain.ErrInvalidBundleChoice
+		}
+
+		delete(chosen, slot.ID)
+
+		product, err := os.productRepo.GetProductByID(ctx, productID)
+		if err != nil {
+			if errors.Is(err, cmdomain.ErrDataNotFound) {
+				return nil, cmdomain.ErrInvalidBundleChoice
+			}
+			return nil, err
+		}
+
+		err = os.checkAvailable(ctx, product, orderedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		quantity := orderProduct.Quantity * slot.Quantity
+
+		components = append(components, opdomain.OrderProductComponent{
+			SlotID:      slot.ID,
+			SlotName:    slot.Name,
+			ProductID:   product.ID,
+			ProductSKU:  product.SKU,
+			ProductName: product.Name,
+			Quantity:    quantity,
+		})
+	}
+
+	if len(chosen) > 0 {
+		return nil, cmdomain.ErrInvalidBundleChoice
+	}
+
+	return components, nil
+}
+
+// checkAvailable checks that a product is not 86'd and is available in the day-part of the time it is ordered
+func (os *OrderService) checkAvailable(ctx context.Context, product *pdomain.Product, orderedAt time.Time) error {
+	if product.IsEightySixed(orderedAt) {
+		return cmdomain.ErrProductEightySixed
+	}
+
+	available, err := os.productRepo.IsProductAvailable(ctx, product.ID, orderedAt.In(os.store.Location))
+	if err != nil {
+		return err
+	}
+
+	if !available {
+		return cmdomain.ErrProductUnavailable
+	}
+
+	return nil
+}
+
+// checkStock checks that there is enough stock of every product for all the lines of an order together,
+// adding up what the single products and the components of the bundles take from the same product
+func (os *OrderService) checkStock(ctx context.Context, orderProducts []opdomain.OrderProduct) error {
+	quantities := make(map[uint64]int64)
+	productIDs := make([]uint64, 0, len(orderProducts))
+
+	take := func(productID uint64, quantity int64) {
+		if _, ok := quantities[productID]; !ok {
+			productIDs = append(productIDs, productID)
+		}
+
+		quantities[productID] += quantity
+	}
+
+	for _, orderProduct := range orderProducts {
+		if len(orderProduct.Components) == 0 {
+			take(orderProduct.ProductID, orderProduct.Quantity)
+			continue
+		}
+
+		for _, component := range orderProduct.Components {
+			take(component.ProductID, component.Quantity)
+		}
+	}
+
+	for _, productID := range productIDs {
+		product, err := os.productRepo.GetProductByID(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		if product.Stock < quantities[productID] {
+			return cmdomain.ErrInsufficientStock
+		}
+	}
+
+	return nil
+}
+
+// businessDateRange converts optional inclusive business dates to the time range they span
+func (os *OrderService) businessDateRange(startDate, endDate time.Time) (time.Time, time.Time, error) {
+	var startTime, endTime time.Time
+
+	if !startDate.IsZero() && !endDate.IsZero() && endDate.Before(startDate) {
+		return startTime, endTime, cmdomain.ErrInvalidDateRange
+	}
+
+	if !startDate.IsZero() {
+		startTime = os.store.DayStart(startDate)
+	}
+
+	if !endDate.IsZero() {
+		endTime = os.store.DayEnd(endDate)
+	}
+
+	return startTime, endTime, nil
+}
+
+// localize converts the order's timestamps to the store's timezone and sets its business date
+func (os *OrderService) localize(order *domain.Order) {
+	order.CreatedAt = order.CreatedAt.In(os.store.Location)
+	order.UpdatedAt = order.UpdatedAt.In(os.store.Location)
+	order.BusinessDate = os.store.BusinessDate(order.CreatedAt)
+
+	if order.Void != nil {
+		order.Void.CreatedAt = order.Void.CreatedAt.In(os.store.Location)
+	}
+
+	for i := range order.Refunds {
+		order.Refunds[i].CreatedAt = order.Refunds[i].CreatedAt.In(os.store.Location)
+	}
+}
+
+// auditOrder returns a copy of an order without the user and payment it refers to,
+// which are audited on their own, so only the ids of the related entities show up in the changes
+func auditOrder(order *domain.Order) *domain.Order {
+	audited := *order
+	audited.User = nil
+	audited.Payment = nil
+
+	return &audited
 }
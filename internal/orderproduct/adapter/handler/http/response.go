@@ -2,22 +2,41 @@ package http
 
 import (
 	"go-restaurant/internal/orderproduct/domain"
-	phttp "go-restaurant/internal/product/adapter/handler/http"
 	"time"
 )
 
+// orderedProductResponse represents the Response body of a product as it was when it was ordered
+type orderedProductResponse struct {
+	ID       uint64  `json:"id" example:"1"`
+	SKU      string  `json:"sku" example:"9a4c25d3-9786-492c-b084-85cb75c1ee3e"`
+	Name     string  `json:"name" example:"Chiki Ball"`
+	Price    float64 `json:"price" example:"5000"`
+	Category string  `json:"category" example:"Foods"`
+}
+
+// orderProductComponentResponse represents the Response body of a product chosen for a slot of an ordered bundle
+type orderProductComponentResponse struct {
+	ID        uint64 `json:"id" example:"1"`
+	Slot      string `json:"slot" example:"Drink"`
+	ProductID uint64 `json:"product_id" example:"2"`
+	SKU       string `json:"sku" example:"9a4c25d3-9786-492c-b084-85cb75c1ee3e"`
+	Name      string `json:"name" example:"Iced Tea"`
+	Quantity  int64  `json:"qty" example:"1"`
+}
+
 // OrderProductResponse represents an order product Response body
 type OrderProductResponse struct {
-	ID               uint64                `json:"id" example:"1"`
-	OrderID          uint64                `json:"order_id" example:"1"`
-	ProductID        uint64                `json:"product_id" example:"1"`
-	Quantity         int64                 `json:"qty" example:"1"`
-	Price            float64               `json:"price" example:"100000"`
-	TotalNormalPrice float64               `json:"total_normal_price" example:"100000"`
-	TotalFinalPrice  float64               `json:"total_final_price" example:"100000"`
-	Product          phttp.ProductResponse `json:"product"`
-	CreatedAt        time.Time             `json:"created_at" example:"1970-01-01T00:00:00Z"`
-	UpdatedAt        time.Time             `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+	ID               uint64                          `json:"id" example:"1"`
+	OrderID          uint64                          `json:"order_id" example:"1"`
+	ProductID        uint64                          `json:"product_id" example:"1"`
+	Quantity         int64                           `json:"qty" example:"1"`
+	Price            float64                         `json:"price" example:"100000"`
+	TotalNormalPrice float64                         `json:"total_normal_price" example:"100000"`
+	TotalFinalPrice  float64                         `json:"total_final_price" example:"100000"`
+	Product          orderedProductResponse          `json:"product"`
+	Components       []orderProductComponentResponse `json:"components,omitempty"`
+	CreatedAt        time.Time                       `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt        time.Time                       `json:"updated_at" example:"1970-01-01T00:00:00Z"`
 }
 
 // NewOrderProductResponse is a helper function to create a Response body for handling order product data
@@ -30,14 +49,39 @@ func NewOrderProductResponse(orderProduct []domain.OrderProduct) []OrderProductR
 			OrderID:          orderProduct.OrderID,
 			ProductID:        orderProduct.ProductID,
 			Quantity:         orderProduct.Quantity,
-			Price:            orderProduct.Product.Price,
+			Price:            orderProduct.UnitPrice,
 			TotalNormalPrice: orderProduct.TotalPrice,
 			TotalFinalPrice:  orderProduct.TotalPrice,
-			Product:          phttp.NewProductResponse(orderProduct.Product),
-			CreatedAt:        orderProduct.CreatedAt,
-			UpdatedAt:        orderProduct.UpdatedAt,
+			Product: orderedProductResponse{
+				ID:       orderProduct.ProductID,
+				SKU:      orderProduct.ProductSKU.String(),
+				Name:     orderProduct.ProductName,
+				Price:    orderProduct.UnitPrice,
+				Category: orderProduct.CategoryName,
+			},
+			Components: newOrderProductComponentResponses(orderProduct.Components),
+			CreatedAt:  orderProduct.CreatedAt,
+			UpdatedAt:  orderProduct.UpdatedAt,
 		})
 	}
 
 	return orderProductResponses
 }
+
+// newOrderProductComponentResponses is a helper function to create a Response body for handling the components of an ordered bundle
+func newOrderProductComponentResponses(components []domain.OrderProductComponent) []orderProductComponentResponse {
+	var componentResponses []orderProductComponentResponse
+
+	for _, component := range components {
+		componentResponses = append(componentResponses, orderProductComponentResponse{
+			ID:        component.ID,
+			Slot:      component.SlotName,
+			ProductID: component.ProductID,
+			SKU:       component.ProductSKU.String(),
+			Name:      component.ProductName,
+			Quantity:  component.Quantity,
+		})
+	}
+
+	return componentResponses
+}
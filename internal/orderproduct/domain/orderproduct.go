@@ -2,19 +2,42 @@ package domain
 
 import (
 	odomain "go-restaurant/internal/order/domain"
-	pdomain "go-restaurant/internal/product/domain"
 	"time"
+
+	"github.com/google/uuid"
 )
 
-// OrderProduct is an entity that represents pivot table between order and product
+// OrderProduct is an entity that represents pivot table between order and product.
+// The product's SKU, name, unit price and category name are snapshotted at sale time,
+// so the order keeps showing what was sold even after the product changes or is deleted.
+// A bundle is ordered with the products chosen for its slots as its components
 type OrderProduct struct {
-	ID         uint64
-	OrderID    uint64
-	ProductID  uint64
-	Quantity   int64
-	TotalPrice float64
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	Order      *odomain.Order
-	Product    *pdomain.Product
+	ID           uint64
+	OrderID      uint64
+	ProductID    uint64
+	Quantity     int64
+	TotalPrice   float64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	ProductSKU   uuid.UUID
+	ProductName  string
+	UnitPrice    float64
+	CategoryName string
+	Components   []OrderProductComponent
+	Order        *odomain.Order
+}
+
+// OrderProductComponent is an entity that represents the product chosen for a slot of an ordered bundle.
+// SlotID is only set when the bundle is ordered, while the slot name and the product's SKU and name are
+// snapshotted like the order product. Quantity is the total quantity taken from the product's stock
+type OrderProductComponent struct {
+	ID             uint64
+	OrderProductID uint64
+	SlotID         uint64
+	SlotName       string
+	ProductID      uint64
+	ProductSKU     uuid.UUID
+	ProductName    string
+	Quantity       int64
+	CreatedAt      time.Time
 }
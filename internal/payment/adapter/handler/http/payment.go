@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	cmhttp "go-restaurant/internal/common/adapter/handler/http"
 	cmutil "go-restaurant/internal/common/util"
+	imghttp "go-restaurant/internal/image/adapter/handler/http"
 	"go-restaurant/internal/payment/domain"
 	"go-restaurant/internal/payment/port"
 )
@@ -208,6 +209,50 @@ func (ph *PaymentHandler) UpdatePayment(ctx *gin.Context) {
 	cmhttp.HandleSuccess(ctx, rsp)
 }
 
+// UploadPaymentLogo godoc
+//
+//	@Summary		Upload a logo of a payment
+//	@Description	Upload a JPEG, PNG or GIF logo of a payment of up to 5 MB and 4096x4096 pixels, which becomes the payment's logo. A thumbnail of it is generated, and an uploaded logo it replaces is deleted
+//	@Tags			Payments
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		uint64			true	"Payment ID"
+//	@Param			image	formData	file			true	"Logo"
+//	@Success		200		{object}	imageResponse	"Payment logo uploaded"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		413		{object}	errorResponse	"Image too large error"
+//	@Failure		415		{object}	errorResponse	"Unsupported image type error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/payments/{id}/logo [put]
+//	@Security		BearerAuth
+func (ph *PaymentHandler) UploadPaymentLogo(ctx *gin.Context) {
+	var req getPaymentRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	logo, err := imghttp.FormImage(ctx)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+	defer logo.Close()
+
+	img, err := ph.svc.UploadPaymentLogo(ctx, req.ID, logo)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := imghttp.NewImageResponse(img)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
 // deletePaymentRequest represents a request body for deleting a payment
 type deletePaymentRequest struct {
 	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
@@ -244,3 +289,43 @@ func (ph *PaymentHandler) DeletePayment(ctx *gin.Context) {
 
 	cmhttp.HandleSuccess(ctx, nil)
 }
+
+// restorePaymentRequest represents a request body for restoring a deleted payment
+type restorePaymentRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// RestorePayment godoc
+//
+//	@Summary		Restore a payment
+//	@Description	Restore a deleted payment by id
+//	@Tags			Payments
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Payment ID"
+//	@Success		200	{object}	paymentResponse	"Payment restored"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		409	{object}	errorResponse	"Data conflict error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/payments/{id}/restore [post]
+//	@Security		BearerAuth
+func (ph *PaymentHandler) RestorePayment(ctx *gin.Context) {
+	var req restorePaymentRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	payment, err := ph.svc.RestorePayment(ctx, req.ID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := NewPaymentResponse(payment)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
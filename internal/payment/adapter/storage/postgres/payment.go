@@ -46,6 +46,7 @@ func (pr *PaymentRepository) CreatePayment(ctx context.Context, payment *domain.
 		&payment.Logo,
 		&payment.CreatedAt,
 		&payment.UpdatedAt,
+		&payment.DeletedAt,
 	)
 	if err != nil {
 		if errCode := pr.db.ErrorCode(err); errCode == "23505" {
@@ -57,10 +58,44 @@ func (pr *PaymentRepository) CreatePayment(ctx context.Context, payment *domain.
 	return payment, nil
 }
 
-// GetPaymentByID retrieves a payment record from the database by id
+// GetPaymentByID retrieves a payment record that is not deleted from the database by id
 func (pr *PaymentRepository) GetPaymentByID(ctx context.Context, id uint64) (*domain.Payment, error) {
 	var payment domain.Payment
 
+	query := pr.db.QueryBuilder.Select("*").
+		From("payments").
+		Where(sq.Eq{"id": id, "deleted_at": nil}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = pr.db.QueryRow(ctx, sql, args...).Scan(
+		&payment.ID,
+		&payment.Name,
+		&payment.Type,
+		&payment.Logo,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+		&payment.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+// GetPaymentByIDIncludingDeleted retrieves a payment record from the database by id,
+// even if it is deleted, so the orders it is part of still resolve it
+func (pr *PaymentRepository) GetPaymentByIDIncludingDeleted(ctx context.Context, id uint64) (*domain.Payment, error) {
+	var payment domain.Payment
+
 	query := pr.db.QueryBuilder.Select("*").
 		From("payments").
 		Where(sq.Eq{"id": id}).
@@ -78,6 +113,7 @@ func (pr *PaymentRepository) GetPaymentByID(ctx context.Context, id uint64) (*do
 		&payment.Logo,
 		&payment.CreatedAt,
 		&payment.UpdatedAt,
+		&payment.DeletedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -96,6 +132,7 @@ func (pr *PaymentRepository) ListPayments(ctx context.Context, skip, limit uint6
 
 	query := pr.db.QueryBuilder.Select("*").
 		From("payments").
+		Where(sq.Eq{"deleted_at": nil}).
 		OrderBy("id").
 		Limit(limit).
 		Offset((skip - 1) * limit)
@@ -118,6 +155,7 @@ func (pr *PaymentRepository) ListPayments(ctx context.Context, skip, limit uint6
 			&payment.Logo,
 			&payment.CreatedAt,
 			&payment.UpdatedAt,
+			&payment.DeletedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -155,6 +193,7 @@ func (pr *PaymentRepository) UpdatePayment(ctx context.Context, payment *domain.
 		&payment.Logo,
 		&payment.CreatedAt,
 		&payment.UpdatedAt,
+		&payment.DeletedAt,
 	)
 	if err != nil {
 		if errCode := pr.db.ErrorCode(err); errCode == "23505" {
@@ -166,10 +205,11 @@ func (pr *PaymentRepository) UpdatePayment(ctx context.Context, payment *domain.
 	return payment, nil
 }
 
-// DeletePayment deletes a payment record from the database by id
+// DeletePayment soft deletes a payment record in the database by id, so the orders it is part of keep referring to it
 func (pr *PaymentRepository) DeletePayment(ctx context.Context, id uint64) error {
-	query := pr.db.QueryBuilder.Delete("payments").
-		Where(sq.Eq{"id": id})
+	query := pr.db.QueryBuilder.Update("payments").
+		Set("deleted_at", time.Now()).
+		Where(sq.Eq{"id": id, "deleted_at": nil})
 
 	sql, args, err := query.ToSql()
 	if err != nil {
@@ -183,3 +223,39 @@ func (pr *PaymentRepository) DeletePayment(ctx context.Context, id uint64) error
 
 	return nil
 }
+
+// RestorePayment restores a soft deleted payment record in the database by id
+func (pr *PaymentRepository) RestorePayment(ctx context.Context, id uint64) (*domain.Payment, error) {
+	var payment domain.Payment
+
+	query := pr.db.QueryBuilder.Update("payments").
+		Set("deleted_at", nil).
+		Where(sq.And{sq.Eq{"id": id}, sq.NotEq{"deleted_at": nil}}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = pr.db.QueryRow(ctx, sql, args...).Scan(
+		&payment.ID,
+		&payment.Name,
+		&payment.Type,
+		&payment.Logo,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+		&payment.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		if errCode := pr.db.ErrorCode(err); errCode == "23505" {
+			return nil, cmdomain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return &payment, nil
+}
@@ -22,4 +22,5 @@ type Payment struct {
 	Logo      string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	DeletedAt *time.Time
 }
@@ -2,7 +2,9 @@ package port
 
 import (
 	"context"
+	imgdomain "go-restaurant/internal/image/domain"
 	"go-restaurant/internal/payment/domain"
+	"io"
 )
 
 //go:generate mockgen -source=payment.go -destination=mock/payment.go -package=mock
@@ -13,12 +15,16 @@ type PaymentRepository interface {
 	CreatePayment(ctx context.Context, payment *domain.Payment) (*domain.Payment, error)
 	// GetPaymentByID selects a payment by id
 	GetPaymentByID(ctx context.Context, id uint64) (*domain.Payment, error)
+	// GetPaymentByIDIncludingDeleted selects a payment by id, even if it is deleted
+	GetPaymentByIDIncludingDeleted(ctx context.Context, id uint64) (*domain.Payment, error)
 	// ListPayments selects a list of payments with pagination
 	ListPayments(ctx context.Context, skip, limit uint64) ([]domain.Payment, error)
 	// UpdatePayment updates a payment
 	UpdatePayment(ctx context.Context, payment *domain.Payment) (*domain.Payment, error)
-	// DeletePayment deletes a payment
+	// DeletePayment soft deletes a payment
 	DeletePayment(ctx context.Context, id uint64) error
+	// RestorePayment restores a soft deleted payment
+	RestorePayment(ctx context.Context, id uint64) (*domain.Payment, error)
 }
 
 // PaymentService is an interface for interacting with payment-related business logic
@@ -31,6 +37,10 @@ type PaymentService interface {
 	ListPayments(ctx context.Context, skip, limit uint64) ([]domain.Payment, error)
 	// UpdatePayment updates a payment
 	UpdatePayment(ctx context.Context, payment *domain.Payment) (*domain.Payment, error)
-	// DeletePayment deletes a payment
+	// UploadPaymentLogo stores an uploaded logo of a payment and makes it the payment's logo
+	UploadPaymentLogo(ctx context.Context, id uint64, logo io.Reader) (*imgdomain.Image, error)
+	// DeletePayment soft deletes a payment
 	DeletePayment(ctx context.Context, id uint64) error
+	// RestorePayment restores a soft deleted payment
+	RestorePayment(ctx context.Context, id uint64) (*domain.Payment, error)
 }
@@ -3,33 +3,54 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	auditdomain "go-restaurant/internal/audit/domain"
+	auditport "go-restaurant/internal/audit/port"
 	cmdomain "go-restaurant/internal/common/domain"
 	cmport "go-restaurant/internal/common/port"
 	cmutil "go-restaurant/internal/common/util"
+	imgdomain "go-restaurant/internal/image/domain"
+	imgport "go-restaurant/internal/image/port"
 	"go-restaurant/internal/payment/domain"
 	"go-restaurant/internal/payment/port"
+	"io"
+	"log/slog"
 )
 
 /*PaymentService implements port.PaymentService interface
- * and provides access to the payment repository
- * and cache service
+ * and provides access to the payment repository, transactor,
+ * image service, cache service and audit service
  */
 type PaymentService struct {
-	repo  port.PaymentRepository
-	cache cmport.CacheRepository
+	repo       port.PaymentRepository
+	transactor cmport.Transactor
+	images     imgport.ImageService
+	cache      cmport.CacheRepository
+	audit      auditport.AuditService
 }
 
 // NewPaymentService creates a new payment service instance
-func NewPaymentService(repo port.PaymentRepository, cache cmport.CacheRepository) *PaymentService {
+func NewPaymentService(repo port.PaymentRepository, transactor cmport.Transactor, images imgport.ImageService, cache cmport.CacheRepository, audit auditport.AuditService) *PaymentService {
 	return &PaymentService{
 		repo,
+		transactor,
+		images,
 		cache,
+		audit,
 	}
 }
 
 // CreatePayment creates a new payment
 func (ps *PaymentService) CreatePayment(ctx context.Context, payment *domain.Payment) (*domain.Payment, error) {
-	payment, err := ps.repo.CreatePayment(ctx, payment)
+	err := ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		payment, err = ps.repo.CreatePayment(ctx, payment)
+		if err != nil {
+			return err
+		}
+
+		return ps.audit.Record(ctx, auditdomain.ActionCreate, auditdomain.EntityPayment, payment.ID, nil, payment)
+	})
 	if err != nil {
 		if errors.Is(err, cmdomain.ErrConflictingData) {
 			return nil, err
@@ -144,7 +165,14 @@ func (ps *PaymentService) UpdatePayment(ctx context.Context, payment *domain.Pay
 		return nil, cmdomain.ErrNoUpdatedData
 	}
 
-	_, err = ps.repo.UpdatePayment(ctx, payment)
+	err = ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := ps.repo.UpdatePayment(ctx, payment)
+		if err != nil {
+			return err
+		}
+
+		return ps.audit.Record(ctx, auditdomain.ActionUpdate, auditdomain.EntityPayment, payment.ID, existingPayment, payment)
+	})
 	if err != nil {
 		if errors.Is(err, cmdomain.ErrConflictingData) {
 			return nil, err
@@ -177,9 +205,39 @@ func (ps *PaymentService) UpdatePayment(ctx context.Context, payment *domain.Pay
 	return payment, nil
 }
 
-// DeletePayment deletes a payment
+// UploadPaymentLogo stores an uploaded logo of a payment and its thumbnail and makes it the payment's logo,
+// deleting the logo it replaces if it was uploaded too
+func (ps *PaymentService) UploadPaymentLogo(ctx context.Context, id uint64, logo io.Reader) (*imgdomain.Image, error) {
+	existingPayment, err := ps.repo.GetPaymentByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	img, err := ps.images.UploadImage(ctx, fmt.Sprintf("%s/%d", imgdomain.PaymentsPrefix, id), logo)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = ps.UpdatePayment(ctx, &domain.Payment{ID: id, Logo: img.URL})
+	if err != nil {
+		_ = ps.images.DeleteImage(ctx, img.URL)
+		return nil, err
+	}
+
+	err = ps.images.DeleteImage(ctx, existingPayment.Logo)
+	if err != nil {
+		slog.Error("Error deleting replaced payment logo", "payment_id", id, "logo", existingPayment.Logo, "error", err)
+	}
+
+	return img, nil
+}
+
+// DeletePayment soft deletes a payment
 func (ps *PaymentService) DeletePayment(ctx context.Context, id uint64) error {
-	_, err := ps.repo.GetPaymentByID(ctx, id)
+	existingPayment, err := ps.repo.GetPaymentByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, cmdomain.ErrDataNotFound) {
 			return err
@@ -199,5 +257,39 @@ func (ps *PaymentService) DeletePayment(ctx context.Context, id uint64) error {
 		return cmdomain.ErrInternal
 	}
 
-	return ps.repo.DeletePayment(ctx, id)
+	return ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := ps.repo.DeletePayment(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return ps.audit.Record(ctx, auditdomain.ActionDelete, auditdomain.EntityPayment, id, existingPayment, nil)
+	})
+}
+
+// RestorePayment restores a deleted payment
+func (ps *PaymentService) RestorePayment(ctx context.Context, id uint64) (*domain.Payment, error) {
+	var payment *domain.Payment
+	err := ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		payment, err = ps.repo.RestorePayment(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return ps.audit.Record(ctx, auditdomain.ActionRestore, auditdomain.EntityPayment, id, nil, payment)
+	})
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) || errors.Is(err, cmdomain.ErrConflictingData) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = ps.cache.DeleteByPrefix(ctx, "payments:*")
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return payment, nil
 }
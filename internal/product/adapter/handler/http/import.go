@@ -0,0 +1,190 @@
+package http
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	cadomain "go-restaurant/internal/category/domain"
+	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+	cmdomain "go-restaurant/internal/common/domain"
+	"go-restaurant/internal/product/domain"
+	"io"
+	"math"
+	"mime/multipart"
+	"strconv"
+	"strings"
+)
+
+// importColumns are the columns a product import file must have in its header row
+var importColumns = []string{"category", "name", "price", "stock", "image", "sku"}
+
+// importProductRequest represents a single row of a product import file, validated with the
+// same rules as createProductRequest except that the category is given by name
+type importProductRequest struct {
+	CategoryName string `binding:"required"`
+	productRequest
+	SKU string `binding:"omitempty,uuid"`
+}
+
+// importProductsRequest represents a request body for importing products
+type importProductsRequest struct {
+	File *multipart.FileHeader `form:"file" binding:"required"`
+	Mode string                `form:"mode" binding:"omitempty,oneof=dry_run commit" example:"dry_run"`
+}
+
+// ImportProducts godoc
+//
+//	@Summary		Import products from a CSV file
+//	@Description	Validate a CSV file with category, name, price, stock, image and sku columns and, in commit mode, create or update its products by SKU in a single transaction. Rows without a SKU create new products. Nothing is written when any row is invalid or in dry run mode
+//	@Tags			Products
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file					true	"CSV file"
+//	@Param			mode	formData	string					false	"Import mode"	Enums(dry_run, commit)
+//	@Success		200		{object}	productImportResponse	"Products imported"
+//	@Failure		400		{object}	errorResponse			"Validation error"
+//	@Failure		401		{object}	errorResponse			"Unauthorized error"
+//	@Failure		403		{object}	errorResponse			"Forbidden error"
+//	@Failure		409		{object}	errorResponse			"Data conflict error"
+//	@Failure		500		{object}	errorResponse			"Internal server error"
+//	@Router			/products/import [post]
+//	@Security		BearerAuth
+func (ph *ProductHandler) ImportProducts(ctx *gin.Context) {
+	var req importProductsRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		cmhttp.HandleError(ctx, cmdomain.ErrInvalidImportFile)
+		return
+	}
+	defer file.Close()
+
+	rows, err := parseImportFile(file)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	dryRun := req.Mode != "commit"
+
+	result, err := ph.svc.ImportProducts(ctx, rows, dryRun)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newProductImportResponse(result)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// parseImportFile reads the rows of a product import file and validates each of them
+func parseImportFile(file io.Reader) ([]domain.ProductImportRow, error) {
+	var rows []domain.ProductImportRow
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, cmdomain.ErrInvalidImportFile
+	}
+
+	columns := make(map[string]int)
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range importColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, cmdomain.ErrInvalidImportFile
+		}
+	}
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, cmdomain.ErrInvalidImportFile
+		}
+
+		rows = append(rows, parseImportRecord(line, record, len(header), columns))
+	}
+
+	if len(rows) == 0 {
+		return nil, cmdomain.ErrInvalidImportFile
+	}
+
+	return rows, nil
+}
+
+// parseImportRecord converts a record of a product import file to an import row,
+// collecting every conversion and validation error of the record
+func parseImportRecord(line int, record []string, width int, columns map[string]int) domain.ProductImportRow {
+	var errs []string
+
+	if len(record) != width {
+		errs = append(errs, fmt.Sprintf("row must have %d columns, not %d", width, len(record)))
+	}
+
+	field := func(column string) string {
+		i := columns[column]
+		if i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	req := importProductRequest{
+		CategoryName: field("category"),
+		productRequest: productRequest{
+			Name:  field("name"),
+			Image: field("image"),
+		},
+		SKU: field("sku"),
+	}
+
+	price, err := strconv.ParseFloat(field("price"), 64)
+	if err != nil || math.IsNaN(price) || math.IsInf(price, 0) {
+		errs = append(errs, "price must be a number")
+	}
+
+	stock, err := strconv.ParseInt(field("stock"), 10, 64)
+	if err != nil {
+		errs = append(errs, "stock must be an integer")
+	}
+
+	req.Price = price
+	req.Stock = stock
+
+	err = binding.Validator.ValidateStruct(&req)
+	if err != nil {
+		errs = append(errs, cmhttp.ParseError(err)...)
+	}
+
+	sku, _ := uuid.Parse(req.SKU)
+
+	return domain.ProductImportRow{
+		Line: line,
+		Product: domain.Product{
+			SKU:      sku,
+			Name:     req.Name,
+			Stock:    req.Stock,
+			Price:    req.Price,
+			Image:    req.Image,
+			Category: &cadomain.Category{Name: req.CategoryName},
+		},
+		Errors: errs,
+	}
+}
@@ -0,0 +1,195 @@
+package http
+
+import (
+	"errors"
+	cmdomain "go-restaurant/internal/common/domain"
+	"strings"
+	"testing"
+)
+
+func TestParseImportFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		rows    int
+		wantErr error
+	}{
+		{
+			name: "valid file",
+			file: "category,name,price,stock,image,sku\n" +
+				"Drinks,Tea,5000,10,https://example.com/tea.png,\n" +
+				"Drinks,Coffee,8000,5,https://example.com/coffee.png,0c2b7d5e-5d6f-4c1a-9f0e-2f8f3c1e4b6a\n",
+			rows: 2,
+		},
+		{
+			name: "columns in any order and case",
+			file: "SKU, Image ,Stock,Price,Name,Category\n" +
+				",https://example.com/tea.png,10,5000,Tea,Drinks\n",
+			rows: 1,
+		},
+		{
+			name:    "empty file",
+			file:    "",
+			wantErr: cmdomain.ErrInvalidImportFile,
+		},
+		{
+			name:    "missing column",
+			file:    "category,name,price,stock,image\nDrinks,Tea,5000,10,https://example.com/tea.png\n",
+			wantErr: cmdomain.ErrInvalidImportFile,
+		},
+		{
+			name:    "header only",
+			file:    "category,name,price,stock,image,sku\n",
+			wantErr: cmdomain.ErrInvalidImportFile,
+		},
+		{
+			name:    "malformed quotes",
+			file:    "category,name,price,stock,image,sku\nDrinks,\"Tea,5000,10,https://example.com/tea.png,\n",
+			wantErr: cmdomain.ErrInvalidImportFile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseImportFile(strings.NewReader(tt.file))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if len(rows) != tt.rows {
+				t.Fatalf("got %d rows, want %d", len(rows), tt.rows)
+			}
+		})
+	}
+}
+
+func TestParseImportFileLines(t *testing.T) {
+	file := "category,name,price,stock,image,sku\n" +
+		"Drinks,Tea,5000,10,https://example.com/tea.png,\n" +
+		"Drinks,Coffee,abc,5,https://example.com/coffee.png,\n"
+
+	rows, err := parseImportFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	for i, row := range rows {
+		if row.Line != i+2 {
+			t.Errorf("row %d: got line %d, want %d", i, row.Line, i+2)
+		}
+	}
+
+	if len(rows[0].Errors) != 0 {
+		t.Errorf("got errors %v for a valid row", rows[0].Errors)
+	}
+
+	if len(rows[1].Errors) == 0 {
+		t.Error("got no errors for a row with an invalid price")
+	}
+}
+
+func TestParseImportRecord(t *testing.T) {
+	header := []string{"category", "name", "price", "stock", "image", "sku"}
+	columns := make(map[string]int)
+	for i, column := range header {
+		columns[column] = i
+	}
+
+	tests := []struct {
+		name    string
+		record  []string
+		wantErr string
+	}{
+		{
+			name:   "valid record",
+			record: []string{"Drinks", "Tea", "5000", "10", "https://example.com/tea.png", ""},
+		},
+		{
+			name:   "valid record with sku",
+			record: []string{"Drinks", "Tea", "5000.5", "10", "https://example.com/tea.png", "0c2b7d5e-5d6f-4c1a-9f0e-2f8f3c1e4b6a"},
+		},
+		{
+			name:   "surrounding spaces",
+			record: []string{" Drinks ", " Tea ", " 5000 ", " 10 ", " https://example.com/tea.png ", " "},
+		},
+		{
+			name:    "price not a number",
+			record:  []string{"Drinks", "Tea", "abc", "10", "https://example.com/tea.png", ""},
+			wantErr: "price must be a number",
+		},
+		{
+			name:    "price NaN",
+			record:  []string{"Drinks", "Tea", "NaN", "10", "https://example.com/tea.png", ""},
+			wantErr: "price must be a number",
+		},
+		{
+			name:    "price infinite",
+			record:  []string{"Drinks", "Tea", "Inf", "10", "https://example.com/tea.png", ""},
+			wantErr: "price must be a number",
+		},
+		{
+			name:    "price negative infinite",
+			record:  []string{"Drinks", "Tea", "-Inf", "10", "https://example.com/tea.png", ""},
+			wantErr: "price must be a number",
+		},
+		{
+			name:    "price negative",
+			record:  []string{"Drinks", "Tea", "-1", "10", "https://example.com/tea.png", ""},
+			wantErr: "Price",
+		},
+		{
+			name:    "stock not an integer",
+			record:  []string{"Drinks", "Tea", "5000", "1.5", "https://example.com/tea.png", ""},
+			wantErr: "stock must be an integer",
+		},
+		{
+			name:    "missing category",
+			record:  []string{"", "Tea", "5000", "10", "https://example.com/tea.png", ""},
+			wantErr: "CategoryName",
+		},
+		{
+			name:    "invalid sku",
+			record:  []string{"Drinks", "Tea", "5000", "10", "https://example.com/tea.png", "tea"},
+			wantErr: "SKU",
+		},
+		{
+			name:    "too few columns",
+			record:  []string{"Drinks", "Tea", "5000", "10"},
+			wantErr: "row must have 6 columns, not 4",
+		},
+		{
+			name:    "too many columns",
+			record:  []string{"Drinks", "Tea", "5000", "10", "https://example.com/tea.png", "", "extra"},
+			wantErr: "row must have 6 columns, not 7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := parseImportRecord(2, tt.record, len(header), columns)
+
+			if tt.wantErr == "" {
+				if len(row.Errors) != 0 {
+					t.Fatalf("got errors %v, want none", row.Errors)
+				}
+
+				if row.Product.Name != "Tea" || row.Product.Category.Name != "Drinks" || row.Product.Stock != 10 {
+					t.Fatalf("got product %+v", row.Product)
+				}
+
+				return
+			}
+
+			found := false
+			for _, err := range row.Errors {
+				if strings.Contains(err, tt.wantErr) {
+					found = true
+				}
+			}
+
+			if !found {
+				t.Fatalf("got errors %v, want one containing %q", row.Errors, tt.wantErr)
+			}
+		})
+	}
+}
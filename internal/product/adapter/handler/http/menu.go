@@ -0,0 +1,47 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	chdomain "go-restaurant/internal/channel/domain"
+	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+)
+
+// getMenuRequest represents a request body for retrieving the menu
+type getMenuRequest struct {
+	Channel      chdomain.Channel `form:"channel" binding:"omitempty,channel" example:"delivery"`
+	AvailableNow bool             `form:"available_now" binding:"omitempty" example:"true"`
+}
+
+// GetMenu godoc
+//
+//	@Summary		Get the menu
+//	@Description	Get the menu as a tree of categories in display order, each with its products and subcategories, optionally only with the products that can be sold now. Given a channel, only the products on its menu are included at the prices of its price list
+//	@Tags			Categories
+//	@Accept			json
+//	@Produce		json
+//	@Param			channel			query		string				false	"Channel"	Enums(dine_in, takeaway, delivery)
+//	@Param			available_now	query		bool				false	"Only products available now"
+//	@Success		200				{array}		menuSectionResponse	"Menu retrieved"
+//	@Failure		400				{object}	errorResponse		"Validation error"
+//	@Failure		401				{object}	errorResponse		"Unauthorized error"
+//	@Failure		403				{object}	errorResponse		"Forbidden error"
+//	@Failure		500				{object}	errorResponse		"Internal server error"
+//	@Router			/categories/tree [get]
+//	@Security		BearerAuth
+func (ph *ProductHandler) GetMenu(ctx *gin.Context) {
+	var req getMenuRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	menu, err := ph.svc.GetMenu(ctx, req.Channel, req.AvailableNow)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newMenuResponse(menu)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
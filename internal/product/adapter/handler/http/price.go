@@ -0,0 +1,177 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+	cmutil "go-restaurant/internal/common/util"
+	"go-restaurant/internal/product/domain"
+	"go-restaurant/internal/product/port"
+	"time"
+)
+
+// ProductPriceHandler represents the HTTP handler for product price-related requests
+type ProductPriceHandler struct {
+	svc port.ProductPriceService
+}
+
+// NewProductPriceHandler creates a new ProductPriceHandler instance
+func NewProductPriceHandler(svc port.ProductPriceService) *ProductPriceHandler {
+	return &ProductPriceHandler{
+		svc,
+	}
+}
+
+// productPricesRequest represents the path of a request for the prices of a product
+type productPricesRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// listProductPricesRequest represents a request body for listing the prices of a product
+type listProductPricesRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListProductPrices godoc
+//
+//	@Summary		List the prices of a product
+//	@Description	List the past, current and scheduled prices of a product with pagination, latest first
+//	@Tags			Products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		uint64			true	"Product ID"
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Product prices retrieved"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/products/{id}/prices [get]
+//	@Security		BearerAuth
+func (ph *ProductPriceHandler) ListProductPrices(ctx *gin.Context) {
+	var uri productPricesRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	var req listProductPricesRequest
+	var pricesList []productPriceResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	prices, err := ph.svc.ListProductPrices(ctx, uri.ID, req.Skip, req.Limit)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	for _, price := range prices {
+		pricesList = append(pricesList, newProductPriceResponse(&price))
+	}
+
+	total := uint64(len(pricesList))
+	meta := cmhttp.NewMeta(total, req.Limit, req.Skip)
+	rsp := cmutil.ToMap(meta, pricesList, "prices")
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// scheduleProductPriceRequest represents a request body for scheduling a price change of a product
+type scheduleProductPriceRequest struct {
+	Price         float64   `json:"price" binding:"required,min=0" example:"5500"`
+	EffectiveFrom time.Time `json:"effective_from" binding:"required" example:"2030-01-07T00:00:00+07:00"`
+}
+
+// ScheduleProductPrice godoc
+//
+//	@Summary		Schedule a price change of a product
+//	@Description	Schedule a new price of a product that orders created from its effective time on are charged
+//	@Tags			Products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id							path		uint64						true	"Product ID"
+//	@Param			scheduleProductPriceRequest	body		scheduleProductPriceRequest	true	"Schedule product price request"
+//	@Success		200							{object}	productPriceResponse		"Product price scheduled"
+//	@Failure		400							{object}	errorResponse				"Validation error"
+//	@Failure		401							{object}	errorResponse				"Unauthorized error"
+//	@Failure		403							{object}	errorResponse				"Forbidden error"
+//	@Failure		404							{object}	errorResponse				"Data not found error"
+//	@Failure		409							{object}	errorResponse				"Data conflict error"
+//	@Failure		500							{object}	errorResponse				"Internal server error"
+//	@Router			/products/{id}/prices [post]
+//	@Security		BearerAuth
+func (ph *ProductPriceHandler) ScheduleProductPrice(ctx *gin.Context) {
+	var uri productPricesRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	var req scheduleProductPriceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	price := domain.ProductPrice{
+		ProductID:     uri.ID,
+		Price:         req.Price,
+		EffectiveFrom: req.EffectiveFrom,
+	}
+
+	_, err := ph.svc.ScheduleProductPrice(ctx, &price)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newProductPriceResponse(&price)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// cancelProductPriceRequest represents a request body for cancelling a scheduled price change of a product
+type cancelProductPriceRequest struct {
+	ID      uint64 `uri:"id" binding:"required,min=1" example:"1"`
+	PriceID uint64 `uri:"price_id" binding:"required,min=1" example:"1"`
+}
+
+// CancelProductPrice godoc
+//
+//	@Summary		Cancel a scheduled price change of a product
+//	@Description	Cancel a price change of a product that has not taken effect yet
+//	@Tags			Products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		uint64			true	"Product ID"
+//	@Param			price_id	path		uint64			true	"Product price ID"
+//	@Success		200			{object}	response		"Product price cancelled"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		404			{object}	errorResponse	"Data not found error"
+//	@Failure		409			{object}	errorResponse	"Data conflict error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/products/{id}/prices/{price_id} [delete]
+//	@Security		BearerAuth
+func (ph *ProductPriceHandler) CancelProductPrice(ctx *gin.Context) {
+	var req cancelProductPriceRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	err := ph.svc.CancelProductPrice(ctx, req.ID, req.PriceID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	cmhttp.HandleSuccess(ctx, nil)
+}
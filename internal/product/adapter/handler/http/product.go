@@ -2,8 +2,11 @@ package http
 
 import (
 	"github.com/gin-gonic/gin"
+	chdomain "go-restaurant/internal/channel/domain"
 	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+	cmdomain "go-restaurant/internal/common/domain"
 	cmutil "go-restaurant/internal/common/util"
+	imghttp "go-restaurant/internal/image/adapter/handler/http"
 	"go-restaurant/internal/product/domain"
 	"go-restaurant/internal/product/port"
 )
@@ -20,19 +23,35 @@ func NewProductHandler(svc port.ProductService) *ProductHandler {
 	}
 }
 
+// productRequest represents the product fields shared by the create and import requests
+type productRequest struct {
+	Name  string  `json:"name" binding:"required" example:"Chiki Ball"`
+	Image string  `json:"image" binding:"required" example:"https://example.com/chiki-ball.png"`
+	Price float64 `json:"price" binding:"required,min=0" example:"5000"`
+	Stock int64   `json:"stock" binding:"required,min=0" example:"100"`
+}
+
+// bundleSlotRequest represents a request body for a slot of a bundle, whose first product is the default choice
+type bundleSlotRequest struct {
+	Name       string   `json:"name" binding:"required" example:"Drink"`
+	Quantity   int64    `json:"qty" binding:"omitempty,min=1" example:"1"`
+	ProductIDs []uint64 `json:"product_ids" binding:"required,min=1,dive,min=1" example:"1"`
+}
+
 // createProductRequest represents a request body for creating a new product
 type createProductRequest struct {
-	CategoryID uint64  `json:"category_id" binding:"required,min=1" example:"1"`
-	Name       string  `json:"name" binding:"required" example:"Chiki Ball"`
-	Image      string  `json:"image" binding:"required" example:"https://example.com/chiki-ball.png"`
-	Price      float64 `json:"price" binding:"required,min=0" example:"5000"`
-	Stock      int64   `json:"stock" binding:"required,min=0" example:"100"`
+	CategoryID uint64 `json:"category_id" binding:"required,min=1" example:"1"`
+	productRequest
+	Cost       float64             `json:"cost" binding:"omitempty,min=0" example:"3000"`
+	DayPartIDs []uint64            `json:"day_part_ids" binding:"omitempty,dive,min=1" example:"1"`
+	Type       domain.ProductType  `json:"type" binding:"omitempty,oneof=single bundle" example:"single"`
+	Slots      []bundleSlotRequest `json:"slots" binding:"omitempty,dive"`
 }
 
 // CreateProduct godoc
 //
 //	@Summary		Create a new product
-//	@Description	create a new product with name, image, price, and stock
+//	@Description	create a new product with name, image, price, cost, and stock, optionally restricted to day-parts. A bundle is sold at its own price and needs slots to choose its component products from, whose stock is tracked instead of its own
 //	@Tags			Products
 //	@Accept			json
 //	@Produce		json
@@ -58,7 +77,11 @@ func (ph *ProductHandler) CreateProduct(ctx *gin.Context) {
 		Name:       req.Name,
 		Image:      req.Image,
 		Price:      req.Price,
+		Cost:       req.Cost,
 		Stock:      req.Stock,
+		DayPartIDs: req.DayPartIDs,
+		Type:       req.Type,
+		Slots:      newBundleSlots(req.Slots),
 	}
 
 	_, err := ph.svc.CreateProduct(ctx, &product)
@@ -111,26 +134,30 @@ func (ph *ProductHandler) GetProduct(ctx *gin.Context) {
 
 // listProductsRequest represents a request body for listing products
 type listProductsRequest struct {
-	CategoryID uint64 `form:"category_id" binding:"omitempty,min=1" example:"1"`
-	Query      string `form:"q" binding:"omitempty" example:"Chiki"`
-	Skip       uint64 `form:"skip" binding:"required,min=0" example:"0"`
-	Limit      uint64 `form:"limit" binding:"required,min=5" example:"5"`
+	CategoryID   uint64           `form:"category_id" binding:"omitempty,min=1" example:"1"`
+	Query        string           `form:"q" binding:"omitempty" example:"Chiki"`
+	Channel      chdomain.Channel `form:"channel" binding:"omitempty,channel" example:"delivery"`
+	AvailableNow bool             `form:"available_now" binding:"omitempty" example:"true"`
+	Skip         uint64           `form:"skip" binding:"required,min=0" example:"0"`
+	Limit        uint64           `form:"limit" binding:"required,min=5" example:"5"`
 }
 
 // ListProducts godoc
 //
 //	@Summary		List products
-//	@Description	List products with pagination
+//	@Description	List products with pagination, optionally only the ones that can be sold now. Filtering by a category includes the products of its subcategories. Given a channel, only the products on its menu are listed at the prices of its price list
 //	@Tags			Products
 //	@Accept			json
 //	@Produce		json
-//	@Param			category_id	query		uint64			false	"Category ID"
-//	@Param			q			query		string			false	"Query"
-//	@Param			skip		query		uint64			true	"Skip"
-//	@Param			limit		query		uint64			true	"Limit"
-//	@Success		200			{object}	meta			"Products retrieved"
-//	@Failure		400			{object}	errorResponse	"Validation error"
-//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Param			category_id		query		uint64			false	"Category ID"
+//	@Param			q				query		string			false	"Query"
+//	@Param			channel			query		string			false	"Channel"	Enums(dine_in, takeaway, delivery)
+//	@Param			available_now	query		bool			false	"Only products available now"
+//	@Param			skip			query		uint64			true	"Skip"
+//	@Param			limit			query		uint64			true	"Limit"
+//	@Success		200				{object}	meta			"Products retrieved"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
 //	@Router			/products [get]
 //	@Security		BearerAuth
 func (ph *ProductHandler) ListProducts(ctx *gin.Context) {
@@ -142,7 +169,7 @@ func (ph *ProductHandler) ListProducts(ctx *gin.Context) {
 		return
 	}
 
-	products, err := ph.svc.ListProducts(ctx, req.Query, req.CategoryID, req.Skip, req.Limit)
+	products, err := ph.svc.ListProducts(ctx, req.Query, req.CategoryID, req.Channel, req.AvailableNow, req.Skip, req.Limit)
 	if err != nil {
 		cmhttp.HandleError(ctx, err)
 		return
@@ -159,19 +186,83 @@ func (ph *ProductHandler) ListProducts(ctx *gin.Context) {
 	cmhttp.HandleSuccess(ctx, rsp)
 }
 
+// exportProductsRequest represents a request body for exporting products
+type exportProductsRequest struct {
+	CategoryID uint64                `form:"category_id" binding:"omitempty,min=1" example:"1"`
+	Query      string                `form:"q" binding:"omitempty" example:"Chiki"`
+	Format     cmdomain.ExportFormat `form:"format" binding:"omitempty,export_format" example:"csv"`
+}
+
+// ExportProducts godoc
+//
+//	@Summary		Export products
+//	@Description	Export all products matching the list filters as a CSV or XLSX file
+//	@Tags			Products
+//	@Accept			json
+//	@Produce		text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			category_id	query		uint64			false	"Category ID"
+//	@Param			q			query		string			false	"Query"
+//	@Param			format		query		string			false	"File format"	Enums(csv, xlsx)
+//	@Success		200			{file}		file			"Products exported"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/products/export [get]
+//	@Security		BearerAuth
+func (ph *ProductHandler) ExportProducts(ctx *gin.Context) {
+	var req exportProductsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	writer, err := cmhttp.NewExportWriter(ctx, req.Format, "products")
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	err = writer.WriteHeader("ID", "SKU", "Name", "Category", "Price", "Cost", "Stock", "Image", "Created At", "Updated At")
+	if err != nil {
+		cmhttp.HandleExport(ctx, writer, err)
+		return
+	}
+
+	err = ph.svc.ExportProducts(ctx, req.Query, req.CategoryID, func(product *domain.Product) error {
+		return writer.WriteRow(
+			product.ID,
+			product.SKU.String(),
+			product.Name,
+			product.Category.Name,
+			product.Price,
+			product.Cost,
+			product.Stock,
+			product.Image,
+			product.CreatedAt,
+			product.UpdatedAt,
+		)
+	})
+
+	cmhttp.HandleExport(ctx, writer, err)
+}
+
 // updateProductRequest represents a request body for updating a product
 type updateProductRequest struct {
-	CategoryID uint64  `json:"category_id" binding:"omitempty,required,min=1" example:"1"`
-	Name       string  `json:"name" binding:"omitempty,required" example:"Nutrisari Jeruk"`
-	Image      string  `json:"image" binding:"omitempty,required" example:"https://example.com/nutrisari-jeruk.png"`
-	Price      float64 `json:"price" binding:"omitempty,required,min=0" example:"2000"`
-	Stock      int64   `json:"stock" binding:"omitempty,required,min=0" example:"200"`
+	CategoryID uint64              `json:"category_id" binding:"omitempty,required,min=1" example:"1"`
+	Name       string              `json:"name" binding:"omitempty,required" example:"Nutrisari Jeruk"`
+	Image      string              `json:"image" binding:"omitempty,required" example:"https://example.com/nutrisari-jeruk.png"`
+	Price      float64             `json:"price" binding:"omitempty,required,min=0" example:"2000"`
+	Cost       float64             `json:"cost" binding:"omitempty,required,min=0" example:"1200"`
+	Stock      int64               `json:"stock" binding:"omitempty,required,min=0" example:"200"`
+	DayPartIDs []uint64            `json:"day_part_ids" binding:"omitempty,dive,min=1" example:"1"`
+	Slots      []bundleSlotRequest `json:"slots" binding:"omitempty,dive"`
 }
 
 // UpdateProduct godoc
 //
 //	@Summary		Update a product
-//	@Description	update a product's name, image, price, or stock by id
+//	@Description	update a product's name, image, price, cost, stock, the day-parts it is restricted to, or the slots of a bundle by id
 //	@Tags			Products
 //	@Accept			json
 //	@Produce		json
@@ -206,7 +297,10 @@ func (ph *ProductHandler) UpdateProduct(ctx *gin.Context) {
 		Name:       req.Name,
 		Image:      req.Image,
 		Price:      req.Price,
+		Cost:       req.Cost,
 		Stock:      req.Stock,
+		DayPartIDs: req.DayPartIDs,
+		Slots:      newBundleSlots(req.Slots),
 	}
 
 	_, err = ph.svc.UpdateProduct(ctx, &product)
@@ -220,6 +314,97 @@ func (ph *ProductHandler) UpdateProduct(ctx *gin.Context) {
 	cmhttp.HandleSuccess(ctx, rsp)
 }
 
+// UploadProductImage godoc
+//
+//	@Summary		Upload an image of a product
+//	@Description	Upload a JPEG, PNG or GIF image of a product of up to 5 MB and 4096x4096 pixels, which becomes the product's image. A thumbnail of it is generated, and an uploaded image it replaces is deleted
+//	@Tags			Products
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		uint64			true	"Product ID"
+//	@Param			image	formData	file			true	"Image"
+//	@Success		200		{object}	imageResponse	"Product image uploaded"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		413		{object}	errorResponse	"Image too large error"
+//	@Failure		415		{object}	errorResponse	"Unsupported image type error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/products/{id}/image [put]
+//	@Security		BearerAuth
+func (ph *ProductHandler) UploadProductImage(ctx *gin.Context) {
+	var uri getProductRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	image, err := imghttp.FormImage(ctx)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+	defer image.Close()
+
+	img, err := ph.svc.UploadProductImage(ctx, uri.ID, image)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := imghttp.NewImageResponse(img)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// setProductAvailabilityRequest represents a request body for 86ing a product or putting it back on sale
+type setProductAvailabilityRequest struct {
+	Available *bool  `json:"available" binding:"required" example:"false"`
+	Reason    string `json:"reason" binding:"omitempty,max=255" example:"Ran out of dough"`
+}
+
+// SetProductAvailability godoc
+//
+//	@Summary		86 a product or put it back on sale
+//	@Description	86 a product by id with a reason, which takes it off sale until the end of the business day regardless of its stock, or put it back on sale
+//	@Tags			Products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id								path		uint64							true	"Product ID"
+//	@Param			setProductAvailabilityRequest	body		setProductAvailabilityRequest	true	"Set product availability request"
+//	@Success		200								{object}	productResponse					"Product availability set"
+//	@Failure		400								{object}	errorResponse					"Validation error"
+//	@Failure		401								{object}	errorResponse					"Unauthorized error"
+//	@Failure		403								{object}	errorResponse					"Forbidden error"
+//	@Failure		404								{object}	errorResponse					"Data not found error"
+//	@Failure		500								{object}	errorResponse					"Internal server error"
+//	@Router			/products/{id}/availability [put]
+//	@Security		BearerAuth
+func (ph *ProductHandler) SetProductAvailability(ctx *gin.Context) {
+	var uri getProductRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	var req setProductAvailabilityRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	product, err := ph.svc.SetProductAvailability(ctx, uri.ID, *req.Available, req.Reason)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := NewProductResponse(product)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
 // deleteProductRequest represents a request body for deleting a product
 type deleteProductRequest struct {
 	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
@@ -256,3 +441,67 @@ func (ph *ProductHandler) DeleteProduct(ctx *gin.Context) {
 
 	cmhttp.HandleSuccess(ctx, nil)
 }
+
+// restoreProductRequest represents a request body for restoring a deleted product
+type restoreProductRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// RestoreProduct godoc
+//
+//	@Summary		Restore a product
+//	@Description	Restore a deleted product by id, unless its category is deleted
+//	@Tags			Products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Product ID"
+//	@Success		200	{object}	productResponse	"Product restored"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		409	{object}	errorResponse	"Data conflict error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/products/{id}/restore [post]
+//	@Security		BearerAuth
+func (ph *ProductHandler) RestoreProduct(ctx *gin.Context) {
+	var req restoreProductRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	product, err := ph.svc.RestoreProduct(ctx, req.ID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := NewProductResponse(product)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// newBundleSlots converts bundle slot requests to bundle slots of one product each by default, keeping nil as nil
+// so an update without slots leaves them unchanged
+func newBundleSlots(reqs []bundleSlotRequest) []domain.BundleSlot {
+	if reqs == nil {
+		return nil
+	}
+
+	slots := make([]domain.BundleSlot, 0, len(reqs))
+	for _, req := range reqs {
+		quantity := req.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+
+		slots = append(slots, domain.BundleSlot{
+			Name:       req.Name,
+			Quantity:   quantity,
+			ProductIDs: req.ProductIDs,
+		})
+	}
+
+	return slots
+}
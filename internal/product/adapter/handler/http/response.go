@@ -8,28 +8,177 @@ import (
 
 // ProductResponse represents a product Response body
 type ProductResponse struct {
-	ID        uint64                `json:"id" example:"1"`
-	SKU       string                `json:"sku" example:"9a4c25d3-9786-492c-b084-85cb75c1ee3e"`
-	Name      string                `json:"name" example:"Chiki Ball"`
-	Stock     int64                 `json:"stock" example:"100"`
-	Price     float64               `json:"price" example:"5000"`
-	Image     string                `json:"image" example:"https://example.com/chiki-ball.png"`
-	Category  http.CategoryResponse `json:"category"`
-	CreatedAt time.Time             `json:"created_at" example:"1970-01-01T00:00:00Z"`
-	UpdatedAt time.Time             `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+	ID                uint64                `json:"id" example:"1"`
+	SKU               string                `json:"sku" example:"9a4c25d3-9786-492c-b084-85cb75c1ee3e"`
+	Name              string                `json:"name" example:"Chiki Ball"`
+	Stock             int64                 `json:"stock" example:"100"`
+	Price             float64               `json:"price" example:"5000"`
+	Cost              float64               `json:"cost" example:"3000"`
+	Image             string                `json:"image" example:"https://example.com/chiki-ball.png"`
+	Category          http.CategoryResponse `json:"category"`
+	Type              domain.ProductType    `json:"type" example:"single"`
+	DayPartIDs        []uint64              `json:"day_part_ids" example:"1"`
+	Slots             []bundleSlotResponse  `json:"slots,omitempty"`
+	Available         bool                  `json:"available" example:"true"`
+	UnavailableReason string                `json:"unavailable_reason,omitempty" example:"Ran out of dough"`
+	UnavailableUntil  *time.Time            `json:"unavailable_until,omitempty" example:"1970-01-01T00:00:00Z"`
+	CreatedAt         time.Time             `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt         time.Time             `json:"updated_at" example:"1970-01-01T00:00:00Z"`
 }
 
 // NewProductResponse is a helper function to create a Response body for handling product data
 func NewProductResponse(product *domain.Product) ProductResponse {
 	return ProductResponse{
-		ID:        product.ID,
-		SKU:       product.SKU.String(),
-		Name:      product.Name,
-		Stock:     product.Stock,
-		Price:     product.Price,
-		Image:     product.Image,
-		Category:  http.NewCategoryResponse(product.Category),
-		CreatedAt: product.CreatedAt,
-		UpdatedAt: product.UpdatedAt,
+		ID:                product.ID,
+		SKU:               product.SKU.String(),
+		Name:              product.Name,
+		Stock:             product.Stock,
+		Price:             product.Price,
+		Cost:              product.Cost,
+		Image:             product.Image,
+		Category:          http.NewCategoryResponse(product.Category),
+		Type:              product.Type,
+		DayPartIDs:        product.DayPartIDs,
+		Slots:             newBundleSlotResponses(product.Slots),
+		Available:         !product.IsEightySixed(time.Now()),
+		UnavailableReason: product.UnavailableReason,
+		UnavailableUntil:  product.UnavailableUntil,
+		CreatedAt:         product.CreatedAt,
+		UpdatedAt:         product.UpdatedAt,
+	}
+}
+
+// bundleSlotResponse represents a bundle slot Response body
+type bundleSlotResponse struct {
+	ID         uint64   `json:"id" example:"1"`
+	Name       string   `json:"name" example:"Drink"`
+	Quantity   int64    `json:"qty" example:"1"`
+	ProductIDs []uint64 `json:"product_ids" example:"1"`
+}
+
+// newBundleSlotResponses is a helper function to create a Response body for handling bundle slot data
+func newBundleSlotResponses(slots []domain.BundleSlot) []bundleSlotResponse {
+	var slotsResponse []bundleSlotResponse
+
+	for _, slot := range slots {
+		slotsResponse = append(slotsResponse, bundleSlotResponse{
+			ID:         slot.ID,
+			Name:       slot.Name,
+			Quantity:   slot.Quantity,
+			ProductIDs: slot.ProductIDs,
+		})
+	}
+
+	return slotsResponse
+}
+
+// menuSectionResponse represents a menu section Response body
+type menuSectionResponse struct {
+	ID            uint64                `json:"id" example:"1"`
+	Name          string                `json:"name" example:"Drinks"`
+	Image         string                `json:"image" example:"https://example.com/drinks.png"`
+	SortOrder     int64                 `json:"sort_order" example:"1"`
+	Products      []ProductResponse     `json:"products"`
+	Subcategories []menuSectionResponse `json:"subcategories"`
+}
+
+// newMenuResponse is a helper function to create a Response body for handling menu data
+func newMenuResponse(sections []domain.MenuSection) []menuSectionResponse {
+	sectionsResponse := []menuSectionResponse{}
+
+	for _, section := range sections {
+		productsResponse := []ProductResponse{}
+		for _, product := range section.Products {
+			productsResponse = append(productsResponse, NewProductResponse(&product))
+		}
+
+		sectionsResponse = append(sectionsResponse, menuSectionResponse{
+			ID:            section.Category.ID,
+			Name:          section.Category.Name,
+			Image:         *section.Category.Image,
+			SortOrder:     *section.Category.SortOrder,
+			Products:      productsResponse,
+			Subcategories: newMenuResponse(section.Subsections),
+		})
+	}
+
+	return sectionsResponse
+}
+
+// productPriceResponse represents a product price Response body
+type productPriceResponse struct {
+	ID            uint64    `json:"id" example:"1"`
+	ProductID     uint64    `json:"product_id" example:"1"`
+	Price         float64   `json:"price" example:"5500"`
+	EffectiveFrom time.Time `json:"effective_from" example:"1970-01-01T00:00:00Z"`
+	Scheduled     bool      `json:"scheduled" example:"true"`
+	CreatedAt     time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newProductPriceResponse is a helper function to create a Response body for handling product price data
+func newProductPriceResponse(price *domain.ProductPrice) productPriceResponse {
+	return productPriceResponse{
+		ID:            price.ID,
+		ProductID:     price.ProductID,
+		Price:         price.Price,
+		EffectiveFrom: price.EffectiveFrom,
+		Scheduled:     price.IsScheduled(time.Now()),
+		CreatedAt:     price.CreatedAt,
+	}
+}
+
+// productImportRowResponse represents a row of a product import Response body
+type productImportRowResponse struct {
+	Line   int      `json:"line" example:"2"`
+	ID     uint64   `json:"id,omitempty" example:"1"`
+	SKU    string   `json:"sku,omitempty" example:"9a4c25d3-9786-492c-b084-85cb75c1ee3e"`
+	Name   string   `json:"name" example:"Chiki Ball"`
+	Action string   `json:"action,omitempty" example:"created"`
+	Errors []string `json:"errors,omitempty" example:"category \"Snacks\" not found"`
+}
+
+// productImportResponse represents a product import Response body
+type productImportResponse struct {
+	DryRun  bool                       `json:"dry_run" example:"true"`
+	Created int                        `json:"created" example:"10"`
+	Updated int                        `json:"updated" example:"2"`
+	Failed  int                        `json:"failed" example:"0"`
+	Rows    []productImportRowResponse `json:"rows"`
+}
+
+// newProductImportResponse is a helper function to create a Response body for handling product import data
+func newProductImportResponse(result *domain.ProductImport) productImportResponse {
+	var rows []productImportRowResponse
+
+	written := result.Failed == 0
+
+	for _, row := range result.Rows {
+		rsp := productImportRowResponse{
+			Line:   row.Line,
+			Name:   row.Product.Name,
+			Errors: row.Errors,
+		}
+
+		if written {
+			rsp.SKU = row.Product.SKU.String()
+			rsp.Action = "updated"
+			if row.Created {
+				rsp.Action = "created"
+			}
+		}
+
+		if written && !result.DryRun {
+			rsp.ID = row.Product.ID
+		}
+
+		rows = append(rows, rsp)
+	}
+
+	return productImportResponse{
+		DryRun:  result.DryRun,
+		Created: result.Created,
+		Updated: result.Updated,
+		Failed:  result.Failed,
+		Rows:    rows,
 	}
 }
@@ -0,0 +1,229 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"go-restaurant/internal/common/adapter/storage/postgres"
+	cmdomain "go-restaurant/internal/common/domain"
+	"go-restaurant/internal/product/domain"
+	"time"
+)
+
+/*ProductPriceRepository implements port.ProductPriceRepository interface
+ * and provides access to the postgres database
+ */
+type ProductPriceRepository struct {
+	db *postgres.DB
+}
+
+// NewProductPriceRepository creates a new product price repository instance
+func NewProductPriceRepository(db *postgres.DB) *ProductPriceRepository {
+	return &ProductPriceRepository{
+		db,
+	}
+}
+
+// CreateProductPrice creates a new product price record in the database
+func (pr *ProductPriceRepository) CreateProductPrice(ctx context.Context, price *domain.ProductPrice) (*domain.ProductPrice, error) {
+	query := pr.db.QueryBuilder.Insert("product_prices").
+		Columns("product_id", "price", "effective_from").
+		Values(price.ProductID, price.Price, price.EffectiveFrom).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = pr.db.QueryRow(ctx, sql, args...).Scan(
+		&price.ID,
+		&price.ProductID,
+		&price.Price,
+		&price.EffectiveFrom,
+		&price.CreatedAt,
+	)
+	if err != nil {
+		if errCode := pr.db.ErrorCode(err); errCode == "23505" {
+			return nil, cmdomain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return price, nil
+}
+
+// GetProductPriceByID retrieves a product price record from the database by id
+func (pr *ProductPriceRepository) GetProductPriceByID(ctx context.Context, id uint64) (*domain.ProductPrice, error) {
+	query := pr.db.QueryBuilder.Select("*").
+		From("product_prices").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	return pr.getProductPrice(ctx, query)
+}
+
+// GetEffectiveProductPrice retrieves the product price record in effect at a time from the database,
+// which is the latest one that took effect by then
+func (pr *ProductPriceRepository) GetEffectiveProductPrice(ctx context.Context, productID uint64, at time.Time) (*domain.ProductPrice, error) {
+	query := pr.db.QueryBuilder.Select("*").
+		From("product_prices").
+		Where(sq.Eq{"product_id": productID}).
+		Where(sq.LtOrEq{"effective_from": at}).
+		OrderBy("effective_from DESC").
+		Limit(1)
+
+	return pr.getProductPrice(ctx, query)
+}
+
+// getProductPrice retrieves the product price record selected by a query from the database
+func (pr *ProductPriceRepository) getProductPrice(ctx context.Context, query sq.SelectBuilder) (*domain.ProductPrice, error) {
+	var price domain.ProductPrice
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = pr.db.QueryRow(ctx, sql, args...).Scan(
+		&price.ID,
+		&price.ProductID,
+		&price.Price,
+		&price.EffectiveFrom,
+		&price.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &price, nil
+}
+
+// ListProductPrices retrieves a list of the price records of a product from the database, latest first
+func (pr *ProductPriceRepository) ListProductPrices(ctx context.Context, productID, skip, limit uint64) ([]domain.ProductPrice, error) {
+	var price domain.ProductPrice
+	var prices []domain.ProductPrice
+
+	query := pr.db.QueryBuilder.Select("*").
+		From("product_prices").
+		Where(sq.Eq{"product_id": productID}).
+		OrderBy("effective_from DESC").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&price.ID,
+			&price.ProductID,
+			&price.Price,
+			&price.EffectiveFrom,
+			&price.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		prices = append(prices, price)
+	}
+
+	return prices, nil
+}
+
+// ApplyProductPrices sets the price of the product records to their price in effect at a time in the database,
+// so scheduled price changes show up in the products once they take effect, and returns the products
+// whose price changed as they were before
+func (pr *ProductPriceRepository) ApplyProductPrices(ctx context.Context, at time.Time) ([]domain.Product, error) {
+	var product domain.Product
+	var products []domain.Product
+
+	effectivePrices := pr.db.QueryBuilder.Select("DISTINCT ON (product_prices.product_id) product_prices.product_id", "product_prices.price", "products.price AS previous_price").
+		From("product_prices").
+		Join("products ON products.id = product_prices.product_id").
+		Where(sq.LtOrEq{"product_prices.effective_from": at}).
+		OrderBy("product_prices.product_id", "product_prices.effective_from DESC")
+
+	query := pr.db.QueryBuilder.Update("products").
+		Set("price", sq.Expr("effective_prices.price")).
+		Set("updated_at", at).
+		FromSelect(effectivePrices, "effective_prices").
+		Where("products.id = effective_prices.product_id").
+		Where("products.price IS DISTINCT FROM effective_prices.price").
+		Suffix("RETURNING products.*, " + productDayPartIDs + ", " + productBundleSlots + ", effective_prices.previous_price")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var previousPrice float64
+
+		err := rows.Scan(
+			&product.ID,
+			&product.CategoryID,
+			&product.SKU,
+			&product.Name,
+			&product.Stock,
+			&product.Price,
+			&product.Image,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&product.Cost,
+			&product.DeletedAt,
+			&product.UnavailableReason,
+			&product.UnavailableUntil,
+			&product.Type,
+			&product.DayPartIDs,
+			&product.Slots,
+			&previousPrice,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		product.Price = previousPrice
+
+		products = append(products, product)
+	}
+
+	return products, rows.Err()
+}
+
+// DeleteProductPrice deletes a product price record from the database by id
+func (pr *ProductPriceRepository) DeleteProductPrice(ctx context.Context, id uint64) error {
+	query := pr.db.QueryBuilder.Delete("product_prices").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = pr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
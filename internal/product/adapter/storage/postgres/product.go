@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	cadomain "go-restaurant/internal/category/domain"
+	chdomain "go-restaurant/internal/channel/domain"
 	"go-restaurant/internal/common/adapter/storage/postgres"
 	cmdomain "go-restaurant/internal/common/domain"
 	cmutil "go-restaurant/internal/common/util"
@@ -26,11 +29,145 @@ func NewProductRepository(db *postgres.DB) *ProductRepository {
 	}
 }
 
-// CreateProduct creates a new product record in the database
+// productDayPartIDs selects the ids of the day-parts a product is restricted to as an array
+const productDayPartIDs = "ARRAY(SELECT day_part_id FROM product_day_parts WHERE product_id = products.id ORDER BY day_part_id)"
+
+// productBundleSlots selects the slots of a bundle with their choices that are not deleted as a JSON array,
+// or NULL for a single product
+const productBundleSlots = `(SELECT json_agg(json_build_object(
+		'ID', bundle_slots.id,
+		'Name', bundle_slots.name,
+		'Quantity', bundle_slots.quantity,
+		'ProductIDs', ARRAY(SELECT product_id FROM bundle_slot_choices JOIN products AS choices ON choices.id = product_id
+			WHERE slot_id = bundle_slots.id AND choices.deleted_at IS NULL ORDER BY position)
+	) ORDER BY bundle_slots.id) FROM bundle_slots WHERE bundle_id = products.id)`
+
+// setDayParts replaces the day-parts a product is restricted to inside a transaction
+// and returns the ids of the day-parts it ends up restricted to
+func (pr *ProductRepository) setDayParts(ctx context.Context, tx pgx.Tx, productID uint64, dayPartIDs []uint64) ([]uint64, error) {
+	var ids []uint64
+
+	deleteQuery := pr.db.QueryBuilder.Delete("product_day_parts").
+		Where(sq.Eq{"product_id": productID})
+
+	sql, args, err := deleteQuery.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dayPartIDs) > 0 {
+		insertQuery := pr.db.QueryBuilder.Insert("product_day_parts").
+			Columns("product_id", "day_part_id").
+			Suffix("ON CONFLICT DO NOTHING")
+
+		for _, dayPartID := range dayPartIDs {
+			insertQuery = insertQuery.Values(productID, dayPartID)
+		}
+
+		sql, args, err := insertQuery.ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Exec(ctx, sql, args...)
+		if err != nil {
+			if errCode := pr.db.ErrorCode(err); errCode == "23503" {
+				return nil, cmdomain.ErrDataNotFound
+			}
+			return nil, err
+		}
+	}
+
+	selectQuery := pr.db.QueryBuilder.Select(productDayPartIDs).
+		From("products").
+		Where(sq.Eq{"id": productID})
+
+	sql, args, err = selectQuery.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(&ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// setBundleSlots replaces the slots of a bundle and their choices inside a transaction,
+// setting the ids of the slots it ends up with
+func (pr *ProductRepository) setBundleSlots(ctx context.Context, tx pgx.Tx, bundleID uint64, slots []domain.BundleSlot) error {
+	deleteQuery := pr.db.QueryBuilder.Delete("bundle_slots").
+		Where(sq.Eq{"bundle_id": bundleID})
+
+	sql, args, err := deleteQuery.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	for i, slot := range slots {
+		slotQuery := pr.db.QueryBuilder.Insert("bundle_slots").
+			Columns("bundle_id", "name", "quantity").
+			Values(bundleID, slot.Name, slot.Quantity).
+			Suffix("RETURNING id")
+
+		sql, args, err := slotQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, sql, args...).Scan(&slots[i].ID)
+		if err != nil {
+			return err
+		}
+
+		choicesQuery := pr.db.QueryBuilder.Insert("bundle_slot_choices").
+			Columns("slot_id", "product_id", "position").
+			Suffix("ON CONFLICT DO NOTHING")
+
+		for position, productID := range slot.ProductIDs {
+			choicesQuery = choicesQuery.Values(slots[i].ID, productID, position)
+		}
+
+		sql, args, err = choicesQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, sql, args...)
+		if err != nil {
+			if errCode := pr.db.ErrorCode(err); errCode == "23503" {
+				return cmdomain.ErrDataNotFound
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateProduct creates a new product record, the day-parts it is restricted to and, for a bundle, its slots in the database
 func (pr *ProductRepository) CreateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	tx, err := pr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
 	query := pr.db.QueryBuilder.Insert("products").
-		Columns("category_id", "name", "image", "price", "stock").
-		Values(product.CategoryID, product.Name, product.Image, product.Price, product.Stock).
+		Columns("category_id", "type", "name", "image", "price", "cost", "stock").
+		Values(product.CategoryID, product.Type, product.Name, product.Image, product.Price, product.Cost, product.Stock).
 		Suffix("RETURNING *")
 
 	sql, args, err := query.ToSql()
@@ -38,7 +175,7 @@ func (pr *ProductRepository) CreateProduct(ctx context.Context, product *domain.
 		return nil, err
 	}
 
-	err = pr.db.QueryRow(ctx, sql, args...).Scan(
+	err = tx.QueryRow(ctx, sql, args...).Scan(
 		&product.ID,
 		&product.CategoryID,
 		&product.SKU,
@@ -48,21 +185,57 @@ func (pr *ProductRepository) CreateProduct(ctx context.Context, product *domain.
 		&product.Image,
 		&product.CreatedAt,
 		&product.UpdatedAt,
+		&product.Cost,
+		&product.DeletedAt,
+		&product.UnavailableReason,
+		&product.UnavailableUntil,
+		&product.Type,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	product.DayPartIDs, err = pr.setDayParts(ctx, tx, product.ID, product.DayPartIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pr.setBundleSlots(ctx, tx, product.ID, product.Slots)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return product, nil
 }
 
-// GetProductByID retrieves a product record from the database by id
+// GetProductByID retrieves a product record that is not deleted from the database by id
 func (pr *ProductRepository) GetProductByID(ctx context.Context, id uint64) (*domain.Product, error) {
+	return pr.getProduct(ctx, sq.Eq{"id": id, "deleted_at": nil})
+}
+
+// GetProductByIDIncludingDeleted retrieves a product record from the database by id,
+// even if it is deleted, so the orders it is part of still resolve it
+func (pr *ProductRepository) GetProductByIDIncludingDeleted(ctx context.Context, id uint64) (*domain.Product, error) {
+	return pr.getProduct(ctx, sq.Eq{"id": id})
+}
+
+// GetProductBySKU retrieves a product record from the database by SKU, even if it is deleted
+func (pr *ProductRepository) GetProductBySKU(ctx context.Context, sku uuid.UUID) (*domain.Product, error) {
+	return pr.getProduct(ctx, sq.Eq{"sku": sku})
+}
+
+// getProduct retrieves the product record matching a condition from the database
+func (pr *ProductRepository) getProduct(ctx context.Context, where sq.Eq) (*domain.Product, error) {
 	var product domain.Product
 
-	query := pr.db.QueryBuilder.Select("*").
+	query := pr.db.QueryBuilder.Select("*", productDayPartIDs, productBundleSlots).
 		From("products").
-		Where(sq.Eq{"id": id}).
+		Where(where).
 		Limit(1)
 
 	sql, args, err := query.ToSql()
@@ -80,6 +253,13 @@ func (pr *ProductRepository) GetProductByID(ctx context.Context, id uint64) (*do
 		&product.Image,
 		&product.CreatedAt,
 		&product.UpdatedAt,
+		&product.Cost,
+		&product.DeletedAt,
+		&product.UnavailableReason,
+		&product.UnavailableUntil,
+		&product.Type,
+		&product.DayPartIDs,
+		&product.Slots,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -91,25 +271,71 @@ func (pr *ProductRepository) GetProductByID(ctx context.Context, id uint64) (*do
 	return &product, nil
 }
 
-// ListProducts retrieves a list of products from the database
-func (pr *ProductRepository) ListProducts(ctx context.Context, search string, categoryId, skip, limit uint64) ([]domain.Product, error) {
+// ListProducts retrieves a list of products from the database, only the ones on the menu of a channel unless it is empty
+// and the ones that can be sold at availableAt unless it is zero
+func (pr *ProductRepository) ListProducts(ctx context.Context, search string, categoryId uint64, channel chdomain.Channel, availableAt time.Time, skip, limit uint64) ([]domain.Product, error) {
 	var product domain.Product
 	var products []domain.Product
 
-	query := pr.db.QueryBuilder.Select("*").
+	query := pr.db.QueryBuilder.Select("*", productDayPartIDs, productBundleSlots).
 		From("products").
 		OrderBy("id").
 		Limit(limit).
 		Offset((skip - 1) * limit)
 
-	if categoryId != 0 {
-		query = query.Where(sq.Eq{"category_id": categoryId})
+	query = filterProducts(query, search, categoryId, channel, availableAt)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
 	}
 
-	if search != "" {
-		query = query.Where(sq.ILike{"name": "%" + search + "%"})
+	rows, err := pr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
 	}
 
+	for rows.Next() {
+		err := rows.Scan(
+			&product.ID,
+			&product.CategoryID,
+			&product.SKU,
+			&product.Name,
+			&product.Stock,
+			&product.Price,
+			&product.Image,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&product.Cost,
+			&product.DeletedAt,
+			&product.UnavailableReason,
+			&product.UnavailableUntil,
+			&product.Type,
+			&product.DayPartIDs,
+			&product.Slots,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, product)
+	}
+
+	return products, nil
+}
+
+// ListMenuProducts retrieves all products that are not deleted from the database by name, only the ones
+// that appear in channel unless it is empty and the ones that can be sold at availableAt unless it is zero
+func (pr *ProductRepository) ListMenuProducts(ctx context.Context, channel chdomain.Channel, availableAt time.Time) ([]domain.Product, error) {
+	var product domain.Product
+	var products []domain.Product
+
+	query := pr.db.QueryBuilder.Select("*", productDayPartIDs, productBundleSlots).
+		From("products").
+		OrderBy("name")
+
+	query = filterProducts(query, "", 0, channel, availableAt)
+
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
@@ -119,6 +345,7 @@ func (pr *ProductRepository) ListProducts(ctx context.Context, search string, ca
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		err := rows.Scan(
@@ -131,6 +358,13 @@ func (pr *ProductRepository) ListProducts(ctx context.Context, search string, ca
 			&product.Image,
 			&product.CreatedAt,
 			&product.UpdatedAt,
+			&product.Cost,
+			&product.DeletedAt,
+			&product.UnavailableReason,
+			&product.UnavailableUntil,
+			&product.Type,
+			&product.DayPartIDs,
+			&product.Slots,
 		)
 		if err != nil {
 			return nil, err
@@ -139,15 +373,103 @@ func (pr *ProductRepository) ListProducts(ctx context.Context, search string, ca
 		products = append(products, product)
 	}
 
-	return products, nil
+	return products, rows.Err()
+}
+
+// StreamProducts retrieves all products matching the list filters together with their category
+// and passes them one by one to fn without loading the whole result set into memory
+func (pr *ProductRepository) StreamProducts(ctx context.Context, search string, categoryId uint64, fn func(product *domain.Product) error) error {
+	var product domain.Product
+	var category cadomain.Category
+
+	query := pr.db.QueryBuilder.Select(
+		"products.id",
+		"products.category_id",
+		"products.sku",
+		"products.name",
+		"products.stock",
+		"products.price",
+		"products.cost",
+		"products.image",
+		"products.created_at",
+		"products.updated_at",
+		"categories.name",
+	).
+		From("products").
+		Join("categories ON categories.id = products.category_id").
+		OrderBy("products.id")
+
+	query = filterProducts(query, search, categoryId, "", time.Time{})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := pr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&product.ID,
+			&product.CategoryID,
+			&product.SKU,
+			&product.Name,
+			&product.Stock,
+			&product.Price,
+			&product.Cost,
+			&product.Image,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&category.Name,
+		)
+		if err != nil {
+			return err
+		}
+
+		category.ID = product.CategoryID
+		product.Category = &category
+
+		err = fn(&product)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
 }
 
-// UpdateProduct updates a product record in the database
+// UpdateProduct updates a product record in the database and, if given, replaces the day-parts it is restricted to
+// and the slots of a bundle
 func (pr *ProductRepository) UpdateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	tx, err := pr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if product.DayPartIDs != nil {
+		_, err = pr.setDayParts(ctx, tx, product.ID, product.DayPartIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if product.Slots != nil {
+		err = pr.setBundleSlots(ctx, tx, product.ID, product.Slots)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	categoryId := cmutil.NullUint64(product.CategoryID)
 	name := cmutil.NullString(product.Name)
 	image := cmutil.NullString(product.Image)
 	price := cmutil.NullFloat64(product.Price)
+	cost := cmutil.NullFloat64(product.Cost)
 	stock := cmutil.NullInt64(product.Stock)
 
 	query := pr.db.QueryBuilder.Update("products").
@@ -155,17 +477,18 @@ func (pr *ProductRepository) UpdateProduct(ctx context.Context, product *domain.
 		Set("category_id", sq.Expr("COALESCE(?, category_id)", categoryId)).
 		Set("image", sq.Expr("COALESCE(?, image)", image)).
 		Set("price", sq.Expr("COALESCE(?, price)", price)).
+		Set("cost", sq.Expr("COALESCE(?, cost)", cost)).
 		Set("stock", sq.Expr("COALESCE(?, stock)", stock)).
 		Set("updated_at", time.Now()).
 		Where(sq.Eq{"id": product.ID}).
-		Suffix("RETURNING *")
+		Suffix("RETURNING *, " + productDayPartIDs + ", " + productBundleSlots)
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = pr.db.QueryRow(ctx, sql, args...).Scan(
+	err = tx.QueryRow(ctx, sql, args...).Scan(
 		&product.ID,
 		&product.CategoryID,
 		&product.SKU,
@@ -175,18 +498,91 @@ func (pr *ProductRepository) UpdateProduct(ctx context.Context, product *domain.
 		&product.Image,
 		&product.CreatedAt,
 		&product.UpdatedAt,
+		&product.Cost,
+		&product.DeletedAt,
+		&product.UnavailableReason,
+		&product.UnavailableUntil,
+		&product.Type,
+		&product.DayPartIDs,
+		&product.Slots,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return product, nil
 }
 
-// DeleteProduct deletes a product record from the database by id
+// UpsertProducts inserts the products of the import rows, or updates them when their SKU already exists,
+// inside a single transaction. In dry run mode the transaction is rolled back after all rows are written
+func (pr *ProductRepository) UpsertProducts(ctx context.Context, rows []domain.ProductImportRow, dryRun bool) error {
+	tx, err := pr.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for i := range rows {
+		product := &rows[i].Product
+
+		query := pr.db.QueryBuilder.Insert("products").
+			Columns("category_id", "sku", "name", "image", "price", "stock").
+			Values(product.CategoryID, product.SKU, product.Name, product.Image, product.Price, product.Stock).
+			Suffix(`ON CONFLICT ("sku") DO UPDATE SET
+				category_id = EXCLUDED.category_id,
+				name = EXCLUDED.name,
+				image = EXCLUDED.image,
+				price = EXCLUDED.price,
+				stock = EXCLUDED.stock,
+				updated_at = now()
+			RETURNING *, ` + productDayPartIDs + `, ` + productBundleSlots + `, (xmax = 0)`)
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, sql, args...).Scan(
+			&product.ID,
+			&product.CategoryID,
+			&product.SKU,
+			&product.Name,
+			&product.Stock,
+			&product.Price,
+			&product.Image,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&product.Cost,
+			&product.DeletedAt,
+			&product.UnavailableReason,
+			&product.UnavailableUntil,
+			&product.Type,
+			&product.DayPartIDs,
+			&product.Slots,
+			&rows[i].Created,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DeleteProduct soft deletes a product record in the database by id, so the orders it is part of keep referring to it
 func (pr *ProductRepository) DeleteProduct(ctx context.Context, id uint64) error {
-	query := pr.db.QueryBuilder.Delete("products").
-		Where(sq.Eq{"id": id})
+	query := pr.db.QueryBuilder.Update("products").
+		Set("deleted_at", time.Now()).
+		Where(sq.Eq{"id": id, "deleted_at": nil})
 
 	sql, args, err := query.ToSql()
 	if err != nil {
@@ -200,3 +596,240 @@ func (pr *ProductRepository) DeleteProduct(ctx context.Context, id uint64) error
 
 	return nil
 }
+
+// IsProductAvailable checks in the database if a product can be sold at a time, given in the store's timezone
+func (pr *ProductRepository) IsProductAvailable(ctx context.Context, id uint64, at time.Time) (bool, error) {
+	var available bool
+
+	query := pr.db.QueryBuilder.Select().
+		Column(availableIn(at)).
+		From("products").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	err = pr.db.QueryRow(ctx, sql, args...).Scan(&available)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, cmdomain.ErrDataNotFound
+		}
+		return false, err
+	}
+
+	return available, nil
+}
+
+// SetProductAvailability 86es a product record that is not deleted in the database by id with a reason
+// until a time, or puts it back on sale when until is nil
+func (pr *ProductRepository) SetProductAvailability(ctx context.Context, id uint64, reason string, until *time.Time) (*domain.Product, error) {
+	var product domain.Product
+
+	query := pr.db.QueryBuilder.Update("products").
+		Set("unavailable_reason", reason).
+		Set("unavailable_until", until).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": id, "deleted_at": nil}).
+		Suffix("RETURNING *, " + productDayPartIDs + ", " + productBundleSlots)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = pr.db.QueryRow(ctx, sql, args...).Scan(
+		&product.ID,
+		&product.CategoryID,
+		&product.SKU,
+		&product.Name,
+		&product.Stock,
+		&product.Price,
+		&product.Image,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+		&product.Cost,
+		&product.DeletedAt,
+		&product.UnavailableReason,
+		&product.UnavailableUntil,
+		&product.Type,
+		&product.DayPartIDs,
+		&product.Slots,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+// ResetProductAvailability puts the products whose 86 expired at a time back on sale in the database
+// and returns them as they were before
+func (pr *ProductRepository) ResetProductAvailability(ctx context.Context, at time.Time) ([]domain.Product, error) {
+	var product domain.Product
+	var products []domain.Product
+
+	query := pr.db.QueryBuilder.Update("products").
+		Set("unavailable_reason", "").
+		Set("unavailable_until", nil).
+		From("products AS expired").
+		Where(sq.And{sq.Expr("expired.id = products.id"), sq.LtOrEq{"products.unavailable_until": at}}).
+		Suffix("RETURNING products.*, " + productDayPartIDs + ", " + productBundleSlots + ", expired.unavailable_reason, expired.unavailable_until")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reason string
+		var until *time.Time
+
+		err := rows.Scan(
+			&product.ID,
+			&product.CategoryID,
+			&product.SKU,
+			&product.Name,
+			&product.Stock,
+			&product.Price,
+			&product.Image,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&product.Cost,
+			&product.DeletedAt,
+			&product.UnavailableReason,
+			&product.UnavailableUntil,
+			&product.Type,
+			&product.DayPartIDs,
+			&product.Slots,
+			&reason,
+			&until,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		product.UnavailableReason = reason
+		product.UnavailableUntil = until
+
+		products = append(products, product)
+	}
+
+	return products, rows.Err()
+}
+
+// RestoreProduct restores a soft deleted product record in the database by id
+func (pr *ProductRepository) RestoreProduct(ctx context.Context, id uint64) (*domain.Product, error) {
+	var product domain.Product
+
+	query := pr.db.QueryBuilder.Update("products").
+		Set("deleted_at", nil).
+		Where(sq.And{sq.Eq{"id": id}, sq.NotEq{"deleted_at": nil}}).
+		Suffix("RETURNING *, " + productDayPartIDs + ", " + productBundleSlots)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = pr.db.QueryRow(ctx, sql, args...).Scan(
+		&product.ID,
+		&product.CategoryID,
+		&product.SKU,
+		&product.Name,
+		&product.Stock,
+		&product.Price,
+		&product.Image,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+		&product.Cost,
+		&product.DeletedAt,
+		&product.UnavailableReason,
+		&product.UnavailableUntil,
+		&product.Type,
+		&product.DayPartIDs,
+		&product.Slots,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+// filterProducts applies the product list filters to a query on the products table, leaving out deleted products,
+// unless channel is empty the products that do not appear in it and, unless availableAt is zero, the products
+// that cannot be sold at that time. Filtering by a category includes the products of its subcategories at any depth
+func filterProducts(query sq.SelectBuilder, search string, categoryId uint64, channel chdomain.Channel, availableAt time.Time) sq.SelectBuilder {
+	query = query.Where(sq.Eq{"products.deleted_at": nil})
+
+	if channel != "" {
+		query = query.Where(onMenuOf(channel))
+	}
+
+	if !availableAt.IsZero() {
+		query = query.Where(availableIn(availableAt))
+	}
+
+	if categoryId != 0 {
+		query = query.Where(sq.Expr(`products.category_id IN (
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM categories WHERE id = ?
+				UNION
+				SELECT categories.id FROM categories JOIN subtree ON categories.parent_id = subtree.id
+			)
+			SELECT id FROM subtree
+		)`, categoryId))
+	}
+
+	if search != "" {
+		query = query.Where(sq.ILike{"products.name": "%" + search + "%"})
+	}
+
+	return query
+}
+
+// onMenuOf builds a condition on the products table that holds for the products that appear in a channel,
+// which are the ones on its menu or every product when it has none
+func onMenuOf(channel chdomain.Channel) sq.Sqlizer {
+	return sq.Expr(`(NOT EXISTS (SELECT 1 FROM menus WHERE channel = ?) OR EXISTS (
+		SELECT 1 FROM menu_products
+		JOIN menus ON menus.id = menu_products.menu_id
+		WHERE menus.channel = ? AND menu_products.product_id = products.id))`, channel, channel)
+}
+
+// availableIn builds a condition on the products table that holds for the products that can be sold at a time,
+// given in the store's timezone. A product 86'd at that time cannot be sold. Otherwise a product restricted to
+// day-parts can only be sold within their windows on the time's weekday, a product that is not follows the
+// day-parts of its category, and one without either is always available
+func availableIn(at time.Time) sq.Sqlizer {
+	weekday := int(at.Weekday())
+	minute := at.Hour()*60 + at.Minute()
+
+	return sq.Expr(`(products.unavailable_until IS NULL OR products.unavailable_until <= ?) AND CASE
+		WHEN EXISTS (SELECT 1 FROM product_day_parts WHERE product_id = products.id) THEN EXISTS (
+			SELECT 1 FROM product_day_parts
+			JOIN day_part_windows ON day_part_windows.day_part_id = product_day_parts.day_part_id
+			WHERE product_day_parts.product_id = products.id
+			AND day_part_windows.weekday = ? AND day_part_windows.start_minute <= ? AND day_part_windows.end_minute > ?)
+		WHEN EXISTS (SELECT 1 FROM category_day_parts WHERE category_id = products.category_id) THEN EXISTS (
+			SELECT 1 FROM category_day_parts
+			JOIN day_part_windows ON day_part_windows.day_part_id = category_day_parts.day_part_id
+			WHERE category_day_parts.category_id = products.category_id
+			AND day_part_windows.weekday = ? AND day_part_windows.start_minute <= ? AND day_part_windows.end_minute > ?)
+		ELSE TRUE
+	END`, at, weekday, minute, minute, weekday, minute, minute)
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"slices"
+)
+
+// ProductType is an enum for the type of a product
+type ProductType string
+
+// ProductType enum values
+const (
+	Single ProductType = "single"
+	Bundle ProductType = "bundle"
+)
+
+// BundleSlot is an entity that represents a slot of a bundle, such as the drink of a combo meal,
+// filled with Quantity of one of the products in ProductIDs. The first product is the default choice
+type BundleSlot struct {
+	ID         uint64
+	Name       string
+	Quantity   int64
+	ProductIDs []uint64
+}
+
+// HasChoice checks if a product can be chosen to fill the slot
+func (s *BundleSlot) HasChoice(productID uint64) bool {
+	return slices.Contains(s.ProductIDs, productID)
+}
+
+// DefaultChoice returns the product the slot is filled with when no other product is chosen,
+// or false if the slot has no choices left
+func (s *BundleSlot) DefaultChoice() (uint64, bool) {
+	if len(s.ProductIDs) == 0 {
+		return 0, false
+	}
+
+	return s.ProductIDs[0], true
+}
+
+// SameAs checks if the slot has the same name, quantity and choices as another slot, ignoring their ids
+func (s *BundleSlot) SameAs(other BundleSlot) bool {
+	return s.Name == other.Name &&
+		s.Quantity == other.Quantity &&
+		slices.Equal(s.ProductIDs, other.ProductIDs)
+}
@@ -0,0 +1,18 @@
+package domain
+
+// ProductImportRow is a value object that represents a single row of a product import file
+type ProductImportRow struct {
+	Line    int
+	Product Product
+	Created bool
+	Errors  []string
+}
+
+// ProductImport is a value object that represents the outcome of a product import
+type ProductImport struct {
+	DryRun  bool
+	Rows    []ProductImportRow
+	Created int
+	Updated int
+	Failed  int
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"go-restaurant/internal/category/domain"
+)
+
+// MenuSection is a value object that represents a category of the menu
+// with its products and the sections of its subcategories
+type MenuSection struct {
+	Category    domain.Category
+	Products    []Product
+	Subsections []MenuSection
+}
+
+// NewMenu builds the menu tree from categories and products that are already in display order.
+// A category whose parent is not among the categories is shown at the top level
+func NewMenu(categories []domain.Category, products []Product) []MenuSection {
+	known := make(map[uint64]bool, len(categories))
+	for _, category := range categories {
+		known[category.ID] = true
+	}
+
+	children := make(map[uint64][]domain.Category)
+	for _, category := range categories {
+		var parentID uint64
+		if category.ParentID != nil && known[*category.ParentID] {
+			parentID = *category.ParentID
+		}
+
+		children[parentID] = append(children[parentID], category)
+	}
+
+	productsByCategory := make(map[uint64][]Product)
+	for _, product := range products {
+		productsByCategory[product.CategoryID] = append(productsByCategory[product.CategoryID], product)
+	}
+
+	return newMenuSections(0, children, productsByCategory)
+}
+
+// newMenuSections builds the sections of the subcategories of a parent, where 0 is the top level
+func newMenuSections(parentID uint64, children map[uint64][]domain.Category, products map[uint64][]Product) []MenuSection {
+	var sections []MenuSection
+
+	for _, category := range children[parentID] {
+		category := category
+
+		sectionProducts := products[category.ID]
+		for i := range sectionProducts {
+			sectionProducts[i].Category = &category
+		}
+
+		sections = append(sections, MenuSection{
+			Category:    category,
+			Products:    sectionProducts,
+			Subsections: newMenuSections(category.ID, children, products),
+		})
+	}
+
+	return sections
+}
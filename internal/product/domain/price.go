@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// ProductPrice is an entity that represents a price of a product, which is in effect
+// from its effective time until the next price of the product takes effect
+type ProductPrice struct {
+	ID            uint64
+	ProductID     uint64
+	Price         float64
+	EffectiveFrom time.Time
+	CreatedAt     time.Time
+}
+
+// IsScheduled checks if the price takes effect after a time
+func (pp *ProductPrice) IsScheduled(now time.Time) bool {
+	return pp.EffectiveFrom.After(now)
+}
@@ -6,16 +6,36 @@ import (
 	"time"
 )
 
-// Product is an entity that represents a product
+// Product is an entity that represents a product. A product restricted to day-parts can only be sold
+// within their windows, otherwise it follows the day-parts of its category. The kitchen can 86 a product
+// when it runs out, which takes it off sale with a reason until UnavailableUntil, regardless of its stock.
+// A bundle is sold as a unit at its own price and is made of one product chosen from each of its slots
 type Product struct {
-	ID         uint64
-	CategoryID uint64
-	SKU        uuid.UUID
-	Name       string
-	Stock      int64
-	Price      float64
-	Image      string
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	Category   *domain.Category
+	ID                uint64
+	CategoryID        uint64
+	SKU               uuid.UUID
+	Name              string
+	Stock             int64
+	Price             float64
+	Cost              float64
+	Image             string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	DeletedAt         *time.Time
+	UnavailableReason string
+	UnavailableUntil  *time.Time
+	Type              ProductType
+	DayPartIDs        []uint64
+	Slots             []BundleSlot
+	Category          *domain.Category
+}
+
+// IsEightySixed checks if the product has been 86'd by the kitchen at a time
+func (p *Product) IsEightySixed(at time.Time) bool {
+	return p.UnavailableUntil != nil && at.Before(*p.UnavailableUntil)
+}
+
+// IsBundle checks if the product is a bundle of other products
+func (p *Product) IsBundle() bool {
+	return p.Type == Bundle
 }
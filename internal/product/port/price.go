@@ -0,0 +1,38 @@
+package port
+
+import (
+	"context"
+	"go-restaurant/internal/product/domain"
+	"time"
+)
+
+//go:generate mockgen -source=price.go -destination=mock/price.go -package=mock
+
+// ProductPriceRepository is an interface for interacting with product price-related data
+type ProductPriceRepository interface {
+	// CreateProductPrice inserts a new product price into the database
+	CreateProductPrice(ctx context.Context, price *domain.ProductPrice) (*domain.ProductPrice, error)
+	// GetProductPriceByID selects a product price by id
+	GetProductPriceByID(ctx context.Context, id uint64) (*domain.ProductPrice, error)
+	// GetEffectiveProductPrice selects the price of a product in effect at a time
+	GetEffectiveProductPrice(ctx context.Context, productID uint64, at time.Time) (*domain.ProductPrice, error)
+	// ListProductPrices selects a list of the prices of a product with pagination
+	ListProductPrices(ctx context.Context, productID, skip, limit uint64) ([]domain.ProductPrice, error)
+	// ApplyProductPrices sets the price of every product to its price in effect at a time
+	// and returns the products whose price changed as they were before
+	ApplyProductPrices(ctx context.Context, at time.Time) ([]domain.Product, error)
+	// DeleteProductPrice deletes a product price
+	DeleteProductPrice(ctx context.Context, id uint64) error
+}
+
+// ProductPriceService is an interface for interacting with product price-related business logic
+type ProductPriceService interface {
+	// ListProductPrices returns a list of the past, current and scheduled prices of a product with pagination
+	ListProductPrices(ctx context.Context, productID, skip, limit uint64) ([]domain.ProductPrice, error)
+	// ScheduleProductPrice schedules a price change of a product
+	ScheduleProductPrice(ctx context.Context, price *domain.ProductPrice) (*domain.ProductPrice, error)
+	// CancelProductPrice cancels a scheduled price change of a product
+	CancelProductPrice(ctx context.Context, productID, id uint64) error
+	// ApplyProductPrices applies the scheduled price changes that took effect
+	ApplyProductPrices(ctx context.Context) error
+}
@@ -2,7 +2,12 @@ package port
 
 import (
 	"context"
+	"github.com/google/uuid"
+	chdomain "go-restaurant/internal/channel/domain"
+	imgdomain "go-restaurant/internal/image/domain"
 	"go-restaurant/internal/product/domain"
+	"io"
+	"time"
 )
 
 //go:generate mockgen -source=product.go -destination=mock/product.go -package=mock
@@ -13,12 +18,32 @@ type ProductRepository interface {
 	CreateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error)
 	// GetProductByID selects a product by id
 	GetProductByID(ctx context.Context, id uint64) (*domain.Product, error)
-	// ListProducts selects a list of products with pagination
-	ListProducts(ctx context.Context, search string, categoryId, skip, limit uint64) ([]domain.Product, error)
+	// GetProductByIDIncludingDeleted selects a product by id, even if it is deleted
+	GetProductByIDIncludingDeleted(ctx context.Context, id uint64) (*domain.Product, error)
+	// GetProductBySKU selects a product by SKU
+	GetProductBySKU(ctx context.Context, sku uuid.UUID) (*domain.Product, error)
+	// ListProducts selects a list of products with pagination, of a category including its subcategories,
+	// only the ones on the menu of a channel unless it is empty and available at a time unless it is zero
+	ListProducts(ctx context.Context, search string, categoryId uint64, channel chdomain.Channel, availableAt time.Time, skip, limit uint64) ([]domain.Product, error)
+	// ListMenuProducts selects all products by name, only the ones available at a time unless it is zero
+	ListMenuProducts(ctx context.Context, channel chdomain.Channel, availableAt time.Time) ([]domain.Product, error)
+	// StreamProducts selects all products matching the list filters one by one
+	StreamProducts(ctx context.Context, search string, categoryId uint64, fn func(product *domain.Product) error) error
+	// IsProductAvailable checks if a product can be sold at a time
+	IsProductAvailable(ctx context.Context, id uint64, at time.Time) (bool, error)
 	// UpdateProduct updates a product
 	UpdateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error)
-	// DeleteProduct deletes a product
+	// SetProductAvailability 86es a product until a time, or puts it back on sale when until is nil
+	SetProductAvailability(ctx context.Context, id uint64, reason string, until *time.Time) (*domain.Product, error)
+	// ResetProductAvailability puts the products whose 86 expired at a time back on sale
+	// and returns them as they were before
+	ResetProductAvailability(ctx context.Context, at time.Time) ([]domain.Product, error)
+	// UpsertProducts inserts or updates by SKU the products of the import rows in a single transaction
+	UpsertProducts(ctx context.Context, rows []domain.ProductImportRow, dryRun bool) error
+	// DeleteProduct soft deletes a product
 	DeleteProduct(ctx context.Context, id uint64) error
+	// RestoreProduct restores a soft deleted product
+	RestoreProduct(ctx context.Context, id uint64) (*domain.Product, error)
 }
 
 // ProductService is an interface for interacting with product-related business logic
@@ -27,10 +52,24 @@ type ProductService interface {
 	CreateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error)
 	// GetProduct returns a product by id
 	GetProduct(ctx context.Context, id uint64) (*domain.Product, error)
-	// ListProducts returns a list of products with pagination
-	ListProducts(ctx context.Context, search string, categoryId, skip, limit uint64) ([]domain.Product, error)
+	// ListProducts returns a list of products with pagination, of a category including its subcategories,
+	// only the ones available now if asked to. Given a channel, only the products on its menu are listed
+	// at the prices of its price list
+	ListProducts(ctx context.Context, search string, categoryId uint64, channel chdomain.Channel, availableNow bool, skip, limit uint64) ([]domain.Product, error)
+	// GetMenu returns the menu as a tree of categories with their products, only the ones available now if asked to
+	GetMenu(ctx context.Context, channel chdomain.Channel, availableNow bool) ([]domain.MenuSection, error)
+	// ExportProducts passes all products matching the list filters one by one to fn
+	ExportProducts(ctx context.Context, search string, categoryId uint64, fn func(product *domain.Product) error) error
 	// UpdateProduct updates a product
 	UpdateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error)
-	// DeleteProduct deletes a product
+	// UploadProductImage stores an uploaded image of a product and makes it the product's image
+	UploadProductImage(ctx context.Context, id uint64, image io.Reader) (*imgdomain.Image, error)
+	// SetProductAvailability 86es a product with a reason until the end of the business day, or puts it back on sale
+	SetProductAvailability(ctx context.Context, id uint64, available bool, reason string) (*domain.Product, error)
+	// DeleteProduct soft deletes a product
 	DeleteProduct(ctx context.Context, id uint64) error
+	// RestoreProduct restores a soft deleted product
+	RestoreProduct(ctx context.Context, id uint64) (*domain.Product, error)
+	// ImportProducts validates the import rows and creates or updates their products by SKU
+	ImportProducts(ctx context.Context, rows []domain.ProductImportRow, dryRun bool) (*domain.ProductImport, error)
 }
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	auditdomain "go-restaurant/internal/audit/domain"
+	auditport "go-restaurant/internal/audit/port"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
+	cmutil "go-restaurant/internal/common/util"
+	"go-restaurant/internal/product/domain"
+	"go-restaurant/internal/product/port"
+	"log/slog"
+	"time"
+)
+
+// priceSchedulerInterval is how often scheduled price changes that took effect are applied to the products
+const priceSchedulerInterval = time.Minute
+
+/*ProductPriceService implements port.ProductPriceService interface
+ * and provides access to the product and product price repositories,
+ * transactor, cache service and audit service
+ */
+type ProductPriceService struct {
+	productRepo port.ProductRepository
+	priceRepo   port.ProductPriceRepository
+	transactor  cmport.Transactor
+	cache       cmport.CacheRepository
+	audit       auditport.AuditService
+}
+
+// NewProductPriceService creates a new product price service instance
+func NewProductPriceService(productRepo port.ProductRepository, priceRepo port.ProductPriceRepository, transactor cmport.Transactor, cache cmport.CacheRepository, audit auditport.AuditService) *ProductPriceService {
+	return &ProductPriceService{
+		productRepo,
+		priceRepo,
+		transactor,
+		cache,
+		audit,
+	}
+}
+
+// ListProductPrices lists the past, current and scheduled prices of a product, latest first
+func (ps *ProductPriceService) ListProductPrices(ctx context.Context, productID, skip, limit uint64) ([]domain.ProductPrice, error) {
+	_, err := ps.productRepo.GetProductByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ps.priceRepo.ListProductPrices(ctx, productID, skip, limit)
+}
+
+// ScheduleProductPrice schedules a price change of a product, which takes effect
+// for orders created from its effective time on
+func (ps *ProductPriceService) ScheduleProductPrice(ctx context.Context, price *domain.ProductPrice) (*domain.ProductPrice, error) {
+	if !price.IsScheduled(time.Now()) {
+		return nil, cmdomain.ErrPriceChangeNotInFuture
+	}
+
+	_, err := ps.productRepo.GetProductByID(ctx, price.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		price, err = ps.priceRepo.CreateProductPrice(ctx, price)
+		if err != nil {
+			return err
+		}
+
+		return ps.audit.Record(ctx, auditdomain.ActionCreate, auditdomain.EntityProductPrice, price.ID, nil, price)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return price, nil
+}
+
+// CancelProductPrice cancels a price change of a product that has not taken effect yet
+func (ps *ProductPriceService) CancelProductPrice(ctx context.Context, productID, id uint64) error {
+	price, err := ps.priceRepo.GetProductPriceByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if price.ProductID != productID {
+		return cmdomain.ErrDataNotFound
+	}
+
+	if !price.IsScheduled(time.Now()) {
+		return cmdomain.ErrPriceChangeInEffect
+	}
+
+	return ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := ps.priceRepo.DeleteProductPrice(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return ps.audit.Record(ctx, auditdomain.ActionDelete, auditdomain.EntityProductPrice, id, price, nil)
+	})
+}
+
+// ApplyProductPrices applies the scheduled price changes that took effect to the products, audits them
+// and invalidates the cached products whose price changed
+func (ps *ProductPriceService) ApplyProductPrices(ctx context.Context) error {
+	var productIDs []uint64
+	err := ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		existingProducts, err := ps.priceRepo.ApplyProductPrices(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+
+		for _, existingProduct := range existingProducts {
+			product, err := ps.productRepo.GetProductByIDIncludingDeleted(ctx, existingProduct.ID)
+			if err != nil {
+				return err
+			}
+
+			err = ps.audit.Record(ctx, auditdomain.ActionUpdate, auditdomain.EntityProduct, product.ID, auditProduct(&existingProduct), auditProduct(product))
+			if err != nil {
+				return err
+			}
+
+			productIDs = append(productIDs, product.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	for _, productID := range productIDs {
+		cacheKey := cmutil.GenerateCacheKey("product", productID)
+		_ = ps.cache.Delete(ctx, cacheKey)
+	}
+
+	return ps.cache.DeleteByPrefix(ctx, "products:*")
+}
+
+// SchedulePrices applies the scheduled price changes that took effect on a schedule until the context is done
+func (ps *ProductPriceService) SchedulePrices(ctx context.Context) {
+	ticker := time.NewTicker(priceSchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		err := ps.ApplyProductPrices(ctx)
+		if err != nil {
+			slog.Error("Error applying scheduled product prices", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
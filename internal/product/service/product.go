@@ -2,35 +2,74 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	auditdomain "go-restaurant/internal/audit/domain"
+	auditport "go-restaurant/internal/audit/port"
+	cadomain "go-restaurant/internal/category/domain"
 	caport "go-restaurant/internal/category/port"
+	chdomain "go-restaurant/internal/channel/domain"
+	chport "go-restaurant/internal/channel/port"
 	cmdomain "go-restaurant/internal/common/domain"
 	cmport "go-restaurant/internal/common/port"
 	cmutil "go-restaurant/internal/common/util"
+	imgdomain "go-restaurant/internal/image/domain"
+	imgport "go-restaurant/internal/image/port"
 	"go-restaurant/internal/product/domain"
 	"go-restaurant/internal/product/port"
+	"io"
+	"log/slog"
+	"slices"
+	"time"
 )
 
+// availabilityResetInterval is how often the products whose 86 expired are put back on sale
+const availabilityResetInterval = time.Minute
+
 /*ProductService implements port.ProductService and port.CategoryService
- * interfaces and provides access to the product and category repositories
- * and cache service
+ * interfaces and provides access to the product, product price, category
+ * and price list repositories, transactor, image service, cache service,
+ * audit service and the store's timezone
  */
 type ProductService struct {
-	productRepo  port.ProductRepository
-	categoryRepo caport.CategoryRepository
-	cache        cmport.CacheRepository
+	productRepo   port.ProductRepository
+	priceRepo     port.ProductPriceRepository
+	categoryRepo  caport.CategoryRepository
+	priceListRepo chport.PriceListRepository
+	transactor    cmport.Transactor
+	images        imgport.ImageService
+	cache         cmport.CacheRepository
+	audit         auditport.AuditService
+	store         *cmdomain.Store
 }
 
 // NewProductService creates a new product service instance
-func NewProductService(productRepo port.ProductRepository, categoryRepo caport.CategoryRepository, cache cmport.CacheRepository) *ProductService {
+func NewProductService(productRepo port.ProductRepository, priceRepo port.ProductPriceRepository, categoryRepo caport.CategoryRepository, priceListRepo chport.PriceListRepository, transactor cmport.Transactor, images imgport.ImageService, cache cmport.CacheRepository, audit auditport.AuditService, store *cmdomain.Store) *ProductService {
 	return &ProductService{
 		productRepo,
+		priceRepo,
 		categoryRepo,
+		priceListRepo,
+		transactor,
+		images,
 		cache,
+		audit,
+		store,
 	}
 }
 
-// CreateProduct creates a new product
+// CreateProduct creates a new product, which is a single product unless it is a bundle with slots
 func (ps *ProductService) CreateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	if product.Type == "" {
+		product.Type = domain.Single
+	}
+
+	err := ps.validateBundle(ctx, product)
+	if err != nil {
+		return nil, err
+	}
+
 	category, err := ps.categoryRepo.GetCategoryByID(ctx, product.CategoryID)
 	if err != nil {
 		return nil, err
@@ -38,7 +77,19 @@ func (ps *ProductService) CreateProduct(ctx context.Context, product *domain.Pro
 
 	product.Category = category
 
-	_, err = ps.productRepo.CreateProduct(ctx, product)
+	err = ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := ps.productRepo.CreateProduct(ctx, product)
+		if err != nil {
+			return err
+		}
+
+		err = ps.recordPrice(ctx, product.ID, product.Price, product.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		return ps.audit.Record(ctx, auditdomain.ActionCreate, auditdomain.EntityProduct, product.ID, nil, auditProduct(product))
+	})
 	if err != nil {
 		if cmdomain.IsUniqueConstraintViolationError(err) {
 			return nil, cmdomain.ErrConflictingData
@@ -86,7 +137,7 @@ func (ps *ProductService) GetProduct(ctx context.Context, id uint64) (*domain.Pr
 		return nil, err
 	}
 
-	category, err := ps.categoryRepo.GetCategoryByID(ctx, product.CategoryID)
+	category, err := ps.categoryRepo.GetCategoryByIDIncludingDeleted(ctx, product.CategoryID)
 	if err != nil {
 		return nil, err
 	}
@@ -106,11 +157,32 @@ func (ps *ProductService) GetProduct(ctx context.Context, id uint64) (*domain.Pr
 	return product, nil
 }
 
-// ListProducts retrieves a list of products
-func (ps *ProductService) ListProducts(ctx context.Context, search string, categoryId, skip, limit uint64) ([]domain.Product, error) {
+// ListProducts retrieves a list of products. Given a channel, only the products on its menu are listed
+// at the prices of its price list. Products available now are not cached, since which products are
+// available changes with the time of day
+func (ps *ProductService) ListProducts(ctx context.Context, search string, categoryId uint64, channel chdomain.Channel, availableNow bool, skip, limit uint64) ([]domain.Product, error) {
 	var products []domain.Product
 
-	params := cmutil.GenerateCacheKeyParams(skip, limit, categoryId, search)
+	if availableNow {
+		products, err := ps.productRepo.ListProducts(ctx, search, categoryId, channel, time.Now().In(ps.store.Location), skip, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		err = ps.resolveCategories(ctx, products)
+		if err != nil {
+			return nil, err
+		}
+
+		err = ps.applyPriceList(ctx, channel, products)
+		if err != nil {
+			return nil, err
+		}
+
+		return products, nil
+	}
+
+	params := cmutil.GenerateCacheKeyParams(skip, limit, categoryId, search, channel)
 	cacheKey := cmutil.GenerateCacheKey("products", params)
 
 	cachedProducts, err := ps.cache.Get(ctx, cacheKey)
@@ -123,18 +195,19 @@ func (ps *ProductService) ListProducts(ctx context.Context, search string, categ
 		return products, nil
 	}
 
-	products, err = ps.productRepo.ListProducts(ctx, search, categoryId, skip, limit)
+	products, err = ps.productRepo.ListProducts(ctx, search, categoryId, channel, time.Time{}, skip, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	for i, product := range products {
-		category, err := ps.categoryRepo.GetCategoryByID(ctx, product.CategoryID)
-		if err != nil {
-			return nil, err
-		}
+	err = ps.resolveCategories(ctx, products)
+	if err != nil {
+		return nil, err
+	}
 
-		products[i].Category = category
+	err = ps.applyPriceList(ctx, channel, products)
+	if err != nil {
+		return nil, err
 	}
 
 	productsSerialized, err := cmutil.Serialize(products)
@@ -150,7 +223,95 @@ func (ps *ProductService) ListProducts(ctx context.Context, search string, categ
 	return products, nil
 }
 
-// UpdateProduct updates a product
+// applyPriceList overrides the prices of the products with the ones of the price list of a channel,
+// leaving them as they are when the channel is empty or has no price list
+func (ps *ProductService) applyPriceList(ctx context.Context, channel chdomain.Channel, products []domain.Product) error {
+	if channel == "" {
+		return nil
+	}
+
+	priceList, err := ps.priceListRepo.GetPriceListByChannel(ctx, channel)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	for i, product := range products {
+		if price, ok := priceList.PriceOf(product.ID); ok {
+			products[i].Price = price
+		}
+	}
+
+	return nil
+}
+
+// GetMenu returns the menu as a tree of the categories that are not deleted in display order, each with
+// its products by name and the sections of its subcategories, only with the products available now if asked to.
+// Given a channel, only the products on its menu are included at the prices of its price list
+func (ps *ProductService) GetMenu(ctx context.Context, channel chdomain.Channel, availableNow bool) ([]domain.MenuSection, error) {
+	var menu []domain.MenuSection
+
+	var availableAt time.Time
+	if availableNow {
+		availableAt = time.Now().In(ps.store.Location)
+	}
+
+	params := cmutil.GenerateCacheKeyParams("menu", channel)
+	cacheKey := cmutil.GenerateCacheKey("products", params)
+	if !availableNow {
+		cachedMenu, err := ps.cache.Get(ctx, cacheKey)
+		if err == nil {
+			err := cmutil.Deserialize(cachedMenu, &menu)
+			if err != nil {
+				return nil, err
+			}
+
+			return menu, nil
+		}
+	}
+
+	categories, err := ps.categoryRepo.ListAllCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := ps.productRepo.ListMenuProducts(ctx, channel, availableAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ps.applyPriceList(ctx, channel, products)
+	if err != nil {
+		return nil, err
+	}
+
+	menu = domain.NewMenu(categories, products)
+
+	if availableNow {
+		return menu, nil
+	}
+
+	menuSerialized, err := cmutil.Serialize(menu)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, menuSerialized, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return menu, nil
+}
+
+// ExportProducts streams all products matching the list filters to fn, bypassing the cache
+func (ps *ProductService) ExportProducts(ctx context.Context, search string, categoryId uint64, fn func(product *domain.Product) error) error {
+	return ps.productRepo.StreamProducts(ctx, search, categoryId, fn)
+}
+
+// UpdateProduct updates a product and, if given, replaces the slots of a bundle. The type of a product cannot change
 func (ps *ProductService) UpdateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
 	existingProduct, err := ps.productRepo.GetProductByID(ctx, product.ID)
 	if err != nil {
@@ -161,16 +322,31 @@ func (ps *ProductService) UpdateProduct(ctx context.Context, product *domain.Pro
 		product.Name == "" &&
 		product.Image == "" &&
 		product.Price == 0 &&
-		product.Stock == 0
+		product.Cost == 0 &&
+		product.Stock == 0 &&
+		product.DayPartIDs == nil &&
+		product.Slots == nil
 	sameData := existingProduct.CategoryID == product.CategoryID &&
 		existingProduct.Name == product.Name &&
 		existingProduct.Image == product.Image &&
 		existingProduct.Price == product.Price &&
-		existingProduct.Stock == product.Stock
+		existingProduct.Cost == product.Cost &&
+		existingProduct.Stock == product.Stock &&
+		(product.DayPartIDs == nil || slices.Equal(existingProduct.DayPartIDs, product.DayPartIDs)) &&
+		(product.Slots == nil || slices.EqualFunc(existingProduct.Slots, product.Slots, func(a, b domain.BundleSlot) bool { return a.SameAs(b) }))
 	if emptyData || sameData {
 		return nil, cmdomain.ErrNoUpdatedData
 	}
 
+	product.Type = existingProduct.Type
+
+	if product.Slots != nil {
+		err = ps.validateBundle(ctx, product)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if product.CategoryID == 0 {
 		product.CategoryID = existingProduct.CategoryID
 	}
@@ -182,7 +358,21 @@ func (ps *ProductService) UpdateProduct(ctx context.Context, product *domain.Pro
 
 	product.Category = category
 
-	_, err = ps.productRepo.UpdateProduct(ctx, product)
+	err = ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := ps.productRepo.UpdateProduct(ctx, product)
+		if err != nil {
+			return err
+		}
+
+		if product.Price != existingProduct.Price {
+			err = ps.recordPrice(ctx, product.ID, product.Price, product.UpdatedAt)
+			if err != nil {
+				return err
+			}
+		}
+
+		return ps.audit.Record(ctx, auditdomain.ActionUpdate, auditdomain.EntityProduct, product.ID, auditProduct(existingProduct), auditProduct(product))
+	})
 	if err != nil {
 		if cmdomain.IsUniqueConstraintViolationError(err) {
 			return nil, cmdomain.ErrConflictingData
@@ -212,9 +402,275 @@ func (ps *ProductService) UpdateProduct(ctx context.Context, product *domain.Pro
 	return product, nil
 }
 
-// DeleteProduct deletes a product
+// ImportProducts resolves the category of every import row by name and, when all rows are valid,
+// creates or updates their products by SKU in a single transaction. Nothing is written when any row
+// has errors or in dry run mode, but the returned import tells which products would be created or updated.
+// Deleted products have to be restored before they can be imported again
+func (ps *ProductService) ImportProducts(ctx context.Context, rows []domain.ProductImportRow, dryRun bool) (*domain.ProductImport, error) {
+	categories := make(map[string]*cadomain.Category)
+	existingProducts := make(map[uuid.UUID]*domain.Product)
+
+	for i := range rows {
+		row := &rows[i]
+		name := row.Product.Category.Name
+
+		category, ok := categories[name]
+		if !ok {
+			var err error
+
+			category, err = ps.categoryRepo.GetCategoryByName(ctx, name)
+			if err != nil && !errors.Is(err, cmdomain.ErrDataNotFound) {
+				return nil, err
+			}
+
+			categories[name] = category
+		}
+
+		if category == nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("category %q not found", name))
+			continue
+		}
+
+		row.Product.CategoryID = category.ID
+		row.Product.Category = category
+
+		if row.Product.SKU == uuid.Nil {
+			row.Product.SKU = uuid.New()
+			continue
+		}
+
+		existingProduct, err := ps.productRepo.GetProductBySKU(ctx, row.Product.SKU)
+		if err != nil {
+			if errors.Is(err, cmdomain.ErrDataNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		if existingProduct.DeletedAt != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("product %s is deleted", row.Product.SKU))
+			continue
+		}
+
+		existingProducts[row.Product.SKU] = existingProduct
+	}
+
+	result := domain.ProductImport{
+		DryRun: dryRun,
+		Rows:   rows,
+	}
+
+	for _, row := range rows {
+		if len(row.Errors) > 0 {
+			result.Failed++
+		}
+	}
+
+	if result.Failed > 0 {
+		return &result, nil
+	}
+
+	err := ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := ps.productRepo.UpsertProducts(ctx, rows, dryRun)
+		if err != nil || dryRun {
+			return err
+		}
+
+		for _, row := range rows {
+			existingProduct, ok := existingProducts[row.Product.SKU]
+			if !ok || existingProduct.Price != row.Product.Price {
+				err = ps.recordPrice(ctx, row.Product.ID, row.Product.Price, row.Product.UpdatedAt)
+				if err != nil {
+					return err
+				}
+			}
+
+			action := auditdomain.ActionUpdate
+			if row.Created {
+				action = auditdomain.ActionCreate
+			}
+
+			var before any
+			if ok {
+				before = auditProduct(existingProduct)
+			}
+
+			err = ps.audit.Record(ctx, action, auditdomain.EntityProduct, row.Product.ID, before, auditProduct(&row.Product))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if cmdomain.IsUniqueConstraintViolationError(err) {
+			return nil, cmdomain.ErrConflictingData
+		}
+
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if row.Created {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	if dryRun {
+		return &result, nil
+	}
+
+	for _, row := range rows {
+		cacheKey := cmutil.GenerateCacheKey("product", row.Product.ID)
+		_ = ps.cache.Delete(ctx, cacheKey)
+	}
+
+	err = ps.cache.DeleteByPrefix(ctx, "products:*")
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UploadProductImage stores an uploaded image of a product and its thumbnail and makes it the product's image,
+// deleting the image it replaces if it was uploaded too
+func (ps *ProductService) UploadProductImage(ctx context.Context, id uint64, image io.Reader) (*imgdomain.Image, error) {
+	existingProduct, err := ps.productRepo.GetProductByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := ps.images.UploadImage(ctx, fmt.Sprintf("%s/%d", imgdomain.ProductsPrefix, id), image)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = ps.UpdateProduct(ctx, &domain.Product{ID: id, Image: img.URL})
+	if err != nil {
+		_ = ps.images.DeleteImage(ctx, img.URL)
+		return nil, err
+	}
+
+	err = ps.images.DeleteImage(ctx, existingProduct.Image)
+	if err != nil {
+		slog.Error("Error deleting replaced product image", "product_id", id, "image", existingProduct.Image, "error", err)
+	}
+
+	return img, nil
+}
+
+// SetProductAvailability 86es a product with a reason until the end of the current business day,
+// regardless of its stock, or puts it back on sale
+func (ps *ProductService) SetProductAvailability(ctx context.Context, id uint64, available bool, reason string) (*domain.Product, error) {
+	existingProduct, err := ps.productRepo.GetProductByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var until *time.Time
+	if available {
+		reason = ""
+	} else {
+		dayEnd := ps.store.DayEnd(ps.store.Today())
+		until = &dayEnd
+	}
+
+	if existingProduct.IsEightySixed(time.Now()) != available && existingProduct.UnavailableReason == reason {
+		return nil, cmdomain.ErrNoUpdatedData
+	}
+
+	var product *domain.Product
+	err = ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		product, err = ps.productRepo.SetProductAvailability(ctx, id, reason, until)
+		if err != nil {
+			return err
+		}
+
+		return ps.audit.Record(ctx, auditdomain.ActionUpdate, auditdomain.EntityProduct, id, auditProduct(existingProduct), auditProduct(product))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := ps.categoryRepo.GetCategoryByIDIncludingDeleted(ctx, product.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	product.Category = category
+
+	err = ps.invalidateProducts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// ResetProductAvailability puts the products whose 86 expired back on sale, audits them
+// and invalidates the cached products that changed
+func (ps *ProductService) ResetProductAvailability(ctx context.Context) error {
+	var productIDs []uint64
+	err := ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		products, err := ps.productRepo.ResetProductAvailability(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+
+		for _, existingProduct := range products {
+			product := existingProduct
+			product.UnavailableReason = ""
+			product.UnavailableUntil = nil
+
+			err = ps.audit.Record(ctx, auditdomain.ActionUpdate, auditdomain.EntityProduct, product.ID, auditProduct(&existingProduct), auditProduct(&product))
+			if err != nil {
+				return err
+			}
+
+			productIDs = append(productIDs, product.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	return ps.invalidateProducts(ctx, productIDs...)
+}
+
+// ScheduleAvailabilityResets puts the products whose 86 expired back on sale on a schedule until the context is done
+func (ps *ProductService) ScheduleAvailabilityResets(ctx context.Context) {
+	ticker := time.NewTicker(availabilityResetInterval)
+	defer ticker.Stop()
+
+	for {
+		err := ps.ResetProductAvailability(ctx)
+		if err != nil {
+			slog.Error("Error resetting product availability", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeleteProduct soft deletes a product
 func (ps *ProductService) DeleteProduct(ctx context.Context, id uint64) error {
-	_, err := ps.productRepo.GetProductByID(ctx, id)
+	existingProduct, err := ps.productRepo.GetProductByID(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -227,5 +683,129 @@ func (ps *ProductService) DeleteProduct(ctx context.Context, id uint64) error {
 		return err
 	}
 
-	return ps.productRepo.DeleteProduct(ctx, id)
+	return ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := ps.productRepo.DeleteProduct(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return ps.audit.Record(ctx, auditdomain.ActionDelete, auditdomain.EntityProduct, id, auditProduct(existingProduct), nil)
+	})
+}
+
+// RestoreProduct restores a deleted product, unless its category is deleted
+func (ps *ProductService) RestoreProduct(ctx context.Context, id uint64) (*domain.Product, error) {
+	var product *domain.Product
+	err := ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		product, err = ps.productRepo.RestoreProduct(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		category, err := ps.categoryRepo.GetCategoryByIDIncludingDeleted(ctx, product.CategoryID)
+		if err != nil {
+			return err
+		}
+
+		if category.DeletedAt != nil {
+			return cmdomain.ErrCategoryDeleted
+		}
+
+		product.Category = category
+
+		return ps.audit.Record(ctx, auditdomain.ActionRestore, auditdomain.EntityProduct, id, nil, auditProduct(product))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = ps.cache.DeleteByPrefix(ctx, "products:*")
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// validateBundle checks that only a bundle has slots, and that every slot of a bundle
+// offers a choice of single products that are not deleted
+func (ps *ProductService) validateBundle(ctx context.Context, product *domain.Product) error {
+	if !product.IsBundle() {
+		if len(product.Slots) > 0 {
+			return cmdomain.ErrInvalidBundle
+		}
+
+		return nil
+	}
+
+	if len(product.Slots) == 0 {
+		return cmdomain.ErrInvalidBundle
+	}
+
+	for _, slot := range product.Slots {
+		if len(slot.ProductIDs) == 0 {
+			return cmdomain.ErrInvalidBundle
+		}
+
+		for _, productID := range slot.ProductIDs {
+			choice, err := ps.productRepo.GetProductByID(ctx, productID)
+			if err != nil {
+				if errors.Is(err, cmdomain.ErrDataNotFound) {
+					return cmdomain.ErrInvalidBundle
+				}
+				return err
+			}
+
+			if choice.IsBundle() {
+				return cmdomain.ErrInvalidBundle
+			}
+		}
+	}
+
+	return nil
+}
+
+// invalidateProducts removes the cached products and product lists, so a change is pushed out to every reader
+func (ps *ProductService) invalidateProducts(ctx context.Context, ids ...uint64) error {
+	for _, id := range ids {
+		cacheKey := cmutil.GenerateCacheKey("product", id)
+		_ = ps.cache.Delete(ctx, cacheKey)
+	}
+
+	return ps.cache.DeleteByPrefix(ctx, "products:*")
+}
+
+// resolveCategories sets the category of every product, even if the category is deleted
+func (ps *ProductService) resolveCategories(ctx context.Context, products []domain.Product) error {
+	for i, product := range products {
+		category, err := ps.categoryRepo.GetCategoryByIDIncludingDeleted(ctx, product.CategoryID)
+		if err != nil {
+			return err
+		}
+
+		products[i].Category = category
+	}
+
+	return nil
+}
+
+// recordPrice adds a price a product is changed to right away to its price history
+func (ps *ProductService) recordPrice(ctx context.Context, productID uint64, price float64, effectiveFrom time.Time) error {
+	_, err := ps.priceRepo.CreateProductPrice(ctx, &domain.ProductPrice{
+		ProductID:     productID,
+		Price:         price,
+		EffectiveFrom: effectiveFrom,
+	})
+
+	return err
+}
+
+// auditProduct returns a copy of a product without its category, which is audited on its own,
+// so only a change of the category id shows up in the changes of a product
+func auditProduct(product *domain.Product) *domain.Product {
+	audited := *product
+	audited.Category = nil
+
+	return &audited
 }
@@ -0,0 +1,414 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
+	"go-restaurant/internal/report/domain"
+	"go-restaurant/internal/report/port"
+	"time"
+)
+
+// ReportHandler represents the HTTP handler for report-related requests
+type ReportHandler struct {
+	svc port.ReportService
+}
+
+// NewReportHandler creates a new ReportHandler instance
+func NewReportHandler(svc port.ReportService) *ReportHandler {
+	return &ReportHandler{
+		svc,
+	}
+}
+
+// salesReportRequest represents a request body for retrieving a sales report
+type salesReportRequest struct {
+	StartDate time.Time             `form:"start_date" binding:"required" time_format:"2006-01-02" example:"2024-01-01"`
+	EndDate   time.Time             `form:"end_date" binding:"required" time_format:"2006-01-02" example:"2024-01-31"`
+	Interval  domain.ReportInterval `form:"interval" binding:"omitempty,report_interval" example:"day"`
+	GroupBy   domain.SalesGroup     `form:"group_by" binding:"omitempty,sales_group" example:"category"`
+}
+
+// GetSalesReport godoc
+//
+//	@Summary		Get a sales report
+//	@Description	Get revenue, order count, average ticket and items sold in a date range, bucketed by hour, day, week or month, with an optional breakdown by category, product, payment or cashier
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Param			start_date	query		string				true	"Start date (YYYY-MM-DD)"
+//	@Param			end_date	query		string				true	"End date, inclusive (YYYY-MM-DD)"
+//	@Param			interval	query		string				false	"Bucket interval"	Enums(hour, day, week, month)
+//	@Param			group_by	query		string				false	"Breakdown"			Enums(category, product, payment, cashier)
+//	@Success		200			{object}	salesReportResponse	"Sales report retrieved"
+//	@Failure		400			{object}	errorResponse		"Validation error"
+//	@Failure		401			{object}	errorResponse		"Unauthorized error"
+//	@Failure		403			{object}	errorResponse		"Forbidden error"
+//	@Failure		500			{object}	errorResponse		"Internal server error"
+//	@Router			/reports/sales [get]
+//	@Security		BearerAuth
+func (rh *ReportHandler) GetSalesReport(ctx *gin.Context) {
+	var req salesReportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	filter := domain.SalesFilter{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Interval:  req.Interval,
+		GroupBy:   req.GroupBy,
+	}
+
+	report, err := rh.svc.GetSalesReport(ctx, &filter)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newSalesReportResponse(report)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// productPerformanceRequest represents a request body for ranking products
+type productPerformanceRequest struct {
+	StartDate  time.Time          `form:"start_date" binding:"required" time_format:"2006-01-02" example:"2024-01-01"`
+	EndDate    time.Time          `form:"end_date" binding:"required" time_format:"2006-01-02" example:"2024-01-31"`
+	CategoryID uint64             `form:"category_id" binding:"omitempty,min=1" example:"1"`
+	SortBy     domain.ProductSort `form:"sort_by" binding:"omitempty,product_sort" example:"quantity"`
+	Order      string             `form:"order" binding:"omitempty,oneof=asc desc" example:"desc"`
+	Limit      uint64             `form:"limit" binding:"omitempty,min=1" example:"10"`
+}
+
+// GetProductPerformance godoc
+//
+//	@Summary		Get a product performance report
+//	@Description	Rank products by quantity sold, revenue or margin in a date range, optionally within a category
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Param			start_date	query		string						true	"Start date (YYYY-MM-DD)"
+//	@Param			end_date	query		string						true	"End date, inclusive (YYYY-MM-DD)"
+//	@Param			category_id	query		uint64						false	"Category ID"
+//	@Param			sort_by		query		string						false	"Ranking metric"	Enums(quantity, revenue, margin)
+//	@Param			order		query		string						false	"Ranking order"		Enums(asc, desc)
+//	@Param			limit		query		uint64						false	"Limit"
+//	@Success		200			{object}	productPerformanceResponse	"Product performance retrieved"
+//	@Failure		400			{object}	errorResponse				"Validation error"
+//	@Failure		401			{object}	errorResponse				"Unauthorized error"
+//	@Failure		403			{object}	errorResponse				"Forbidden error"
+//	@Failure		500			{object}	errorResponse				"Internal server error"
+//	@Router			/reports/products [get]
+//	@Security		BearerAuth
+func (rh *ReportHandler) GetProductPerformance(ctx *gin.Context) {
+	var req productPerformanceRequest
+	var productsList []productPerformanceResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	filter := domain.ProductFilter{
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+		CategoryID: req.CategoryID,
+		SortBy:     req.SortBy,
+		Ascending:  req.Order == "asc",
+		Limit:      req.Limit,
+	}
+
+	products, err := rh.svc.GetProductPerformance(ctx, &filter)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	for _, product := range products {
+		productsList = append(productsList, newProductPerformanceResponse(&product))
+	}
+
+	rsp := map[string]any{
+		"products": productsList,
+	}
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// unsoldProductsRequest represents a request body for listing unsold products
+type unsoldProductsRequest struct {
+	Days       uint64 `form:"days" binding:"required,min=1" example:"30"`
+	CategoryID uint64 `form:"category_id" binding:"omitempty,min=1" example:"1"`
+}
+
+// ListUnsoldProducts godoc
+//
+//	@Summary		List unsold products
+//	@Description	List products that have never been sold or have not been sold in the last N days
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Param			days		query		uint64						true	"Days without sale"
+//	@Param			category_id	query		uint64						false	"Category ID"
+//	@Success		200			{object}	productPerformanceResponse	"Unsold products retrieved"
+//	@Failure		400			{object}	errorResponse				"Validation error"
+//	@Failure		401			{object}	errorResponse				"Unauthorized error"
+//	@Failure		403			{object}	errorResponse				"Forbidden error"
+//	@Failure		500			{object}	errorResponse				"Internal server error"
+//	@Router			/reports/products/unsold [get]
+//	@Security		BearerAuth
+func (rh *ReportHandler) ListUnsoldProducts(ctx *gin.Context) {
+	var req unsoldProductsRequest
+	var productsList []productPerformanceResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	products, err := rh.svc.ListUnsoldProducts(ctx, req.CategoryID, req.Days)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	for _, product := range products {
+		productsList = append(productsList, newProductPerformanceResponse(&product))
+	}
+
+	rsp := map[string]any{
+		"products": productsList,
+	}
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// exportSalesReportRequest represents a request body for exporting a sales report
+type exportSalesReportRequest struct {
+	salesReportRequest
+	Format cmdomain.ExportFormat `form:"format" binding:"omitempty,export_format" example:"csv"`
+}
+
+// ExportSalesReport godoc
+//
+//	@Summary		Export a sales report
+//	@Description	Export a sales report as a CSV or XLSX file, with one row per breakdown item when group_by is set or one row per time bucket otherwise
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			start_date	query		string			true	"Start date (YYYY-MM-DD)"
+//	@Param			end_date	query		string			true	"End date, inclusive (YYYY-MM-DD)"
+//	@Param			interval	query		string			false	"Bucket interval"	Enums(hour, day, week, month)
+//	@Param			group_by	query		string			false	"Breakdown"			Enums(category, product, payment, cashier)
+//	@Param			format		query		string			false	"File format"		Enums(csv, xlsx)
+//	@Success		200			{file}		file			"Sales report exported"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/reports/sales/export [get]
+//	@Security		BearerAuth
+func (rh *ReportHandler) ExportSalesReport(ctx *gin.Context) {
+	var req exportSalesReportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	filter := domain.SalesFilter{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Interval:  req.Interval,
+		GroupBy:   req.GroupBy,
+	}
+
+	report, err := rh.svc.GetSalesReport(ctx, &filter)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	writer, err := cmhttp.NewExportWriter(ctx, req.Format, "sales-report")
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	if report.Filter.GroupBy != "" {
+		err = writeSalesBreakdown(writer, report.Breakdown)
+	} else {
+		err = writeSalesBuckets(writer, report.Buckets)
+	}
+
+	cmhttp.HandleExport(ctx, writer, err)
+}
+
+// exportProductPerformanceRequest represents a request body for exporting a product performance report
+type exportProductPerformanceRequest struct {
+	productPerformanceRequest
+	Format cmdomain.ExportFormat `form:"format" binding:"omitempty,export_format" example:"csv"`
+}
+
+// ExportProductPerformance godoc
+//
+//	@Summary		Export a product performance report
+//	@Description	Export products ranked by quantity sold, revenue or margin as a CSV or XLSX file
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			start_date	query		string			true	"Start date (YYYY-MM-DD)"
+//	@Param			end_date	query		string			true	"End date, inclusive (YYYY-MM-DD)"
+//	@Param			category_id	query		uint64			false	"Category ID"
+//	@Param			sort_by		query		string			false	"Ranking metric"	Enums(quantity, revenue, margin)
+//	@Param			order		query		string			false	"Ranking order"		Enums(asc, desc)
+//	@Param			limit		query		uint64			false	"Limit"
+//	@Param			format		query		string			false	"File format"		Enums(csv, xlsx)
+//	@Success		200			{file}		file			"Product performance exported"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/reports/products/export [get]
+//	@Security		BearerAuth
+func (rh *ReportHandler) ExportProductPerformance(ctx *gin.Context) {
+	var req exportProductPerformanceRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	filter := domain.ProductFilter{
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+		CategoryID: req.CategoryID,
+		SortBy:     req.SortBy,
+		Ascending:  req.Order == "asc",
+		Limit:      req.Limit,
+	}
+
+	products, err := rh.svc.GetProductPerformance(ctx, &filter)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	writer, err := cmhttp.NewExportWriter(ctx, req.Format, "product-performance")
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	err = writeProductPerformance(writer, products)
+
+	cmhttp.HandleExport(ctx, writer, err)
+}
+
+// exportUnsoldProductsRequest represents a request body for exporting unsold products
+type exportUnsoldProductsRequest struct {
+	unsoldProductsRequest
+	Format cmdomain.ExportFormat `form:"format" binding:"omitempty,export_format" example:"csv"`
+}
+
+// ExportUnsoldProducts godoc
+//
+//	@Summary		Export unsold products
+//	@Description	Export products that have not been sold in the last N days as a CSV or XLSX file
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			days		query		uint64			true	"Days without sale"
+//	@Param			category_id	query		uint64			false	"Category ID"
+//	@Param			format		query		string			false	"File format"	Enums(csv, xlsx)
+//	@Success		200			{file}		file			"Unsold products exported"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/reports/products/unsold/export [get]
+//	@Security		BearerAuth
+func (rh *ReportHandler) ExportUnsoldProducts(ctx *gin.Context) {
+	var req exportUnsoldProductsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	products, err := rh.svc.ListUnsoldProducts(ctx, req.CategoryID, req.Days)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	writer, err := cmhttp.NewExportWriter(ctx, req.Format, "unsold-products")
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	err = writeProductPerformance(writer, products)
+
+	cmhttp.HandleExport(ctx, writer, err)
+}
+
+// writeSalesBuckets writes sales report time bucket rows to an export writer
+func writeSalesBuckets(writer cmport.ExportWriter, buckets []domain.SalesBucket) error {
+	err := writer.WriteHeader("Period", "Revenue", "Order Count", "Average Ticket", "Items Sold")
+	if err != nil {
+		return err
+	}
+
+	for _, bucket := range buckets {
+		err := writer.WriteRow(bucket.Period, bucket.Revenue, bucket.OrderCount, bucket.AverageTicket, bucket.ItemsSold)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeSalesBreakdown writes sales report breakdown rows to an export writer
+func writeSalesBreakdown(writer cmport.ExportWriter, breakdown []domain.SalesBreakdown) error {
+	err := writer.WriteHeader("ID", "Name", "Revenue", "Order Count", "Average Ticket", "Items Sold")
+	if err != nil {
+		return err
+	}
+
+	for _, item := range breakdown {
+		err := writer.WriteRow(item.ID, item.Name, item.Revenue, item.OrderCount, item.AverageTicket, item.ItemsSold)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeProductPerformance writes product performance rows to an export writer
+func writeProductPerformance(writer cmport.ExportWriter, products []domain.ProductPerformance) error {
+	err := writer.WriteHeader("Product ID", "Name", "Category ID", "Category", "Quantity Sold", "Revenue", "Margin", "Last Sold At")
+	if err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		err := writer.WriteRow(
+			product.ProductID,
+			product.Name,
+			product.CategoryID,
+			product.CategoryName,
+			product.QuantitySold,
+			product.Revenue,
+			product.Margin,
+			product.LastSoldAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
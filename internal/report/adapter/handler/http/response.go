@@ -0,0 +1,104 @@
+package http
+
+import (
+	"go-restaurant/internal/report/domain"
+	"time"
+)
+
+// salesSummaryResponse represents aggregated sales figures in a Response body
+type salesSummaryResponse struct {
+	Revenue       float64 `json:"revenue" example:"1500000"`
+	OrderCount    uint64  `json:"order_count" example:"30"`
+	AverageTicket float64 `json:"average_ticket" example:"50000"`
+	ItemsSold     int64   `json:"items_sold" example:"75"`
+}
+
+// salesBucketResponse represents the sales figures of a time bucket in a Response body
+type salesBucketResponse struct {
+	Period time.Time `json:"period" example:"1970-01-01T00:00:00Z"`
+	salesSummaryResponse
+}
+
+// salesBreakdownResponse represents the sales figures of a breakdown dimension in a Response body
+type salesBreakdownResponse struct {
+	ID   uint64 `json:"id" example:"1"`
+	Name string `json:"name" example:"Foods"`
+	salesSummaryResponse
+}
+
+// salesReportResponse represents a sales report Response body
+type salesReportResponse struct {
+	StartDate string                   `json:"start_date" example:"1970-01-01"`
+	EndDate   string                   `json:"end_date" example:"1970-01-31"`
+	Interval  domain.ReportInterval    `json:"interval" example:"day"`
+	GroupBy   domain.SalesGroup        `json:"group_by,omitempty" example:"category"`
+	Total     salesSummaryResponse     `json:"total"`
+	Buckets   []salesBucketResponse    `json:"buckets"`
+	Breakdown []salesBreakdownResponse `json:"breakdown,omitempty"`
+}
+
+// newSalesSummaryResponse is a helper function to create a Response body for handling aggregated sales figures
+func newSalesSummaryResponse(summary *domain.SalesSummary) salesSummaryResponse {
+	return salesSummaryResponse{
+		Revenue:       summary.Revenue,
+		OrderCount:    summary.OrderCount,
+		AverageTicket: summary.AverageTicket,
+		ItemsSold:     summary.ItemsSold,
+	}
+}
+
+// newSalesReportResponse is a helper function to create a Response body for handling sales report data
+func newSalesReportResponse(report *domain.SalesReport) salesReportResponse {
+	buckets := []salesBucketResponse{}
+	for _, bucket := range report.Buckets {
+		buckets = append(buckets, salesBucketResponse{
+			Period:               bucket.Period,
+			salesSummaryResponse: newSalesSummaryResponse(&bucket.SalesSummary),
+		})
+	}
+
+	var breakdown []salesBreakdownResponse
+	for _, item := range report.Breakdown {
+		breakdown = append(breakdown, salesBreakdownResponse{
+			ID:                   item.ID,
+			Name:                 item.Name,
+			salesSummaryResponse: newSalesSummaryResponse(&item.SalesSummary),
+		})
+	}
+
+	return salesReportResponse{
+		StartDate: report.Filter.StartDate.Format(time.DateOnly),
+		EndDate:   report.Filter.EndDate.Format(time.DateOnly),
+		Interval:  report.Filter.Interval,
+		GroupBy:   report.Filter.GroupBy,
+		Total:     newSalesSummaryResponse(&report.Total),
+		Buckets:   buckets,
+		Breakdown: breakdown,
+	}
+}
+
+// productPerformanceResponse represents the sales figures of a product in a Response body
+type productPerformanceResponse struct {
+	ProductID    uint64     `json:"product_id" example:"1"`
+	Name         string     `json:"name" example:"Chiki Ball"`
+	CategoryID   uint64     `json:"category_id" example:"1"`
+	CategoryName string     `json:"category_name" example:"Foods"`
+	QuantitySold int64      `json:"quantity_sold" example:"120"`
+	Revenue      float64    `json:"revenue" example:"600000"`
+	Margin       float64    `json:"margin" example:"240000"`
+	LastSoldAt   *time.Time `json:"last_sold_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newProductPerformanceResponse is a helper function to create a Response body for handling product performance data
+func newProductPerformanceResponse(product *domain.ProductPerformance) productPerformanceResponse {
+	return productPerformanceResponse{
+		ProductID:    product.ProductID,
+		Name:         product.Name,
+		CategoryID:   product.CategoryID,
+		CategoryName: product.CategoryName,
+		QuantitySold: product.QuantitySold,
+		Revenue:      product.Revenue,
+		Margin:       product.Margin,
+		LastSoldAt:   product.LastSoldAt,
+	}
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"github.com/go-playground/validator/v10"
+	"go-restaurant/internal/report/domain"
+)
+
+// ReportIntervalValidator is a custom validator for validating report intervals
+var ReportIntervalValidator validator.Func = func(fl validator.FieldLevel) bool {
+	interval := fl.Field().Interface().(domain.ReportInterval)
+
+	switch interval {
+	case "hour", "day", "week", "month":
+		return true
+	default:
+		return false
+	}
+}
+
+// SalesGroupValidator is a custom validator for validating sales report breakdown dimensions
+var SalesGroupValidator validator.Func = func(fl validator.FieldLevel) bool {
+	group := fl.Field().Interface().(domain.SalesGroup)
+
+	switch group {
+	case "category", "product", "payment", "cashier":
+		return true
+	default:
+		return false
+	}
+}
+
+// ProductSortValidator is a custom validator for validating product performance ranking metrics
+var ProductSortValidator validator.Func = func(fl validator.FieldLevel) bool {
+	sort := fl.Field().Interface().(domain.ProductSort)
+
+	switch sort {
+	case "quantity", "revenue", "margin":
+		return true
+	default:
+		return false
+	}
+}
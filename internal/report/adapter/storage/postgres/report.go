@@ -0,0 +1,372 @@
+package repository
+
+import (
+	"context"
+	"go-restaurant/internal/common/adapter/storage/postgres"
+	"go-restaurant/internal/report/domain"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// orderItemsJoin joins the number of items sold per order to the orders table
+const orderItemsJoin = "(SELECT order_id, SUM(quantity) AS quantity FROM order_products GROUP BY order_id) AS items ON items.order_id = o.id"
+
+// orderRefundsJoin joins the amount refunded per order to the orders table
+const orderRefundsJoin = "(SELECT order_id, SUM(amount) AS amount FROM order_refunds GROUP BY order_id) AS refunds ON refunds.order_id = o.id"
+
+// orderSalesColumns are the aggregate columns of the sales queries built on the orders table,
+// whose revenue is what the orders were paid after their discounts less what was refunded of them
+var orderSalesColumns = []string{
+	"COALESCE(SUM(o.total_price - COALESCE(refunds.amount, 0)), 0)",
+	"COUNT(o.id)",
+	"COALESCE(AVG(o.total_price - COALESCE(refunds.amount, 0)), 0)",
+	"COALESCE(SUM(items.quantity), 0)::bigint",
+}
+
+// lineSalesColumns are the aggregate columns of the sales queries built on the order_products table
+var lineSalesColumns = []string{
+	"SUM(op.total_price)",
+	"COUNT(DISTINCT o.id)",
+	"SUM(op.total_price) / COUNT(DISTINCT o.id)",
+	"SUM(op.quantity)::bigint",
+}
+
+// productSortColumns maps the product performance ranking metrics to their result columns
+var productSortColumns = map[domain.ProductSort]string{
+	domain.ByQuantity: "quantity_sold",
+	domain.ByRevenue:  "revenue",
+	domain.ByMargin:   "margin",
+}
+
+/*ReportRepository implements port.ReportRepository interface
+ * and provides access to the postgres database
+ */
+type ReportRepository struct {
+	db *postgres.DB
+}
+
+// NewReportRepository creates a new report repository instance
+func NewReportRepository(db *postgres.DB) *ReportRepository {
+	return &ReportRepository{
+		db,
+	}
+}
+
+// GetSalesSummary aggregates the sales figures of all orders in the filter's date range
+func (rr *ReportRepository) GetSalesSummary(ctx context.Context, filter *domain.SalesFilter) (*domain.SalesSummary, error) {
+	var summary domain.SalesSummary
+
+	query := rr.db.QueryBuilder.Select(orderSalesColumns...).
+		From("orders o").
+		LeftJoin(orderItemsJoin).
+		LeftJoin(orderRefundsJoin).
+		Where(createdBetween(filter))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = rr.db.QueryRow(ctx, sql, args...).Scan(
+		&summary.Revenue,
+		&summary.OrderCount,
+		&summary.AverageTicket,
+		&summary.ItemsSold,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+// ListSalesBuckets aggregates the sales figures of orders in the filter's date range per time bucket
+func (rr *ReportRepository) ListSalesBuckets(ctx context.Context, filter *domain.SalesFilter) ([]domain.SalesBucket, error) {
+	var bucket domain.SalesBucket
+	var buckets []domain.SalesBucket
+
+	query := rr.db.QueryBuilder.Select().
+		Column(businessPeriod(filter)).
+		Columns(orderSalesColumns...).
+		From("orders o").
+		LeftJoin(orderItemsJoin).
+		LeftJoin(orderRefundsJoin).
+		Where(createdBetween(filter)).
+		GroupBy("1").
+		OrderBy("1")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&bucket.Period,
+			&bucket.Revenue,
+			&bucket.OrderCount,
+			&bucket.AverageTicket,
+			&bucket.ItemsSold,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, rows.Err()
+}
+
+// ListSalesBreakdown aggregates the sales figures of orders in the filter's date range
+// per category, product, payment or cashier, sorted by revenue
+func (rr *ReportRepository) ListSalesBreakdown(ctx context.Context, filter *domain.SalesFilter) ([]domain.SalesBreakdown, error) {
+	var breakdown domain.SalesBreakdown
+	var breakdowns []domain.SalesBreakdown
+
+	var query sq.SelectBuilder
+
+	switch filter.GroupBy {
+	case domain.ByCategory:
+		query = rr.lineSalesQuery("c.id", "c.name").
+			Join("categories c ON c.id = p.category_id")
+	case domain.ByProduct:
+		query = rr.lineSalesQuery("p.id", "p.name")
+	case domain.ByPayment:
+		query = rr.orderSalesQuery("pay.id", "pay.name").
+			Join("payments pay ON pay.id = o.payment_id")
+	case domain.ByCashier:
+		query = rr.orderSalesQuery("u.id", "u.name").
+			Join("users u ON u.id = o.user_id")
+	default:
+		return nil, nil
+	}
+
+	query = query.Where(createdBetween(filter)).
+		GroupBy("1", "2").
+		OrderBy("3 DESC", "1")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&breakdown.ID,
+			&breakdown.Name,
+			&breakdown.Revenue,
+			&breakdown.OrderCount,
+			&breakdown.AverageTicket,
+			&breakdown.ItemsSold,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		breakdowns = append(breakdowns, breakdown)
+	}
+
+	return breakdowns, rows.Err()
+}
+
+// ListProductPerformance ranks products by quantity sold, revenue or margin in the filter's date range,
+// including products without any sale so slow sellers show up at the bottom of the ranking
+func (rr *ReportRepository) ListProductPerformance(ctx context.Context, filter *domain.ProductFilter) ([]domain.ProductPerformance, error) {
+	var product domain.ProductPerformance
+	var products []domain.ProductPerformance
+
+	salesQuery := sq.Select(
+		"op.product_id",
+		"SUM(op.quantity) AS quantity",
+		"SUM(op.total_price) AS revenue",
+		"MAX(o.created_at) AS last_sold_at",
+	).
+		From("order_products op").
+		Join("orders o ON o.id = op.order_id").
+		Where(sq.GtOrEq{"o.created_at": filter.StartDate}).
+		Where(sq.Lt{"o.created_at": filter.EndDate}).
+		Where(notVoided).
+		GroupBy("op.product_id")
+
+	salesSql, salesArgs, err := salesQuery.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	direction := " DESC"
+	if filter.Ascending {
+		direction = " ASC"
+	}
+
+	query := rr.db.QueryBuilder.Select(
+		"p.id",
+		"p.name",
+		"c.id",
+		"c.name",
+		"COALESCE(s.quantity, 0)::bigint AS quantity_sold",
+		"COALESCE(s.revenue, 0) AS revenue",
+		"COALESCE(s.revenue, 0) - COALESCE(s.quantity, 0) * p.cost AS margin",
+		"s.last_sold_at",
+	).
+		From("products p").
+		Join("categories c ON c.id = p.category_id").
+		LeftJoin("("+salesSql+") AS s ON s.product_id = p.id", salesArgs...).
+		OrderBy(productSortColumns[filter.SortBy]+direction, "p.id")
+
+	if filter.CategoryID != 0 {
+		query = query.Where(sq.Eq{"p.category_id": filter.CategoryID})
+	}
+
+	if filter.Limit != 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&product.ProductID,
+			&product.Name,
+			&product.CategoryID,
+			&product.CategoryName,
+			&product.QuantitySold,
+			&product.Revenue,
+			&product.Margin,
+			&product.LastSoldAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, product)
+	}
+
+	return products, rows.Err()
+}
+
+// ListUnsoldProducts retrieves products that were never sold or were last sold before the given time,
+// the longest unsold products first
+func (rr *ReportRepository) ListUnsoldProducts(ctx context.Context, categoryId uint64, since time.Time) ([]domain.ProductPerformance, error) {
+	var product domain.ProductPerformance
+	var products []domain.ProductPerformance
+
+	query := rr.db.QueryBuilder.Select(
+		"p.id",
+		"p.name",
+		"c.id",
+		"c.name",
+		"MAX(o.created_at) AS last_sold_at",
+	).
+		From("products p").
+		Join("categories c ON c.id = p.category_id").
+		LeftJoin("order_products op ON op.product_id = p.id").
+		LeftJoin("orders o ON o.id = op.order_id AND "+notVoided).
+		GroupBy("p.id", "c.id").
+		Having(sq.Or{
+			sq.Expr("MAX(o.created_at) IS NULL"),
+			sq.Expr("MAX(o.created_at) < ?", since),
+		}).
+		OrderBy("last_sold_at NULLS FIRST", "p.id")
+
+	if categoryId != 0 {
+		query = query.Where(sq.Eq{"p.category_id": categoryId})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&product.ProductID,
+			&product.Name,
+			&product.CategoryID,
+			&product.CategoryName,
+			&product.LastSoldAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, product)
+	}
+
+	return products, rows.Err()
+}
+
+// orderSalesQuery builds a sales query on the orders table selecting the given id and name columns
+func (rr *ReportRepository) orderSalesQuery(id, name string) sq.SelectBuilder {
+	return rr.db.QueryBuilder.Select(id, name).
+		Columns(orderSalesColumns...).
+		From("orders o").
+		LeftJoin(orderItemsJoin).
+		LeftJoin(orderRefundsJoin)
+}
+
+// lineSalesQuery builds a sales query on the order_products table selecting the given id and name columns
+func (rr *ReportRepository) lineSalesQuery(id, name string) sq.SelectBuilder {
+	return rr.db.QueryBuilder.Select(id, name).
+		Columns(lineSalesColumns...).
+		From("order_products op").
+		Join("orders o ON o.id = op.order_id").
+		Join("products p ON p.id = op.product_id")
+}
+
+// businessPeriod truncates the order's creation time to the filter's interval in the store's timezone.
+// The time is shifted back by the day cutoff before truncating so that orders placed after midnight
+// but before the cutoff fall into the previous business day, and shifted forward again afterwards
+// so the period is the instant its business day, week or month begins
+func businessPeriod(filter *domain.SalesFilter) sq.Sqlizer {
+	cutoff := filter.DayCutoff.Seconds()
+
+	return sq.Expr(
+		"(date_trunc(?, (o.created_at AT TIME ZONE ?) - make_interval(secs => ?)) + make_interval(secs => ?)) AT TIME ZONE ?",
+		string(filter.Interval),
+		filter.Timezone,
+		cutoff,
+		cutoff,
+		filter.Timezone,
+	)
+}
+
+// notVoided filters out voided orders, which do not count as sales
+const notVoided = "NOT EXISTS (SELECT 1 FROM order_voids ov WHERE ov.order_id = o.id)"
+
+// createdBetween filters orders created within the filter's date range that were not voided
+func createdBetween(filter *domain.SalesFilter) sq.And {
+	return sq.And{
+		sq.GtOrEq{"o.created_at": filter.StartDate},
+		sq.Lt{"o.created_at": filter.EndDate},
+		sq.Expr(notVoided),
+	}
+}
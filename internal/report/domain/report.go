@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"time"
+)
+
+// ReportInterval is an enum for report's time bucket
+type ReportInterval string
+
+// ReportInterval enum values
+const (
+	Hour  ReportInterval = "hour"
+	Day   ReportInterval = "day"
+	Week  ReportInterval = "week"
+	Month ReportInterval = "month"
+)
+
+// SalesGroup is an enum for sales report's breakdown dimension
+type SalesGroup string
+
+// SalesGroup enum values
+const (
+	ByCategory SalesGroup = "category"
+	ByProduct  SalesGroup = "product"
+	ByPayment  SalesGroup = "payment"
+	ByCashier  SalesGroup = "cashier"
+)
+
+// ProductSort is an enum for product performance report's ranking metric
+type ProductSort string
+
+// ProductSort enum values
+const (
+	ByQuantity ProductSort = "quantity"
+	ByRevenue  ProductSort = "revenue"
+	ByMargin   ProductSort = "margin"
+)
+
+// SalesFilter is a value object that represents the filter of a sales report.
+// Timezone and DayCutoff are used to bucket orders by the store's business day
+type SalesFilter struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Interval  ReportInterval
+	GroupBy   SalesGroup
+	Timezone  string
+	DayCutoff time.Duration
+}
+
+// SalesSummary is a value object that represents aggregated sales figures
+type SalesSummary struct {
+	Revenue       float64
+	OrderCount    uint64
+	AverageTicket float64
+	ItemsSold     int64
+}
+
+// SalesBucket is a value object that represents sales figures of a time bucket
+type SalesBucket struct {
+	Period time.Time
+	SalesSummary
+}
+
+// SalesBreakdown is a value object that represents sales figures of a single category, product, payment or cashier
+type SalesBreakdown struct {
+	ID   uint64
+	Name string
+	SalesSummary
+}
+
+// SalesReport is an entity that represents a sales report
+type SalesReport struct {
+	Filter    SalesFilter
+	Total     SalesSummary
+	Buckets   []SalesBucket
+	Breakdown []SalesBreakdown
+}
+
+// ProductFilter is a value object that represents the filter of a product performance report
+type ProductFilter struct {
+	StartDate  time.Time
+	EndDate    time.Time
+	CategoryID uint64
+	SortBy     ProductSort
+	Ascending  bool
+	Limit      uint64
+}
+
+// ProductPerformance is a value object that represents the sales figures of a single product
+type ProductPerformance struct {
+	ProductID    uint64
+	Name         string
+	CategoryID   uint64
+	CategoryName string
+	QuantitySold int64
+	Revenue      float64
+	Margin       float64
+	LastSoldAt   *time.Time
+}
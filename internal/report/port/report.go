@@ -0,0 +1,33 @@
+package port
+
+import (
+	"context"
+	"go-restaurant/internal/report/domain"
+	"time"
+)
+
+//go:generate mockgen -source=report.go -destination=mock/report.go -package=mock
+
+// ReportRepository is an interface for interacting with report-related data
+type ReportRepository interface {
+	// GetSalesSummary aggregates sales figures over the filter's date range
+	GetSalesSummary(ctx context.Context, filter *domain.SalesFilter) (*domain.SalesSummary, error)
+	// ListSalesBuckets aggregates sales figures per time bucket
+	ListSalesBuckets(ctx context.Context, filter *domain.SalesFilter) ([]domain.SalesBucket, error)
+	// ListSalesBreakdown aggregates sales figures per category, product, payment or cashier
+	ListSalesBreakdown(ctx context.Context, filter *domain.SalesFilter) ([]domain.SalesBreakdown, error)
+	// ListProductPerformance ranks products by quantity, revenue or margin
+	ListProductPerformance(ctx context.Context, filter *domain.ProductFilter) ([]domain.ProductPerformance, error)
+	// ListUnsoldProducts selects products that have not been sold since the given time
+	ListUnsoldProducts(ctx context.Context, categoryId uint64, since time.Time) ([]domain.ProductPerformance, error)
+}
+
+// ReportService is an interface for interacting with report-related business logic
+type ReportService interface {
+	// GetSalesReport returns a sales report for the given filter
+	GetSalesReport(ctx context.Context, filter *domain.SalesFilter) (*domain.SalesReport, error)
+	// GetProductPerformance returns products ranked by quantity, revenue or margin
+	GetProductPerformance(ctx context.Context, filter *domain.ProductFilter) ([]domain.ProductPerformance, error)
+	// ListUnsoldProducts returns products that have not been sold in the last given days
+	ListUnsoldProducts(ctx context.Context, categoryId, days uint64) ([]domain.ProductPerformance, error)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	cmdomain "go-restaurant/internal/common/domain"
+	"go-restaurant/internal/report/domain"
+	"go-restaurant/internal/report/port"
+)
+
+/*ReportService implements port.ReportService interface
+ * and provides access to the report repository
+ * and the store's business day settings
+ */
+type ReportService struct {
+	repo  port.ReportRepository
+	store *cmdomain.Store
+}
+
+// NewReportService creates a new report service instance
+func NewReportService(repo port.ReportRepository, store *cmdomain.Store) *ReportService {
+	return &ReportService{
+		repo,
+		store,
+	}
+}
+
+// GetSalesReport aggregates revenue, order count, average ticket and items sold
+// for the given business date range, both per time bucket and per breakdown dimension
+func (rs *ReportService) GetSalesReport(ctx context.Context, filter *domain.SalesFilter) (*domain.SalesReport, error) {
+	if filter.EndDate.Before(filter.StartDate) {
+		return nil, cmdomain.ErrInvalidDateRange
+	}
+
+	if filter.Interval == "" {
+		filter.Interval = domain.Day
+	}
+
+	queryFilter := *filter
+	queryFilter.StartDate = rs.store.DayStart(filter.StartDate)
+	queryFilter.EndDate = rs.store.DayEnd(filter.EndDate)
+	queryFilter.Timezone = rs.store.Location.String()
+	queryFilter.DayCutoff = rs.store.DayCutoff
+
+	total, err := rs.repo.GetSalesSummary(ctx, &queryFilter)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	buckets, err := rs.repo.ListSalesBuckets(ctx, &queryFilter)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	for i, bucket := range buckets {
+		buckets[i].Period = bucket.Period.In(rs.store.Location)
+	}
+
+	var breakdown []domain.SalesBreakdown
+	if filter.GroupBy != "" {
+		breakdown, err = rs.repo.ListSalesBreakdown(ctx, &queryFilter)
+		if err != nil {
+			return nil, cmdomain.ErrInternal
+		}
+	}
+
+	return &domain.SalesReport{
+		Filter:    *filter,
+		Total:     *total,
+		Buckets:   buckets,
+		Breakdown: breakdown,
+	}, nil
+}
+
+// GetProductPerformance ranks products by quantity sold, revenue or margin in the given business date range
+func (rs *ReportService) GetProductPerformance(ctx context.Context, filter *domain.ProductFilter) ([]domain.ProductPerformance, error) {
+	if filter.EndDate.Before(filter.StartDate) {
+		return nil, cmdomain.ErrInvalidDateRange
+	}
+
+	if filter.SortBy == "" {
+		filter.SortBy = domain.ByQuantity
+	}
+
+	queryFilter := *filter
+	queryFilter.StartDate = rs.store.DayStart(filter.StartDate)
+	queryFilter.EndDate = rs.store.DayEnd(filter.EndDate)
+
+	products, err := rs.repo.ListProductPerformance(ctx, &queryFilter)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return products, nil
+}
+
+// ListUnsoldProducts lists products that have never been sold or were last sold more than the given business days ago
+func (rs *ReportService) ListUnsoldProducts(ctx context.Context, categoryId, days uint64) ([]domain.ProductPerformance, error) {
+	since := rs.store.DayStart(rs.store.Today().AddDate(0, 0, -int(days)))
+
+	products, err := rs.repo.ListUnsoldProducts(ctx, categoryId, since)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return products, nil
+}
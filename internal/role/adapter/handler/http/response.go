@@ -0,0 +1,39 @@
+package http
+
+import (
+	"go-restaurant/internal/role/domain"
+	udomain "go-restaurant/internal/user/domain"
+	"time"
+)
+
+// roleResponse represents a role Response body
+type roleResponse struct {
+	Name        udomain.UserRole    `json:"name" example:"cashier"`
+	Description string              `json:"description" example:"Takes orders and payments"`
+	Permissions []domain.Permission `json:"permissions" example:"order.create"`
+	CreatedAt   time.Time           `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt   time.Time           `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newRoleResponse is a helper function to create a Response body for handling role data
+func newRoleResponse(role *domain.Role) roleResponse {
+	return roleResponse{
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.Permissions,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+// permissionsResponse represents a permission list Response body
+type permissionsResponse struct {
+	Permissions []domain.Permission `json:"permissions" example:"order.create"`
+}
+
+// newPermissionsResponse is a helper function to create a Response body for handling permission data
+func newPermissionsResponse(permissions []domain.Permission) permissionsResponse {
+	return permissionsResponse{
+		Permissions: permissions,
+	}
+}
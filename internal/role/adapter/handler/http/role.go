@@ -0,0 +1,265 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	autil "go-restaurant/internal/auth/util"
+	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+	cmutil "go-restaurant/internal/common/util"
+	"go-restaurant/internal/role/domain"
+	"go-restaurant/internal/role/port"
+	udomain "go-restaurant/internal/user/domain"
+)
+
+// RoleHandler represents the HTTP handler for role-related requests
+type RoleHandler struct {
+	svc port.RoleService
+}
+
+// NewRoleHandler creates a new RoleHandler instance
+func NewRoleHandler(svc port.RoleService) *RoleHandler {
+	return &RoleHandler{
+		svc,
+	}
+}
+
+// createRoleRequest represents a request body for creating a new role
+type createRoleRequest struct {
+	Name        udomain.UserRole    `json:"name" binding:"required,user_role" example:"host"`
+	Description string              `json:"description" binding:"omitempty" example:"Seats guests"`
+	Permissions []domain.Permission `json:"permissions" binding:"required,dive,permission" example:"order.read"`
+}
+
+// CreateRole godoc
+//
+//	@Summary		Create a new role
+//	@Description	create a new role with a name, a description and a set of permissions. Only permissions the user is allowed themselves can be given
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			createRoleRequest	body		createRoleRequest	true	"Create role request"
+//	@Success		200					{object}	roleResponse		"Role created"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/roles [post]
+//	@Security		BearerAuth
+func (rh *RoleHandler) CreateRole(ctx *gin.Context) {
+	var req createRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	authPayload := autil.GetAuthPayload(ctx, cmhttp.AuthorizationPayloadKey)
+
+	role := domain.Role{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	}
+
+	_, err := rh.svc.CreateRole(ctx, authPayload, &role)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newRoleResponse(&role)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// getRoleRequest represents a request body for retrieving a role
+type getRoleRequest struct {
+	Name udomain.UserRole `uri:"name" binding:"required,user_role" example:"cashier"`
+}
+
+// GetRole godoc
+//
+//	@Summary		Get a role
+//	@Description	get a role and its permissions by name
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string			true	"Role name"
+//	@Success		200		{object}	roleResponse	"Role retrieved"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/roles/{name} [get]
+//	@Security		BearerAuth
+func (rh *RoleHandler) GetRole(ctx *gin.Context) {
+	var req getRoleRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	role, err := rh.svc.GetRole(ctx, req.Name)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newRoleResponse(role)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// listRolesRequest represents a request body for listing roles
+type listRolesRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListRoles godoc
+//
+//	@Summary		List roles
+//	@Description	List roles and their permissions with pagination
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Roles displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/roles [get]
+//	@Security		BearerAuth
+func (rh *RoleHandler) ListRoles(ctx *gin.Context) {
+	var req listRolesRequest
+	var rolesList []roleResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	roles, err := rh.svc.ListRoles(ctx, req.Skip, req.Limit)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	for _, role := range roles {
+		rolesList = append(rolesList, newRoleResponse(&role))
+	}
+
+	total := uint64(len(rolesList))
+	meta := cmhttp.NewMeta(total, req.Limit, req.Skip)
+	rsp := cmutil.ToMap(meta, rolesList, "roles")
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// ListPermissions godoc
+//
+//	@Summary		List permissions
+//	@Description	List every permission that can be given to a role
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	permissionsResponse	"Permissions displayed"
+//	@Failure		401	{object}	errorResponse		"Unauthorized error"
+//	@Failure		403	{object}	errorResponse		"Forbidden error"
+//	@Router			/roles/permissions [get]
+//	@Security		BearerAuth
+func (rh *RoleHandler) ListPermissions(ctx *gin.Context) {
+	rsp := newPermissionsResponse(domain.Permissions)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// updateRoleRequest represents a request body for updating a role
+type updateRoleRequest struct {
+	Description string              `json:"description" binding:"omitempty,required" example:"Seats guests and takes orders"`
+	Permissions []domain.Permission `json:"permissions" binding:"omitempty,dive,permission" example:"order.create"`
+}
+
+// UpdateRole godoc
+//
+//	@Summary		Update a role
+//	@Description	update a role's description, or replace its permissions, by name. The admin role, and roles with permissions the user is not allowed themselves, cannot be updated
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			name				path		string				true	"Role name"
+//	@Param			updateRoleRequest	body		updateRoleRequest	true	"Update role request"
+//	@Success		200					{object}	roleResponse		"Role updated"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/roles/{name} [put]
+//	@Security		BearerAuth
+func (rh *RoleHandler) UpdateRole(ctx *gin.Context) {
+	var uri getRoleRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	var req updateRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	authPayload := autil.GetAuthPayload(ctx, cmhttp.AuthorizationPayloadKey)
+
+	role := domain.Role{
+		Name:        uri.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	}
+
+	updatedRole, err := rh.svc.UpdateRole(ctx, authPayload, &role)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newRoleResponse(updatedRole)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// DeleteRole godoc
+//
+//	@Summary		Delete a role
+//	@Description	Delete a role by name. Roles given to users, the admin role and the cashier role cannot be deleted
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string			true	"Role name"
+//	@Success		200		{object}	response		"Role deleted"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		409		{object}	errorResponse	"Data conflict error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/roles/{name} [delete]
+//	@Security		BearerAuth
+func (rh *RoleHandler) DeleteRole(ctx *gin.Context) {
+	var req getRoleRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	err := rh.svc.DeleteRole(ctx, req.Name)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	cmhttp.HandleSuccess(ctx, nil)
+}
@@ -0,0 +1,19 @@
+package http
+
+import (
+	"github.com/go-playground/validator/v10"
+	"go-restaurant/internal/role/domain"
+)
+
+// PermissionValidator is a custom validator for validating role permissions
+var PermissionValidator validator.Func = func(fl validator.FieldLevel) bool {
+	permission := fl.Field().Interface().(domain.Permission)
+
+	for _, p := range domain.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
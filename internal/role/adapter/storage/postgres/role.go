@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-restaurant/internal/common/adapter/storage/postgres"
+	cmdomain "go-restaurant/internal/common/domain"
+	"go-restaurant/internal/role/domain"
+	udomain "go-restaurant/internal/user/domain"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/*RoleRepository implements port.RoleRepository interface
+ * and provides access to the postgres database
+ */
+type RoleRepository struct {
+	db *postgres.DB
+}
+
+// NewRoleRepository creates a new role repository instance
+func NewRoleRepository(db *postgres.DB) *RoleRepository {
+	return &RoleRepository{
+		db,
+	}
+}
+
+// selectRoles builds a query that selects roles with their permissions aggregated into an array
+func (rr *RoleRepository) selectRoles() sq.SelectBuilder {
+	return rr.db.QueryBuilder.Select(
+		"roles.name",
+		"roles.description",
+		"roles.created_at",
+		"roles.updated_at",
+		"COALESCE(array_agg(role_permissions.permission ORDER BY role_permissions.permission) FILTER (WHERE role_permissions.permission IS NOT NULL), '{}')",
+	).
+		From("roles").
+		LeftJoin("role_permissions ON role_permissions.role = roles.name").
+		GroupBy("roles.name")
+}
+
+// scanRole scans a row selected by selectRoles into a role
+func scanRole(row pgx.Row, role *domain.Role) error {
+	var permissions []string
+
+	err := row.Scan(
+		&role.Name,
+		&role.Description,
+		&role.CreatedAt,
+		&role.UpdatedAt,
+		&permissions,
+	)
+	if err != nil {
+		return err
+	}
+
+	role.Permissions = make([]domain.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		role.Permissions = append(role.Permissions, domain.Permission(permission))
+	}
+
+	return nil
+}
+
+// insertPermissions inserts the permissions of a role inside a transaction
+func (rr *RoleRepository) insertPermissions(ctx context.Context, tx pgx.Tx, role *domain.Role) error {
+	if len(role.Permissions) == 0 {
+		return nil
+	}
+
+	query := rr.db.QueryBuilder.Insert("role_permissions").
+		Columns("role", "permission")
+
+	for _, permission := range role.Permissions {
+		query = query.Values(role.Name, permission)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, sql, args...)
+	return err
+}
+
+// CreateRole creates a new role record and its permissions in the database
+func (rr *RoleRepository) CreateRole(ctx context.Context, role *domain.Role) (*domain.Role, error) {
+	tx, err := rr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := rr.db.QueryBuilder.Insert("roles").
+		Columns("name", "description").
+		Values(role.Name, role.Description).
+		Suffix("RETURNING created_at, updated_at")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(
+		&role.CreatedAt,
+		&role.UpdatedAt,
+	)
+	if err != nil {
+		if errCode := rr.db.ErrorCode(err); errCode == "23505" {
+			return nil, cmdomain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	err = rr.insertPermissions(ctx, tx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// GetRoleByName retrieves a role record and its permissions from the database by name
+func (rr *RoleRepository) GetRoleByName(ctx context.Context, name udomain.UserRole) (*domain.Role, error) {
+	var role domain.Role
+
+	query := rr.selectRoles().
+		Where(sq.Eq{"roles.name": name})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanRole(rr.db.QueryRow(ctx, sql, args...), &role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// ListRoles retrieves a list of roles and their permissions from the database
+func (rr *RoleRepository) ListRoles(ctx context.Context, skip, limit uint64) ([]domain.Role, error) {
+	var role domain.Role
+	var roles []domain.Role
+
+	query := rr.selectRoles().
+		OrderBy("roles.name").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		err := scanRole(rows, &role)
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+// UpdateRole updates a role record in the database and, if given, replaces its permissions
+func (rr *RoleRepository) UpdateRole(ctx context.Context, role *domain.Role) (*domain.Role, error) {
+	tx, err := rr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	description := sq.Expr("COALESCE(NULLIF(?, ''), description)", role.Description)
+
+	query := rr.db.QueryBuilder.Update("roles").
+		Set("description", description).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"name": role.Name})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if role.Permissions != nil {
+		query := rr.db.QueryBuilder.Delete("role_permissions").
+			Where(sq.Eq{"role": role.Name})
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		err = rr.insertPermissions(ctx, tx, role)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	selectQuery := rr.selectRoles().
+		Where(sq.Eq{"roles.name": role.Name})
+
+	sql, args, err = selectQuery.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanRole(tx.QueryRow(ctx, sql, args...), role)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// DeleteRole deletes a role record and its permissions from the database by name
+func (rr *RoleRepository) DeleteRole(ctx context.Context, name udomain.UserRole) error {
+	query := rr.db.QueryBuilder.Delete("roles").
+		Where(sq.Eq{"name": name})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = rr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		if errCode := rr.db.ErrorCode(err); errCode == "23503" {
+			return cmdomain.ErrRoleInUse
+		}
+		return err
+	}
+
+	return nil
+}
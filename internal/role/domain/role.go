@@ -0,0 +1,98 @@
+package domain
+
+import (
+	udomain "go-restaurant/internal/user/domain"
+	"time"
+)
+
+// Permission is an enum for the actions a role allows
+type Permission string
+
+// Permission enum values
+const (
+	UserRead            Permission = "user.read"
+	UserWrite           Permission = "user.write"
+	RoleRead            Permission = "role.read"
+	RoleWrite           Permission = "role.write"
+	TerminalRead        Permission = "terminal.read"
+	TerminalWrite       Permission = "terminal.write"
+	APIKeyRead          Permission = "api_key.read"
+	APIKeyWrite         Permission = "api_key.write"
+	AuditRead           Permission = "audit.read"
+	PaymentRead         Permission = "payment.read"
+	PaymentWrite        Permission = "payment.write"
+	CategoryRead        Permission = "category.read"
+	CategoryWrite       Permission = "category.write"
+	ProductRead         Permission = "product.read"
+	ProductWrite        Permission = "product.write"
+	ProductAvailability Permission = "product.availability"
+	OrderCreate         Permission = "order.create"
+	OrderRead           Permission = "order.read"
+	OrderVoid           Permission = "order.void"
+	OrderRefund         Permission = "order.refund"
+	OrderDiscount       Permission = "order.discount"
+	OrderExport         Permission = "order.export"
+	ReportRead          Permission = "report.read"
+	RecordRestore       Permission = "record.restore"
+)
+
+// Permissions lists every permission that can be given to a role
+var Permissions = []Permission{
+	UserRead,
+	UserWrite,
+	RoleRead,
+	RoleWrite,
+	TerminalRead,
+	TerminalWrite,
+	APIKeyRead,
+	APIKeyWrite,
+	AuditRead,
+	PaymentRead,
+	PaymentWrite,
+	CategoryRead,
+	CategoryWrite,
+	ProductRead,
+	ProductWrite,
+	ProductAvailability,
+	OrderCreate,
+	OrderRead,
+	OrderVoid,
+	OrderRefund,
+	OrderDiscount,
+	OrderExport,
+	ReportRead,
+	RecordRestore,
+}
+
+// Role is an entity that represents a named set of permissions given to users
+type Role struct {
+	Name        udomain.UserRole
+	Description string
+	Permissions []Permission
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// HasPermission checks if the role allows the permission. The admin role allows every permission
+func (r *Role) HasPermission(permission Permission) bool {
+	if r.Name == udomain.Admin {
+		return true
+	}
+
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowedPermissions lists the permissions the role allows, which are all of them for the admin role
+func (r *Role) AllowedPermissions() []Permission {
+	if r.Name == udomain.Admin {
+		return Permissions
+	}
+
+	return r.Permissions
+}
@@ -0,0 +1,44 @@
+package port
+
+import (
+	"context"
+	adomain "go-restaurant/internal/auth/domain"
+	"go-restaurant/internal/role/domain"
+	udomain "go-restaurant/internal/user/domain"
+)
+
+//go:generate mockgen -source=role.go -destination=mock/role.go -package=mock
+
+// RoleRepository is an interface for interacting with role-related data
+type RoleRepository interface {
+	// CreateRole inserts a new role and its permissions into the database
+	CreateRole(ctx context.Context, role *domain.Role) (*domain.Role, error)
+	// GetRoleByName selects a role by name
+	GetRoleByName(ctx context.Context, name udomain.UserRole) (*domain.Role, error)
+	// ListRoles selects a list of roles with pagination
+	ListRoles(ctx context.Context, skip, limit uint64) ([]domain.Role, error)
+	// UpdateRole updates a role and replaces its permissions
+	UpdateRole(ctx context.Context, role *domain.Role) (*domain.Role, error)
+	// DeleteRole deletes a role
+	DeleteRole(ctx context.Context, name udomain.UserRole) error
+}
+
+// RoleService is an interface for interacting with role-related business logic
+type RoleService interface {
+	// CreateRole creates a new role with permissions the grantor is allowed
+	CreateRole(ctx context.Context, grantor *adomain.TokenPayload, role *domain.Role) (*domain.Role, error)
+	// GetRole returns a role by name
+	GetRole(ctx context.Context, name udomain.UserRole) (*domain.Role, error)
+	// ListRoles returns a list of roles with pagination
+	ListRoles(ctx context.Context, skip, limit uint64) ([]domain.Role, error)
+	// UpdateRole updates a role whose current and new permissions the grantor is allowed
+	UpdateRole(ctx context.Context, grantor *adomain.TokenPayload, role *domain.Role) (*domain.Role, error)
+	// DeleteRole deletes a role
+	DeleteRole(ctx context.Context, name udomain.UserRole) error
+	// HasPermission checks if a role allows a permission
+	HasPermission(ctx context.Context, name udomain.UserRole, permission domain.Permission) (bool, error)
+	// CanGrant checks if the user of a token payload is allowed every permission, so they can give them to others
+	CanGrant(ctx context.Context, grantor *adomain.TokenPayload, permissions []domain.Permission) (bool, error)
+	// CanGrantRole checks if the user of a token payload is allowed every permission of a role, so they can give it to others
+	CanGrantRole(ctx context.Context, grantor *adomain.TokenPayload, name udomain.UserRole) (bool, error)
+}
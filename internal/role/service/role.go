@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"errors"
+	adomain "go-restaurant/internal/auth/domain"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
+	cmutil "go-restaurant/internal/common/util"
+	"go-restaurant/internal/role/domain"
+	"go-restaurant/internal/role/port"
+	udomain "go-restaurant/internal/user/domain"
+)
+
+/*RoleService implements port.RoleService interface
+ * and provides access to the role repository
+ * and cache service
+ */
+type RoleService struct {
+	repo  port.RoleRepository
+	cache cmport.CacheRepository
+}
+
+// NewRoleService creates a new role service instance
+func NewRoleService(repo port.RoleRepository, cache cmport.CacheRepository) *RoleService {
+	return &RoleService{
+		repo,
+		cache,
+	}
+}
+
+// CreateRole creates a new role. The grantor can only create roles with permissions they are allowed themselves
+func (rs *RoleService) CreateRole(ctx context.Context, grantor *adomain.TokenPayload, role *domain.Role) (*domain.Role, error) {
+	err := rs.authorizePermissions(ctx, grantor, role.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err = rs.repo.CreateRole(ctx, role)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrConflictingData) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = rs.cache.DeleteByPrefix(ctx, "roles:*")
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return role, nil
+}
+
+// GetRole retrieves a role by name
+func (rs *RoleService) GetRole(ctx context.Context, name udomain.UserRole) (*domain.Role, error) {
+	var role *domain.Role
+
+	cacheKey := cmutil.GenerateCacheKey("role", name)
+	cachedRole, err := rs.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := cmutil.Deserialize(cachedRole, &role)
+		if err != nil {
+			return nil, cmdomain.ErrInternal
+		}
+		return role, nil
+	}
+
+	role, err = rs.repo.GetRoleByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	roleSerialized, err := cmutil.Serialize(role)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = rs.cache.Set(ctx, cacheKey, roleSerialized, 0)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return role, nil
+}
+
+// ListRoles retrieves a list of roles
+func (rs *RoleService) ListRoles(ctx context.Context, skip, limit uint64) ([]domain.Role, error) {
+	var roles []domain.Role
+
+	params := cmutil.GenerateCacheKeyParams(skip, limit)
+	cacheKey := cmutil.GenerateCacheKey("roles", params)
+
+	cachedRoles, err := rs.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := cmutil.Deserialize(cachedRoles, &roles)
+		if err != nil {
+			return nil, cmdomain.ErrInternal
+		}
+
+		return roles, nil
+	}
+
+	roles, err = rs.repo.ListRoles(ctx, skip, limit)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	rolesSerialized, err := cmutil.Serialize(roles)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = rs.cache.Set(ctx, cacheKey, rolesSerialized, 0)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return roles, nil
+}
+
+// UpdateRole updates the description and permissions of a role. The admin role always has
+// every permission, so it cannot be updated. The grantor can only update roles whose current
+// and new permissions they are allowed themselves
+func (rs *RoleService) UpdateRole(ctx context.Context, grantor *adomain.TokenPayload, role *domain.Role) (*domain.Role, error) {
+	if role.Name == udomain.Admin {
+		return nil, cmdomain.ErrProtectedRole
+	}
+
+	existingRole, err := rs.repo.GetRoleByName(ctx, role.Name)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = rs.authorizePermissions(ctx, grantor, existingRole.AllowedPermissions())
+	if err != nil {
+		return nil, err
+	}
+
+	err = rs.authorizePermissions(ctx, grantor, role.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	emptyData := role.Description == "" && role.Permissions == nil
+	if emptyData {
+		return nil, cmdomain.ErrNoUpdatedData
+	}
+
+	role, err = rs.repo.UpdateRole(ctx, role)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("role", role.Name)
+
+	err = rs.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = rs.cache.DeleteByPrefix(ctx, "roles:*")
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return role, nil
+}
+
+// DeleteRole deletes a role by name. The admin role and the cashier role given to
+// newly registered users cannot be deleted
+func (rs *RoleService) DeleteRole(ctx context.Context, name udomain.UserRole) error {
+	if name == udomain.Admin || name == udomain.Cashier {
+		return cmdomain.ErrProtectedRole
+	}
+
+	_, err := rs.repo.GetRoleByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return err
+		}
+		return cmdomain.ErrInternal
+	}
+
+	err = rs.repo.DeleteRole(ctx, name)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrRoleInUse) {
+			return err
+		}
+		return cmdomain.ErrInternal
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("role", name)
+
+	err = rs.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	err = rs.cache.DeleteByPrefix(ctx, "roles:*")
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// HasPermission checks if a role allows a permission. Unknown roles allow nothing
+func (rs *RoleService) HasPermission(ctx context.Context, name udomain.UserRole, permission domain.Permission) (bool, error) {
+	role, err := rs.GetRole(ctx, name)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return role.HasPermission(permission), nil
+}
+
+// CanGrant checks if the role of the user of a token payload allows every permission and, if the payload
+// is limited to scopes, that every permission is one of them. Unknown roles allow nothing
+func (rs *RoleService) CanGrant(ctx context.Context, grantor *adomain.TokenPayload, permissions []domain.Permission) (bool, error) {
+	role, err := rs.GetRole(ctx, grantor.Role)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, permission := range permissions {
+		if !role.HasPermission(permission) || !grantor.HasScope(permission) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// CanGrantRole checks if the user of a token payload is allowed every permission of a role,
+// so only admins can make other users admins
+func (rs *RoleService) CanGrantRole(ctx context.Context, grantor *adomain.TokenPayload, name udomain.UserRole) (bool, error) {
+	role, err := rs.GetRole(ctx, name)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return false, cmdomain.ErrInvalidRole
+		}
+		return false, err
+	}
+
+	if role.Name == udomain.Admin && grantor.Role != udomain.Admin {
+		return false, nil
+	}
+
+	return rs.CanGrant(ctx, grantor, role.AllowedPermissions())
+}
+
+// authorizePermissions checks that the grantor is allowed every permission, so they can give them to a role
+func (rs *RoleService) authorizePermissions(ctx context.Context, grantor *adomain.TokenPayload, permissions []domain.Permission) error {
+	canGrant, err := rs.CanGrant(ctx, grantor, permissions)
+	if err != nil {
+		return err
+	}
+
+	if !canGrant {
+		return cmdomain.ErrRoleNotGrantable
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"go-restaurant/internal/terminal/domain"
+	"time"
+)
+
+// terminalResponse represents a terminal Response body
+type terminalResponse struct {
+	ID        uint64    `json:"id" example:"1"`
+	Name      string    `json:"name" example:"Front counter"`
+	CreatedAt time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newTerminalResponse is a helper function to create a Response body for handling terminal data
+func newTerminalResponse(terminal *domain.Terminal) terminalResponse {
+	return terminalResponse{
+		ID:        terminal.ID,
+		Name:      terminal.Name,
+		CreatedAt: terminal.CreatedAt,
+		UpdatedAt: terminal.UpdatedAt,
+	}
+}
+
+// terminalRegistrationResponse represents a registered terminal Response body
+type terminalRegistrationResponse struct {
+	terminalResponse
+	Key string `json:"key" example:"bXkgdGVybWluYWwga2V5IGlzIHNlY3JldCBhbmQgcmFuZG9t"`
+}
+
+// newTerminalRegistrationResponse is a helper function to create a Response body for handling
+// a registered terminal and the key it was issued
+func newTerminalRegistrationResponse(terminal *domain.Terminal, key string) terminalRegistrationResponse {
+	return terminalRegistrationResponse{
+		terminalResponse: newTerminalResponse(terminal),
+		Key:              key,
+	}
+}
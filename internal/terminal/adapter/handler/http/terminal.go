@@ -0,0 +1,187 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	cmhttp "go-restaurant/internal/common/adapter/handler/http"
+	cmutil "go-restaurant/internal/common/util"
+	"go-restaurant/internal/terminal/domain"
+	"go-restaurant/internal/terminal/port"
+)
+
+// TerminalHandler represents the HTTP handler for terminal-related requests
+type TerminalHandler struct {
+	svc port.TerminalService
+}
+
+// NewTerminalHandler creates a new TerminalHandler instance
+func NewTerminalHandler(svc port.TerminalService) *TerminalHandler {
+	return &TerminalHandler{
+		svc,
+	}
+}
+
+// registerTerminalRequest represents a request body for registering a new terminal
+type registerTerminalRequest struct {
+	Name string `json:"name" binding:"required" example:"Front counter"`
+}
+
+// RegisterTerminal godoc
+//
+//	@Summary		Register a new terminal
+//	@Description	register a new POS terminal and issue the key it sends to log users in with their PIN. The key is only returned once
+//	@Tags			Terminals
+//	@Accept			json
+//	@Produce		json
+//	@Param			registerTerminalRequest	body		registerTerminalRequest			true	"Register terminal request"
+//	@Success		200						{object}	terminalRegistrationResponse	"Terminal registered"
+//	@Failure		400						{object}	errorResponse					"Validation error"
+//	@Failure		401						{object}	errorResponse					"Unauthorized error"
+//	@Failure		403						{object}	errorResponse					"Forbidden error"
+//	@Failure		500						{object}	errorResponse					"Internal server error"
+//	@Router			/terminals [post]
+//	@Security		BearerAuth
+func (th *TerminalHandler) RegisterTerminal(ctx *gin.Context) {
+	var req registerTerminalRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	terminal := domain.Terminal{
+		Name: req.Name,
+	}
+
+	_, key, err := th.svc.RegisterTerminal(ctx, &terminal)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newTerminalRegistrationResponse(&terminal, key)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// getTerminalRequest represents a request body for retrieving a terminal
+type getTerminalRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetTerminal godoc
+//
+//	@Summary		Get a terminal
+//	@Description	get a terminal by id
+//	@Tags			Terminals
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64				true	"Terminal ID"
+//	@Success		200	{object}	terminalResponse	"Terminal retrieved"
+//	@Failure		400	{object}	errorResponse		"Validation error"
+//	@Failure		401	{object}	errorResponse		"Unauthorized error"
+//	@Failure		403	{object}	errorResponse		"Forbidden error"
+//	@Failure		404	{object}	errorResponse		"Data not found error"
+//	@Failure		500	{object}	errorResponse		"Internal server error"
+//	@Router			/terminals/{id} [get]
+//	@Security		BearerAuth
+func (th *TerminalHandler) GetTerminal(ctx *gin.Context) {
+	var req getTerminalRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	terminal, err := th.svc.GetTerminal(ctx, req.ID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newTerminalResponse(terminal)
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// listTerminalsRequest represents a request body for listing terminals
+type listTerminalsRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListTerminals godoc
+//
+//	@Summary		List terminals
+//	@Description	List registered terminals with pagination
+//	@Tags			Terminals
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Terminals displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/terminals [get]
+//	@Security		BearerAuth
+func (th *TerminalHandler) ListTerminals(ctx *gin.Context) {
+	var req listTerminalsRequest
+	var terminalsList []terminalResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	terminals, err := th.svc.ListTerminals(ctx, req.Skip, req.Limit)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	for _, terminal := range terminals {
+		terminalsList = append(terminalsList, newTerminalResponse(&terminal))
+	}
+
+	total := uint64(len(terminalsList))
+	meta := cmhttp.NewMeta(total, req.Limit, req.Skip)
+	rsp := cmutil.ToMap(meta, terminalsList, "terminals")
+
+	cmhttp.HandleSuccess(ctx, rsp)
+}
+
+// deleteTerminalRequest represents a request body for deleting a terminal
+type deleteTerminalRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeleteTerminal godoc
+//
+//	@Summary		Delete a terminal
+//	@Description	Delete a terminal by id. Its key stops working and users logged in to it are logged out
+//	@Tags			Terminals
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Terminal ID"
+//	@Success		200	{object}	response		"Terminal deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/terminals/{id} [delete]
+//	@Security		BearerAuth
+func (th *TerminalHandler) DeleteTerminal(ctx *gin.Context) {
+	var req deleteTerminalRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		cmhttp.ValidationError(ctx, err)
+		return
+	}
+
+	err := th.svc.DeleteTerminal(ctx, req.ID)
+	if err != nil {
+		cmhttp.HandleError(ctx, err)
+		return
+	}
+
+	cmhttp.HandleSuccess(ctx, nil)
+}
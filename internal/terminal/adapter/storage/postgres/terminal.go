@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"go-restaurant/internal/common/adapter/storage/postgres"
+	cmdomain "go-restaurant/internal/common/domain"
+	"go-restaurant/internal/terminal/domain"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/*TerminalRepository implements port.TerminalRepository interface
+ * and provides access to the postgres database
+ */
+type TerminalRepository struct {
+	db *postgres.DB
+}
+
+// NewTerminalRepository creates a new terminal repository instance
+func NewTerminalRepository(db *postgres.DB) *TerminalRepository {
+	return &TerminalRepository{
+		db,
+	}
+}
+
+// CreateTerminal creates a new terminal record in the database
+func (tr *TerminalRepository) CreateTerminal(ctx context.Context, terminal *domain.Terminal) (*domain.Terminal, error) {
+	query := tr.db.QueryBuilder.Insert("terminals").
+		Columns("name", "key_hash").
+		Values(terminal.Name, terminal.KeyHash).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tr.db.QueryRow(ctx, sql, args...).Scan(
+		&terminal.ID,
+		&terminal.Name,
+		&terminal.KeyHash,
+		&terminal.CreatedAt,
+		&terminal.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return terminal, nil
+}
+
+// GetTerminalByID retrieves a terminal record from the database by id
+func (tr *TerminalRepository) GetTerminalByID(ctx context.Context, id uint64) (*domain.Terminal, error) {
+	return tr.getTerminal(ctx, sq.Eq{"id": id})
+}
+
+// GetTerminalByKeyHash retrieves a terminal record from the database by the hash of its key
+func (tr *TerminalRepository) GetTerminalByKeyHash(ctx context.Context, keyHash string) (*domain.Terminal, error) {
+	return tr.getTerminal(ctx, sq.Eq{"key_hash": keyHash})
+}
+
+// getTerminal retrieves the terminal record matching a condition from the database
+func (tr *TerminalRepository) getTerminal(ctx context.Context, where sq.Eq) (*domain.Terminal, error) {
+	var terminal domain.Terminal
+
+	query := tr.db.QueryBuilder.Select("*").
+		From("terminals").
+		Where(where).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tr.db.QueryRow(ctx, sql, args...).Scan(
+		&terminal.ID,
+		&terminal.Name,
+		&terminal.KeyHash,
+		&terminal.CreatedAt,
+		&terminal.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &terminal, nil
+}
+
+// ListTerminals retrieves a list of terminals from the database
+func (tr *TerminalRepository) ListTerminals(ctx context.Context, skip, limit uint64) ([]domain.Terminal, error) {
+	var terminal domain.Terminal
+	var terminals []domain.Terminal
+
+	query := tr.db.QueryBuilder.Select("*").
+		From("terminals").
+		OrderBy("id").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		err := rows.Scan(
+			&terminal.ID,
+			&terminal.Name,
+			&terminal.KeyHash,
+			&terminal.CreatedAt,
+			&terminal.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		terminals = append(terminals, terminal)
+	}
+
+	return terminals, nil
+}
+
+// DeleteTerminal deletes a terminal record from the database by id
+func (tr *TerminalRepository) DeleteTerminal(ctx context.Context, id uint64) error {
+	query := tr.db.QueryBuilder.Delete("terminals").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"time"
+)
+
+// Terminal is an entity that represents a registered POS terminal that users can log in to with their PIN
+type Terminal struct {
+	ID        uint64
+	Name      string
+	KeyHash   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
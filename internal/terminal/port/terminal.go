@@ -0,0 +1,36 @@
+package port
+
+import (
+	"context"
+	"go-restaurant/internal/terminal/domain"
+)
+
+//go:generate mockgen -source=terminal.go -destination=mock/terminal.go -package=mock
+
+// TerminalRepository is an interface for interacting with terminal-related data
+type TerminalRepository interface {
+	// CreateTerminal inserts a new terminal into the database
+	CreateTerminal(ctx context.Context, terminal *domain.Terminal) (*domain.Terminal, error)
+	// GetTerminalByID selects a terminal by id
+	GetTerminalByID(ctx context.Context, id uint64) (*domain.Terminal, error)
+	// GetTerminalByKeyHash selects a terminal by the hash of its key
+	GetTerminalByKeyHash(ctx context.Context, keyHash string) (*domain.Terminal, error)
+	// ListTerminals selects a list of terminals with pagination
+	ListTerminals(ctx context.Context, skip, limit uint64) ([]domain.Terminal, error)
+	// DeleteTerminal deletes a terminal
+	DeleteTerminal(ctx context.Context, id uint64) error
+}
+
+// TerminalService is an interface for interacting with terminal-related business logic
+type TerminalService interface {
+	// RegisterTerminal registers a new terminal and returns the key it authenticates with
+	RegisterTerminal(ctx context.Context, terminal *domain.Terminal) (*domain.Terminal, string, error)
+	// GetTerminal returns a terminal by id
+	GetTerminal(ctx context.Context, id uint64) (*domain.Terminal, error)
+	// ListTerminals returns a list of terminals with pagination
+	ListTerminals(ctx context.Context, skip, limit uint64) ([]domain.Terminal, error)
+	// DeleteTerminal deletes a terminal
+	DeleteTerminal(ctx context.Context, id uint64) error
+	// AuthenticateTerminal returns the terminal a key was issued to
+	AuthenticateTerminal(ctx context.Context, key string) (*domain.Terminal, error)
+}
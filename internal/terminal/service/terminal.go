@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
+	cmutil "go-restaurant/internal/common/util"
+	"go-restaurant/internal/terminal/domain"
+	"go-restaurant/internal/terminal/port"
+)
+
+/*TerminalService implements port.TerminalService interface
+ * and provides access to the terminal repository
+ * and cache service
+ */
+type TerminalService struct {
+	repo  port.TerminalRepository
+	cache cmport.CacheRepository
+}
+
+// NewTerminalService creates a new terminal service instance
+func NewTerminalService(repo port.TerminalRepository, cache cmport.CacheRepository) *TerminalService {
+	return &TerminalService{
+		repo,
+		cache,
+	}
+}
+
+// RegisterTerminal registers a new terminal and generates the key it authenticates with.
+// Only the hash of the key is stored, so it is returned this one time
+func (ts *TerminalService) RegisterTerminal(ctx context.Context, terminal *domain.Terminal) (*domain.Terminal, string, error) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return nil, "", cmdomain.ErrInternal
+	}
+
+	key := base64.RawURLEncoding.EncodeToString(secret)
+	terminal.KeyHash = hashTerminalKey(key)
+
+	terminal, err = ts.repo.CreateTerminal(ctx, terminal)
+	if err != nil {
+		return nil, "", cmdomain.ErrInternal
+	}
+
+	err = ts.cache.DeleteByPrefix(ctx, "terminals:*")
+	if err != nil {
+		return nil, "", cmdomain.ErrInternal
+	}
+
+	return terminal, key, nil
+}
+
+// GetTerminal retrieves a terminal by id
+func (ts *TerminalService) GetTerminal(ctx context.Context, id uint64) (*domain.Terminal, error) {
+	var terminal *domain.Terminal
+
+	cacheKey := cmutil.GenerateCacheKey("terminal", id)
+	cachedTerminal, err := ts.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := cmutil.Deserialize(cachedTerminal, &terminal)
+		if err != nil {
+			return nil, cmdomain.ErrInternal
+		}
+		return terminal, nil
+	}
+
+	terminal, err = ts.repo.GetTerminalByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	terminalSerialized, err := cmutil.Serialize(terminal)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = ts.cache.Set(ctx, cacheKey, terminalSerialized, 0)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return terminal, nil
+}
+
+// ListTerminals retrieves a list of terminals
+func (ts *TerminalService) ListTerminals(ctx context.Context, skip, limit uint64) ([]domain.Terminal, error) {
+	var terminals []domain.Terminal
+
+	params := cmutil.GenerateCacheKeyParams(skip, limit)
+	cacheKey := cmutil.GenerateCacheKey("terminals", params)
+
+	cachedTerminals, err := ts.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := cmutil.Deserialize(cachedTerminals, &terminals)
+		if err != nil {
+			return nil, cmdomain.ErrInternal
+		}
+
+		return terminals, nil
+	}
+
+	terminals, err = ts.repo.ListTerminals(ctx, skip, limit)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	terminalsSerialized, err := cmutil.Serialize(terminals)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = ts.cache.Set(ctx, cacheKey, terminalsSerialized, 0)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return terminals, nil
+}
+
+// DeleteTerminal deletes a terminal by id, which also revokes the tokens users got by logging in to it
+func (ts *TerminalService) DeleteTerminal(ctx context.Context, id uint64) error {
+	_, err := ts.repo.GetTerminalByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return err
+		}
+		return cmdomain.ErrInternal
+	}
+
+	err = ts.repo.DeleteTerminal(ctx, id)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("terminal", id)
+
+	err = ts.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	err = ts.cache.DeleteByPrefix(ctx, "terminals:*")
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// AuthenticateTerminal returns the terminal a key was issued to
+func (ts *TerminalService) AuthenticateTerminal(ctx context.Context, key string) (*domain.Terminal, error) {
+	terminal, err := ts.repo.GetTerminalByKeyHash(ctx, hashTerminalKey(key))
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, cmdomain.ErrInvalidTerminal
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	return terminal, nil
+}
+
+// hashTerminalKey hashes a terminal key, which is random enough that a fast hash is safe
+func hashTerminalKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	autil "go-restaurant/internal/auth/util"
+	chttp "go-restaurant/internal/common/adapter/handler/http"
+	"go-restaurant/internal/common/util"
+	"go-restaurant/internal/user/domain"
+	"go-restaurant/internal/user/port"
+)
+
+// InviteHandler represents the HTTP handler for invite-related requests
+type InviteHandler struct {
+	svc port.InviteService
+}
+
+// NewInviteHandler creates a new InviteHandler instance
+func NewInviteHandler(svc port.InviteService) *InviteHandler {
+	return &InviteHandler{
+		svc,
+	}
+}
+
+// createInviteRequest represents the request body for creating an invite
+type createInviteRequest struct {
+	Role domain.UserRole `json:"role" binding:"required,user_role" example:"cashier"`
+}
+
+// CreateInvite godoc
+//
+//	@Summary		Create an invite
+//	@Description	create an invite code to register a user with a role that allows no more than the role of the current user. The code can be used once within seven days and is only returned now
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			createInviteRequest	body		createInviteRequest	true	"Create invite request"
+//	@Success		200					{object}	inviteCodeResponse	"Invite created"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/invites [post]
+//	@Security		BearerAuth
+func (ih *InviteHandler) CreateInvite(ctx *gin.Context) {
+	var req createInviteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		chttp.ValidationError(ctx, err)
+		return
+	}
+
+	authPayload := autil.GetAuthPayload(ctx, chttp.AuthorizationPayloadKey)
+
+	invite := domain.Invite{
+		Role:      req.Role,
+		CreatedBy: authPayload.UserID,
+	}
+
+	_, code, err := ih.svc.CreateInvite(ctx, authPayload, &invite)
+	if err != nil {
+		chttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newInviteCodeResponse(&invite, code)
+
+	chttp.HandleSuccess(ctx, rsp)
+}
+
+// listInvitesRequest represents the request body for listing invites
+type listInvitesRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListInvites godoc
+//
+//	@Summary		List invites
+//	@Description	List invites with pagination, newest first
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Invites displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/invites [get]
+//	@Security		BearerAuth
+func (ih *InviteHandler) ListInvites(ctx *gin.Context) {
+	var req listInvitesRequest
+	var invitesList []inviteResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		chttp.ValidationError(ctx, err)
+		return
+	}
+
+	invites, err := ih.svc.ListInvites(ctx, req.Skip, req.Limit)
+	if err != nil {
+		chttp.HandleError(ctx, err)
+		return
+	}
+
+	for _, invite := range invites {
+		invitesList = append(invitesList, newInviteResponse(&invite))
+	}
+
+	total := uint64(len(invitesList))
+	meta := chttp.NewMeta(total, req.Limit, req.Skip)
+	rsp := cmutil.ToMap(meta, invitesList, "invites")
+
+	chttp.HandleSuccess(ctx, rsp)
+}
+
+// deleteInviteRequest represents the request body for deleting an invite
+type deleteInviteRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeleteInvite godoc
+//
+//	@Summary		Delete an invite
+//	@Description	Delete an invite by id, so its code can no longer be used to register
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Invite ID"
+//	@Success		200	{object}	response		"Invite deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/invites/{id} [delete]
+//	@Security		BearerAuth
+func (ih *InviteHandler) DeleteInvite(ctx *gin.Context) {
+	var req deleteInviteRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		chttp.ValidationError(ctx, err)
+		return
+	}
+
+	err := ih.svc.DeleteInvite(ctx, req.ID)
+	if err != nil {
+		chttp.HandleError(ctx, err)
+		return
+	}
+
+	chttp.HandleSuccess(ctx, nil)
+}
@@ -10,6 +10,7 @@ type userResponse struct {
 	ID        uint64    `json:"id" example:"1"`
 	Name      string    `json:"name" example:"John Doe"`
 	Email     string    `json:"email" example:"test@example.com"`
+	Role      string    `json:"role" example:"cashier"`
 	CreatedAt time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
 	UpdatedAt time.Time `json:"updated_at" example:"1970-01-01T00:00:00Z"`
 }
@@ -20,7 +21,46 @@ func newUserResponse(user *domain.User) userResponse {
 		ID:        user.ID,
 		Name:      user.Name,
 		Email:     user.Email,
+		Role:      string(user.Role),
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
 }
+
+// inviteResponse represents an invite response body
+type inviteResponse struct {
+	ID        uint64     `json:"id" example:"1"`
+	Role      string     `json:"role" example:"cashier"`
+	CreatedBy uint64     `json:"created_by" example:"1"`
+	UsedBy    *uint64    `json:"used_by" example:"2"`
+	UsedAt    *time.Time `json:"used_at" example:"1970-01-01T00:00:00Z"`
+	ExpiresAt time.Time  `json:"expires_at" example:"1970-01-08T00:00:00Z"`
+	CreatedAt time.Time  `json:"created_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// newInviteResponse is a helper function to create a response body for handling invite data
+func newInviteResponse(invite *domain.Invite) inviteResponse {
+	return inviteResponse{
+		ID:        invite.ID,
+		Role:      string(invite.Role),
+		CreatedBy: invite.CreatedBy,
+		UsedBy:    invite.UsedBy,
+		UsedAt:    invite.UsedAt,
+		ExpiresAt: invite.ExpiresAt,
+		CreatedAt: invite.CreatedAt,
+	}
+}
+
+// inviteCodeResponse represents a created invite response body, with the code to register with
+type inviteCodeResponse struct {
+	inviteResponse
+	Code string `json:"code" example:"pQm0Yk2x7HcV1bN5tR8wZg"`
+}
+
+// newInviteCodeResponse is a helper function to create a response body for handling a created invite and its code
+func newInviteCodeResponse(invite *domain.Invite, code string) inviteCodeResponse {
+	return inviteCodeResponse{
+		inviteResponse: newInviteResponse(invite),
+		Code:           code,
+	}
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"github.com/gin-gonic/gin"
+	autil "go-restaurant/internal/auth/util"
 	chttp "go-restaurant/internal/common/adapter/handler/http"
 	"go-restaurant/internal/common/util"
 	"go-restaurant/internal/user/domain"
@@ -22,23 +23,23 @@ func NewUserHandler(svc port.UserService) *UserHandler {
 
 // registerRequest represents the request body for creating a user
 type registerRequest struct {
-	Name     string `json:"name" binding:"required" example:"John Doe"`
-	Email    string `json:"email" binding:"required,email" example:"test@example.com"`
-	Password string `json:"password" binding:"required,min=8" example:"12345678"`
+	Name       string `json:"name" binding:"required" example:"John Doe"`
+	Email      string `json:"email" binding:"required,email" example:"test@example.com"`
+	Password   string `json:"password" binding:"required,min=8" example:"12345678"`
+	InviteCode string `json:"invite_code" binding:"required" example:"pQm0Yk2x7HcV1bN5tR8wZg"`
 }
 
 // Register godoc
 //
 //	@Summary		Register a new user
-//	@Description	create a new user account with default role "cashier"
+//	@Description	create a new user account with an invite code, which gives the account the role of the invite. Fails if registration is disabled
 //	@Tags			Users
 //	@Accept			json
 //	@Produce		json
 //	@Param			registerRequest	body		registerRequest	true	"Register request"
 //	@Success		200				{object}	userResponse	"User created"
 //	@Failure		400				{object}	errorResponse	"Validation error"
-//	@Failure		401				{object}	errorResponse	"Unauthorized error"
-//	@Failure		404				{object}	errorResponse	"Data not found error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
 //	@Failure		409				{object}	errorResponse	"Data conflict error"
 //	@Failure		500				{object}	errorResponse	"Internal server error"
 //	@Router			/users [post]
@@ -55,7 +56,7 @@ func (uh *UserHandler) Register(ctx *gin.Context) {
 		Password: req.Password,
 	}
 
-	_, err := uh.svc.Register(ctx, &user)
+	_, err := uh.svc.Register(ctx, &user, req.InviteCode)
 	if err != nil {
 		chttp.HandleError(ctx, err)
 		return
@@ -160,7 +161,7 @@ type updateUserRequest struct {
 // UpdateUser godoc
 //
 //	@Summary		Update a user
-//	@Description	Update a user's name, email, password, or role by id
+//	@Description	Update a user's name, email, password, or role by id. Users can only update users whose current and new role allow no more than their own role
 //	@Tags			Users
 //	@Accept			json
 //	@Produce		json
@@ -188,6 +189,8 @@ func (uh *UserHandler) UpdateUser(ctx *gin.Context) {
 		return
 	}
 
+	authPayload := autil.GetAuthPayload(ctx, chttp.AuthorizationPayloadKey)
+
 	user := domain.User{
 		ID:       id,
 		Name:     req.Name,
@@ -196,7 +199,7 @@ func (uh *UserHandler) UpdateUser(ctx *gin.Context) {
 		Role:     req.Role,
 	}
 
-	_, err = uh.svc.UpdateUser(ctx, &user)
+	_, err = uh.svc.UpdateUser(ctx, authPayload, &user)
 	if err != nil {
 		chttp.HandleError(ctx, err)
 		return
@@ -215,7 +218,7 @@ type deleteUserRequest struct {
 // DeleteUser godoc
 //
 //	@Summary		Delete a user
-//	@Description	Delete a user by id
+//	@Description	Delete a user by id. Only users whose role the current user could give can be deleted, and the last admin cannot be deleted
 //	@Tags			Users
 //	@Accept			json
 //	@Produce		json
@@ -225,6 +228,7 @@ type deleteUserRequest struct {
 //	@Failure		401	{object}	errorResponse	"Unauthorized error"
 //	@Failure		403	{object}	errorResponse	"Forbidden error"
 //	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		409	{object}	errorResponse	"Data conflict error"
 //	@Failure		500	{object}	errorResponse	"Internal server error"
 //	@Router			/users/{id} [delete]
 //	@Security		BearerAuth
@@ -235,7 +239,129 @@ func (uh *UserHandler) DeleteUser(ctx *gin.Context) {
 		return
 	}
 
-	err := uh.svc.DeleteUser(ctx, req.ID)
+	authPayload := autil.GetAuthPayload(ctx, chttp.AuthorizationPayloadKey)
+
+	err := uh.svc.DeleteUser(ctx, authPayload, req.ID)
+	if err != nil {
+		chttp.HandleError(ctx, err)
+		return
+	}
+
+	chttp.HandleSuccess(ctx, nil)
+}
+
+// restoreUserRequest represents the request body for restoring a deleted user
+type restoreUserRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// RestoreUser godoc
+//
+//	@Summary		Restore a user
+//	@Description	Restore a deleted user by id. Only users whose role the current user could give can be restored
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"User ID"
+//	@Success		200	{object}	userResponse	"User restored"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		409	{object}	errorResponse	"Data conflict error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/users/{id}/restore [post]
+//	@Security		BearerAuth
+func (uh *UserHandler) RestoreUser(ctx *gin.Context) {
+	var req restoreUserRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		chttp.ValidationError(ctx, err)
+		return
+	}
+
+	authPayload := autil.GetAuthPayload(ctx, chttp.AuthorizationPayloadKey)
+
+	user, err := uh.svc.RestoreUser(ctx, authPayload, req.ID)
+	if err != nil {
+		chttp.HandleError(ctx, err)
+		return
+	}
+
+	rsp := newUserResponse(user)
+
+	chttp.HandleSuccess(ctx, rsp)
+}
+
+// setPINRequest represents the request body for setting the approval PIN of the current user
+type setPINRequest struct {
+	Password string `json:"password" binding:"required,min=8" example:"12345678"`
+	PIN      string `json:"pin" binding:"required,numeric,min=4,max=8" example:"4821"`
+}
+
+// SetPIN godoc
+//
+//	@Summary		Set the approval PIN
+//	@Description	Set the numeric PIN the current user enters to approve sensitive actions of other users, such as voiding an order. The current password is required
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			setPINRequest	body		setPINRequest	true	"Set PIN request"
+//	@Success		200				{object}	response		"PIN set"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		401				{object}	errorResponse	"Unauthorized error"
+//	@Failure		404				{object}	errorResponse	"Data not found error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
+//	@Router			/users/pin [put]
+//	@Security		BearerAuth
+func (uh *UserHandler) SetPIN(ctx *gin.Context) {
+	var req setPINRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		chttp.ValidationError(ctx, err)
+		return
+	}
+
+	authPayload := autil.GetAuthPayload(ctx, chttp.AuthorizationPayloadKey)
+
+	err := uh.svc.SetPIN(ctx, authPayload.UserID, req.Password, req.PIN)
+	if err != nil {
+		chttp.HandleError(ctx, err)
+		return
+	}
+
+	chttp.HandleSuccess(ctx, nil)
+}
+
+// changePasswordRequest represents the request body for changing the password of the current user
+type changePasswordRequest struct {
+	OldPassword string `json:"old_password" binding:"required,min=8" example:"12345678"`
+	NewPassword string `json:"new_password" binding:"required,min=8" example:"87654321"`
+}
+
+// ChangePassword godoc
+//
+//	@Summary		Change the password
+//	@Description	Change the password of the current user after verifying the old one. Every session of the user is logged out, including the current one
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			changePasswordRequest	body		changePasswordRequest	true	"Change password request"
+//	@Success		200						{object}	response				"Password changed"
+//	@Failure		400						{object}	errorResponse			"Validation error"
+//	@Failure		401						{object}	errorResponse			"Unauthorized error"
+//	@Failure		404						{object}	errorResponse			"Data not found error"
+//	@Failure		500						{object}	errorResponse			"Internal server error"
+//	@Router			/users/password [put]
+//	@Security		BearerAuth
+func (uh *UserHandler) ChangePassword(ctx *gin.Context) {
+	var req changePasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		chttp.ValidationError(ctx, err)
+		return
+	}
+
+	authPayload := autil.GetAuthPayload(ctx, chttp.AuthorizationPayloadKey)
+
+	err := uh.svc.ChangePassword(ctx, authPayload.UserID, req.OldPassword, req.NewPassword)
 	if err != nil {
 		chttp.HandleError(ctx, err)
 		return
@@ -3,16 +3,16 @@ package http
 import (
 	"github.com/go-playground/validator/v10"
 	"go-restaurant/internal/user/domain"
+	"regexp"
 )
 
-// UserRoleValidator is a custom validator for validating user roles
+// userRolePattern matches role names made of lowercase letters, digits and underscores
+var userRolePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// UserRoleValidator is a custom validator for validating user role names.
+// Roles are stored in the database, so whether the role exists is checked when it is saved
 var UserRoleValidator validator.Func = func(fl validator.FieldLevel) bool {
 	userRole := fl.Field().Interface().(domain.UserRole)
 
-	switch userRole {
-	case "admin", "cashier":
-		return true
-	default:
-		return false
-	}
+	return userRolePattern.MatchString(string(userRole))
 }
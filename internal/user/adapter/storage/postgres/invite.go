@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"go-restaurant/internal/common/adapter/storage/postgres"
+	cmdomain "go-restaurant/internal/common/domain"
+	"go-restaurant/internal/user/domain"
+	"time"
+)
+
+// InviteRepository implements port.InviteRepository interface
+// and provides access to the postgres database
+type InviteRepository struct {
+	db *postgres.DB
+}
+
+// NewInviteRepository creates a new invite repository instance
+func NewInviteRepository(db *postgres.DB) *InviteRepository {
+	return &InviteRepository{
+		db,
+	}
+}
+
+// CreateInvite creates a new invite in the database
+func (ir *InviteRepository) CreateInvite(ctx context.Context, invite *domain.Invite) (*domain.Invite, error) {
+	query := ir.db.QueryBuilder.Insert("invites").
+		Columns("code_hash", "role", "created_by", "expires_at").
+		Values(invite.CodeHash, invite.Role, invite.CreatedBy, invite.ExpiresAt).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanInvite(ir.db.QueryRow(ctx, sql, args...), invite)
+	if err != nil {
+		if errCode := ir.db.ErrorCode(err); errCode == "23503" {
+			return nil, cmdomain.ErrInvalidRole
+		}
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+// GetInviteByID gets an invite by ID from the database
+func (ir *InviteRepository) GetInviteByID(ctx context.Context, id uint64) (*domain.Invite, error) {
+	var invite domain.Invite
+
+	query := ir.db.QueryBuilder.Select("*").
+		From("invites").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanInvite(ir.db.QueryRow(ctx, sql, args...), &invite)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+// ListInvites lists all invites from the database, newest first
+func (ir *InviteRepository) ListInvites(ctx context.Context, skip, limit uint64) ([]domain.Invite, error) {
+	var invite domain.Invite
+	var invites []domain.Invite
+
+	query := ir.db.QueryBuilder.Select("*").
+		From("invites").
+		OrderBy("id DESC").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ir.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		err := scanInvite(rows, &invite)
+		if err != nil {
+			return nil, err
+		}
+
+		invites = append(invites, invite)
+	}
+
+	return invites, nil
+}
+
+// DeleteInvite deletes an invite by ID from the database
+func (ir *InviteRepository) DeleteInvite(ctx context.Context, id uint64) error {
+	query := ir.db.QueryBuilder.Delete("invites").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = ir.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RedeemInvite creates a new user with the role of an unused and unexpired invite in the database
+// and marks the invite as used by them, inside a transaction so an invite is only redeemed once
+func (ir *InviteRepository) RedeemInvite(ctx context.Context, codeHash string, user *domain.User) (*domain.User, error) {
+	tx, err := ir.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := ir.db.QueryBuilder.Select("role").
+		From("invites").
+		Where(sq.Eq{"code_hash": codeHash}).
+		Where(sq.Eq{"used_at": nil}).
+		Where(sq.Gt{"expires_at": time.Now()}).
+		Suffix("FOR UPDATE")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(&user.Role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrInvalidInvite
+		}
+		return nil, err
+	}
+
+	userQuery := ir.db.QueryBuilder.Insert("users").
+		Columns("name", "email", "password", "role").
+		Values(user.Name, user.Email, user.Password, user.Role).
+		Suffix("RETURNING *")
+
+	sql, args, err = userQuery.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.Password,
+		&user.Role,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.PIN,
+		&user.DeletedAt,
+	)
+	if err != nil {
+		if errCode := ir.db.ErrorCode(err); errCode == "23505" {
+			return nil, cmdomain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	inviteQuery := ir.db.QueryBuilder.Update("invites").
+		Set("used_by", user.ID).
+		Set("used_at", time.Now()).
+		Where(sq.Eq{"code_hash": codeHash})
+
+	sql, args, err = inviteQuery.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// scanInvite scans an invites row into an invite
+func scanInvite(row pgx.Row, invite *domain.Invite) error {
+	return row.Scan(
+		&invite.ID,
+		&invite.CodeHash,
+		&invite.Role,
+		&invite.CreatedBy,
+		&invite.UsedBy,
+		&invite.UsedAt,
+		&invite.ExpiresAt,
+		&invite.CreatedAt,
+	)
+}
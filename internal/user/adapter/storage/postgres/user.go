@@ -25,11 +25,19 @@ func NewUserRepository(db *postgres.DB) *UserRepository {
 	}
 }
 
-// CreateUser creates a new user in the database
+// CreateUser creates a new user in the database, with the default role unless one is given
 func (ur *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	columns := []string{"name", "email", "password"}
+	values := []any{user.Name, user.Email, user.Password}
+
+	if user.Role != "" {
+		columns = append(columns, "role")
+		values = append(values, user.Role)
+	}
+
 	query := ur.db.QueryBuilder.Insert("users").
-		Columns("name", "email", "password").
-		Values(user.Name, user.Email, user.Password).
+		Columns(columns...).
+		Values(values...).
 		Suffix("RETURNING *")
 
 	sql, args, err := query.ToSql()
@@ -45,6 +53,8 @@ func (ur *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*d
 		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.PIN,
+		&user.DeletedAt,
 	)
 	if err != nil {
 		if errCode := ur.db.ErrorCode(err); errCode == "23505" {
@@ -56,10 +66,46 @@ func (ur *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*d
 	return user, nil
 }
 
-// GetUserByID gets a user by ID from the database
+// GetUserByID gets a user that is not deleted by ID from the database
 func (ur *UserRepository) GetUserByID(ctx context.Context, id uint64) (*domain.User, error) {
 	var user domain.User
 
+	query := ur.db.QueryBuilder.Select("*").
+		From("users").
+		Where(sq.Eq{"id": id, "deleted_at": nil}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ur.db.QueryRow(ctx, sql, args...).Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.Password,
+		&user.Role,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.PIN,
+		&user.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// GetUserByIDIncludingDeleted retrieves a user record from the database by id,
+// even if it is deleted, so the orders it is part of still resolve it
+func (ur *UserRepository) GetUserByIDIncludingDeleted(ctx context.Context, id uint64) (*domain.User, error) {
+	var user domain.User
+
 	query := ur.db.QueryBuilder.Select("*").
 		From("users").
 		Where(sq.Eq{"id": id}).
@@ -78,6 +124,8 @@ func (ur *UserRepository) GetUserByID(ctx context.Context, id uint64) (*domain.U
 		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.PIN,
+		&user.DeletedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -89,13 +137,13 @@ func (ur *UserRepository) GetUserByID(ctx context.Context, id uint64) (*domain.U
 	return &user, nil
 }
 
-// GetUserByEmail gets a user by email from the database
+// GetUserByEmail gets a user that is not deleted by email from the database
 func (ur *UserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
 
 	query := ur.db.QueryBuilder.Select("*").
 		From("users").
-		Where(sq.Eq{"email": email}).
+		Where(sq.Eq{"email": email, "deleted_at": nil}).
 		Limit(1)
 
 	sql, args, err := query.ToSql()
@@ -111,6 +159,8 @@ func (ur *UserRepository) GetUserByEmail(ctx context.Context, email string) (*do
 		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.PIN,
+		&user.DeletedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -129,6 +179,7 @@ func (ur *UserRepository) ListUsers(ctx context.Context, skip, limit uint64) ([]
 
 	query := ur.db.QueryBuilder.Select("*").
 		From("users").
+		Where(sq.Eq{"deleted_at": nil}).
 		OrderBy("id").
 		Limit(limit).
 		Offset((skip - 1) * limit)
@@ -153,6 +204,8 @@ func (ur *UserRepository) ListUsers(ctx context.Context, skip, limit uint64) ([]
 			&user.Role,
 			&user.CreatedAt,
 			&user.UpdatedAt,
+			&user.PIN,
+			&user.DeletedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -164,18 +217,41 @@ func (ur *UserRepository) ListUsers(ctx context.Context, skip, limit uint64) ([]
 	return users, nil
 }
 
+// CountUsersByRole counts the users with a role that are not deleted in the database
+func (ur *UserRepository) CountUsersByRole(ctx context.Context, role domain.UserRole) (uint64, error) {
+	var count uint64
+
+	query := ur.db.QueryBuilder.Select("COUNT(*)").
+		From("users").
+		Where(sq.Eq{"role": role, "deleted_at": nil})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	err = ur.db.QueryRow(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 // UpdateUser updates a user by ID in the database
 func (ur *UserRepository) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
 	name := cmutil.NullString(user.Name)
 	email := cmutil.NullString(user.Email)
 	password := cmutil.NullString(user.Password)
 	role := cmutil.NullString(string(user.Role))
+	pin := cmutil.NullString(user.PIN)
 
 	query := ur.db.QueryBuilder.Update("users").
 		Set("name", sq.Expr("COALESCE(?, name)", name)).
 		Set("email", sq.Expr("COALESCE(?, email)", email)).
 		Set("password", sq.Expr("COALESCE(?, password)", password)).
 		Set("role", sq.Expr("COALESCE(?, role)", role)).
+		Set("pin", sq.Expr("COALESCE(?, pin)", pin)).
 		Set("updated_at", time.Now()).
 		Where(sq.Eq{"id": user.ID}).
 		Suffix("RETURNING *")
@@ -193,10 +269,14 @@ func (ur *UserRepository) UpdateUser(ctx context.Context, user *domain.User) (*d
 		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.PIN,
+		&user.DeletedAt,
 	)
 	if err != nil {
 		if errCode := ur.db.ErrorCode(err); errCode == "23505" {
 			return nil, cmdomain.ErrConflictingData
+		} else if errCode == "23503" {
+			return nil, cmdomain.ErrInvalidRole
 		}
 		return nil, err
 	}
@@ -204,10 +284,11 @@ func (ur *UserRepository) UpdateUser(ctx context.Context, user *domain.User) (*d
 	return user, nil
 }
 
-// DeleteUser deletes a user by ID from the database
+// DeleteUser soft deletes a user record in the database by id, so the orders it is part of keep referring to it
 func (ur *UserRepository) DeleteUser(ctx context.Context, id uint64) error {
-	query := ur.db.QueryBuilder.Delete("users").
-		Where(sq.Eq{"id": id})
+	query := ur.db.QueryBuilder.Update("users").
+		Set("deleted_at", time.Now()).
+		Where(sq.Eq{"id": id, "deleted_at": nil})
 
 	sql, args, err := query.ToSql()
 	if err != nil {
@@ -221,3 +302,41 @@ func (ur *UserRepository) DeleteUser(ctx context.Context, id uint64) error {
 
 	return nil
 }
+
+// RestoreUser restores a soft deleted user record in the database by id
+func (ur *UserRepository) RestoreUser(ctx context.Context, id uint64) (*domain.User, error) {
+	var user domain.User
+
+	query := ur.db.QueryBuilder.Update("users").
+		Set("deleted_at", nil).
+		Where(sq.And{sq.Eq{"id": id}, sq.NotEq{"deleted_at": nil}}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ur.db.QueryRow(ctx, sql, args...).Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.Password,
+		&user.Role,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.PIN,
+		&user.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cmdomain.ErrDataNotFound
+		}
+		if errCode := ur.db.ErrorCode(err); errCode == "23505" {
+			return nil, cmdomain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
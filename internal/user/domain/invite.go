@@ -0,0 +1,40 @@
+package domain
+
+import (
+	cmdomain "go-restaurant/internal/common/domain"
+	"time"
+)
+
+// Registration is an enum for how new users can register themselves
+type Registration string
+
+// Registration enum values
+const (
+	RegistrationInvite   Registration = "invite"
+	RegistrationDisabled Registration = "disabled"
+)
+
+// NewRegistration parses the configured registration mode, which is invite-only by default
+func NewRegistration(mode string) (Registration, error) {
+	switch Registration(mode) {
+	case "", RegistrationInvite:
+		return RegistrationInvite, nil
+	case RegistrationDisabled:
+		return RegistrationDisabled, nil
+	default:
+		return "", cmdomain.ErrInvalidRegistration
+	}
+}
+
+// Invite is an entity that represents an invitation to register with a role,
+// which can be used once before it expires
+type Invite struct {
+	ID        uint64
+	CodeHash  string
+	Role      UserRole
+	CreatedBy uint64
+	UsedBy    *uint64
+	UsedAt    *time.Time
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
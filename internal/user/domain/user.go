@@ -20,6 +20,8 @@ type User struct {
 	Email     string
 	Password  string
 	Role      UserRole
+	PIN       string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	DeletedAt *time.Time
 }
@@ -0,0 +1,33 @@
+package port
+
+import (
+	"context"
+	adomain "go-restaurant/internal/auth/domain"
+	"go-restaurant/internal/user/domain"
+)
+
+//go:generate mockgen -source=invite.go -destination=mock/invite.go -package=mock
+
+// InviteRepository is an interface for interacting with invite-related data
+type InviteRepository interface {
+	// CreateInvite inserts a new invite into the database
+	CreateInvite(ctx context.Context, invite *domain.Invite) (*domain.Invite, error)
+	// GetInviteByID selects an invite by id
+	GetInviteByID(ctx context.Context, id uint64) (*domain.Invite, error)
+	// ListInvites selects a list of invites with pagination
+	ListInvites(ctx context.Context, skip, limit uint64) ([]domain.Invite, error)
+	// DeleteInvite deletes an invite
+	DeleteInvite(ctx context.Context, id uint64) error
+	// RedeemInvite inserts a new user with the role of an unused invite and marks the invite as used
+	RedeemInvite(ctx context.Context, codeHash string, user *domain.User) (*domain.User, error)
+}
+
+// InviteService is an interface for interacting with invite-related business logic
+type InviteService interface {
+	// CreateInvite creates a new invite, if the grantor could give its role, and returns the code to register with
+	CreateInvite(ctx context.Context, grantor *adomain.TokenPayload, invite *domain.Invite) (*domain.Invite, string, error)
+	// ListInvites returns a list of invites with pagination
+	ListInvites(ctx context.Context, skip, limit uint64) ([]domain.Invite, error)
+	// DeleteInvite deletes an invite
+	DeleteInvite(ctx context.Context, id uint64) error
+}
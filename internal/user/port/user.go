@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	adomain "go-restaurant/internal/auth/domain"
 	"go-restaurant/internal/user/domain"
 )
 
@@ -13,26 +14,44 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, user *domain.User) (*domain.User, error)
 	// GetUserByID selects a user by id
 	GetUserByID(ctx context.Context, id uint64) (*domain.User, error)
+	// GetUserByIDIncludingDeleted selects a user by id, even if it is deleted
+	GetUserByIDIncludingDeleted(ctx context.Context, id uint64) (*domain.User, error)
 	// GetUserByEmail selects a user by email
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
 	// ListUsers selects a list of users with pagination
 	ListUsers(ctx context.Context, skip, limit uint64) ([]domain.User, error)
+	// CountUsersByRole counts the users with a role
+	CountUsersByRole(ctx context.Context, role domain.UserRole) (uint64, error)
 	// UpdateUser updates a user
 	UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error)
-	// DeleteUser deletes a user
+	// DeleteUser soft deletes a user
 	DeleteUser(ctx context.Context, id uint64) error
+	// RestoreUser restores a soft deleted user
+	RestoreUser(ctx context.Context, id uint64) (*domain.User, error)
 }
 
 // UserService is an interface for interacting with user-related business logic
 type UserService interface {
-	// Register registers a new user
-	Register(ctx context.Context, user *domain.User) (*domain.User, error)
+	// Register registers a new user with the role of an invite
+	Register(ctx context.Context, user *domain.User, inviteCode string) (*domain.User, error)
+	// BootstrapAdmin creates the initial admin if there is no admin yet
+	BootstrapAdmin(ctx context.Context, user *domain.User) (*domain.User, error)
+	// ProvisionUser creates a user linked to their account at an identity provider
+	ProvisionUser(ctx context.Context, identity *adomain.UserIdentity, user *domain.User) (*domain.User, error)
+	// SyncRole gives a user provisioned by single sign-on the role mapped from their identity provider groups
+	SyncRole(ctx context.Context, id uint64, role domain.UserRole) (*domain.User, error)
 	// GetUser returns a user by id
 	GetUser(ctx context.Context, id uint64) (*domain.User, error)
 	// ListUsers returns a list of users with pagination
 	ListUsers(ctx context.Context, skip, limit uint64) ([]domain.User, error)
-	// UpdateUser updates a user
-	UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error)
-	// DeleteUser deletes a user
-	DeleteUser(ctx context.Context, id uint64) error
+	// UpdateUser updates a user, if the grantor could give the user their current and new role
+	UpdateUser(ctx context.Context, grantor *adomain.TokenPayload, user *domain.User) (*domain.User, error)
+	// DeleteUser soft deletes a user, if the grantor could give the user their role and they are not the last admin
+	DeleteUser(ctx context.Context, grantor *adomain.TokenPayload, id uint64) error
+	// RestoreUser restores a soft deleted user, if the grantor could give the user their role
+	RestoreUser(ctx context.Context, grantor *adomain.TokenPayload, id uint64) (*domain.User, error)
+	// SetPIN sets the approval PIN of a user after checking their password
+	SetPIN(ctx context.Context, id uint64, password, pin string) error
+	// ChangePassword sets a new password for a user after checking their old one
+	ChangePassword(ctx context.Context, id uint64, oldPassword, newPassword string) error
 }
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	adomain "go-restaurant/internal/auth/domain"
+	cmdomain "go-restaurant/internal/common/domain"
+	cmport "go-restaurant/internal/common/port"
+	cmutil "go-restaurant/internal/common/util"
+	roleport "go-restaurant/internal/role/port"
+	"go-restaurant/internal/user/domain"
+	"go-restaurant/internal/user/port"
+	"time"
+)
+
+// inviteDuration is how long an invite can be used after it is created
+const inviteDuration = 7 * 24 * time.Hour
+
+/*InviteService implements port.InviteService interface
+ * and provides access to the invite repository,
+ * role service and cache service
+ */
+type InviteService struct {
+	repo  port.InviteRepository
+	roles roleport.RoleService
+	cache cmport.CacheRepository
+}
+
+// NewInviteService creates a new invite service instance
+func NewInviteService(repo port.InviteRepository, roles roleport.RoleService, cache cmport.CacheRepository) *InviteService {
+	return &InviteService{
+		repo,
+		roles,
+		cache,
+	}
+}
+
+// CreateInvite creates a new invite to register with a role and generates its code. The grantor must be
+// allowed every permission of the role. Only the hash of the code is stored, so it is returned this one time
+func (is *InviteService) CreateInvite(ctx context.Context, grantor *adomain.TokenPayload, invite *domain.Invite) (*domain.Invite, string, error) {
+	canGrant, err := is.roles.CanGrantRole(ctx, grantor, invite.Role)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !canGrant {
+		return nil, "", cmdomain.ErrRoleNotGrantable
+	}
+
+	secret := make([]byte, 16)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return nil, "", cmdomain.ErrInternal
+	}
+
+	code := base64.RawURLEncoding.EncodeToString(secret)
+
+	invite.CodeHash = hashInviteCode(code)
+	invite.ExpiresAt = time.Now().Add(inviteDuration)
+
+	invite, err = is.repo.CreateInvite(ctx, invite)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrInvalidRole) {
+			return nil, "", err
+		}
+		return nil, "", cmdomain.ErrInternal
+	}
+
+	err = is.cache.DeleteByPrefix(ctx, "invites:*")
+	if err != nil {
+		return nil, "", cmdomain.ErrInternal
+	}
+
+	return invite, code, nil
+}
+
+// ListInvites lists all invites
+func (is *InviteService) ListInvites(ctx context.Context, skip, limit uint64) ([]domain.Invite, error) {
+	var invites []domain.Invite
+
+	params := cmutil.GenerateCacheKeyParams(skip, limit)
+	cacheKey := cmutil.GenerateCacheKey("invites", params)
+
+	cachedInvites, err := is.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := cmutil.Deserialize(cachedInvites, &invites)
+		if err != nil {
+			return nil, cmdomain.ErrInternal
+		}
+
+		return invites, nil
+	}
+
+	invites, err = is.repo.ListInvites(ctx, skip, limit)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	invitesSerialized, err := cmutil.Serialize(invites)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = is.cache.Set(ctx, cacheKey, invitesSerialized, 0)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return invites, nil
+}
+
+// DeleteInvite deletes an invite by ID, so its code can no longer be used
+func (is *InviteService) DeleteInvite(ctx context.Context, id uint64) error {
+	_, err := is.repo.GetInviteByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return err
+		}
+		return cmdomain.ErrInternal
+	}
+
+	err = is.repo.DeleteInvite(ctx, id)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	err = is.cache.DeleteByPrefix(ctx, "invites:*")
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// hashInviteCode hashes an invite code, which is random enough that a fast hash is safe
+func hashInviteCode(code string) string {
+	hash := sha256.Sum256([]byte(code))
+
+	return hex.EncodeToString(hash[:])
+}
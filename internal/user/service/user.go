@@ -3,40 +3,121 @@ package service
 import (
 	"context"
 	"errors"
+	auditdomain "go-restaurant/internal/audit/domain"
+	auditport "go-restaurant/internal/audit/port"
+	adomain "go-restaurant/internal/auth/domain"
+	aport "go-restaurant/internal/auth/port"
 	cmdomain "go-restaurant/internal/common/domain"
 	cmport "go-restaurant/internal/common/port"
 	cmutil "go-restaurant/internal/common/util"
+	roleport "go-restaurant/internal/role/port"
 	"go-restaurant/internal/user/domain"
 	"go-restaurant/internal/user/port"
 )
 
 /*UserService implements port.UserService interface
- * and provides access to the user repository
- * and cache service
+ * and provides access to the user repository,
+ * invite repository, identity repository, transactor,
+ * cache service, auth service, role service and audit service
  */
 type UserService struct {
-	repo  port.UserRepository
-	cache cmport.CacheRepository
+	repo         port.UserRepository
+	invites      port.InviteRepository
+	identities   aport.IdentityRepository
+	transactor   cmport.Transactor
+	cache        cmport.CacheRepository
+	auth         aport.AuthService
+	roles        roleport.RoleService
+	audit        auditport.AuditService
+	registration domain.Registration
 }
 
 // NewUserService creates a new user service instance
-func NewUserService(repo port.UserRepository, cache cmport.CacheRepository) *UserService {
+func NewUserService(repo port.UserRepository, invites port.InviteRepository, identities aport.IdentityRepository, transactor cmport.Transactor, cache cmport.CacheRepository, auth aport.AuthService, roles roleport.RoleService, audit auditport.AuditService, registration domain.Registration) *UserService {
 	return &UserService{
 		repo,
+		invites,
+		identities,
+		transactor,
 		cache,
+		auth,
+		roles,
+		audit,
+		registration,
 	}
 }
 
-// Register creates a new user
-func (us *UserService) Register(ctx context.Context, user *domain.User) (*domain.User, error) {
+// Register creates a new user with the role of the invite they register with,
+// unless registration is disabled
+func (us *UserService) Register(ctx context.Context, user *domain.User, inviteCode string) (*domain.User, error) {
+	if us.registration == domain.RegistrationDisabled {
+		return nil, cmdomain.ErrRegistrationDisabled
+	}
+
+	if inviteCode == "" {
+		return nil, cmdomain.ErrInvalidInvite
+	}
+
+	hashedPassword, err := cmutil.HashPassword(user.Password)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	user.Password = hashedPassword
+
+	err = us.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = us.invites.RedeemInvite(ctx, hashInviteCode(inviteCode), user)
+		if err != nil {
+			return err
+		}
+
+		return us.audit.Record(ctx, auditdomain.ActionCreate, auditdomain.EntityUser, user.ID, nil, user)
+	})
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrConflictingData) || errors.Is(err, cmdomain.ErrInvalidInvite) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = us.cache.DeleteByPrefix(ctx, "invites:*")
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return us.cacheNewUser(ctx, user)
+}
+
+// BootstrapAdmin creates the initial admin on the first run, so the users who set up the service
+// can log in and invite everyone else. Nothing is created once there is an admin
+func (us *UserService) BootstrapAdmin(ctx context.Context, user *domain.User) (*domain.User, error) {
+	count, err := us.repo.CountUsersByRole(ctx, domain.Admin)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	if count > 0 {
+		return nil, nil
+	}
+
 	hashedPassword, err := cmutil.HashPassword(user.Password)
 	if err != nil {
 		return nil, cmdomain.ErrInternal
 	}
 
 	user.Password = hashedPassword
+	user.Role = domain.Admin
+
+	err = us.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = us.repo.CreateUser(ctx, user)
+		if err != nil {
+			return err
+		}
 
-	user, err = us.repo.CreateUser(ctx, user)
+		return us.audit.Record(ctx, auditdomain.ActionCreate, auditdomain.EntityUser, user.ID, nil, user)
+	})
 	if err != nil {
 		if errors.Is(err, cmdomain.ErrConflictingData) {
 			return nil, err
@@ -44,6 +125,41 @@ func (us *UserService) Register(ctx context.Context, user *domain.User) (*domain
 		return nil, cmdomain.ErrInternal
 	}
 
+	return us.cacheNewUser(ctx, user)
+}
+
+// ProvisionUser creates a user linked to their account at an identity provider, with the role mapped from
+// their groups there. The link records that the user was provisioned, so their role follows the identity provider
+func (us *UserService) ProvisionUser(ctx context.Context, identity *adomain.UserIdentity, user *domain.User) (*domain.User, error) {
+	hashedPassword, err := cmutil.HashPassword(user.Password)
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	user.Password = hashedPassword
+	identity.Provisioned = true
+
+	err = us.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = us.identities.ProvisionUser(ctx, identity, user)
+		if err != nil {
+			return err
+		}
+
+		return us.audit.Record(ctx, auditdomain.ActionCreate, auditdomain.EntityUser, user.ID, nil, user)
+	})
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrConflictingData) || errors.Is(err, cmdomain.ErrInvalidRole) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	return us.cacheNewUser(ctx, user)
+}
+
+// cacheNewUser caches a newly created user and invalidates the cached user lists
+func (us *UserService) cacheNewUser(ctx context.Context, user *domain.User) (*domain.User, error) {
 	cacheKey := cmutil.GenerateCacheKey("user", user.ID)
 	userSerialized, err := cmutil.Serialize(user)
 	if err != nil {
@@ -132,8 +248,10 @@ func (us *UserService) ListUsers(ctx context.Context, skip, limit uint64) ([]dom
 	return users, nil
 }
 
-// UpdateUser updates a user's name, email, and password
-func (us *UserService) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+// UpdateUser updates a user's name, email, password and role. The grantor can only update users
+// whose current and new role they could give themselves, so they cannot take over or create users
+// with more permissions than their own
+func (us *UserService) UpdateUser(ctx context.Context, grantor *adomain.TokenPayload, user *domain.User) (*domain.User, error) {
 	existingUser, err := us.repo.GetUserByID(ctx, user.ID)
 	if err != nil {
 		if errors.Is(err, cmdomain.ErrDataNotFound) {
@@ -142,6 +260,23 @@ func (us *UserService) UpdateUser(ctx context.Context, user *domain.User) (*doma
 		return nil, cmdomain.ErrInternal
 	}
 
+	err = us.authorizeRole(ctx, grantor, existingUser.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role != "" && user.Role != existingUser.Role {
+		err = us.authorizeRole(ctx, grantor, user.Role)
+		if err != nil {
+			return nil, err
+		}
+
+		err = us.keepAdmin(ctx, existingUser, user.Role)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	emptyData := user.Name == "" &&
 		user.Email == "" &&
 		user.Password == "" &&
@@ -164,14 +299,63 @@ func (us *UserService) UpdateUser(ctx context.Context, user *domain.User) (*doma
 
 	user.Password = hashedPassword
 
-	_, err = us.repo.UpdateUser(ctx, user)
+	return us.saveUser(ctx, existingUser, user)
+}
+
+// SyncRole gives a user provisioned by single sign-on the role mapped from their identity provider groups,
+// unless it would leave no admin
+func (us *UserService) SyncRole(ctx context.Context, id uint64, role domain.UserRole) (*domain.User, error) {
+	existingUser, err := us.repo.GetUserByID(ctx, id)
 	if err != nil {
-		if errors.Is(err, cmdomain.ErrConflictingData) {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
 			return nil, err
 		}
 		return nil, cmdomain.ErrInternal
 	}
 
+	if existingUser.Role == role {
+		return existingUser, nil
+	}
+
+	err = us.keepAdmin(ctx, existingUser, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.saveUser(ctx, existingUser, &domain.User{
+		ID:   id,
+		Role: role,
+	})
+}
+
+// saveUser stores and audits the changes to a user, revoking their sessions if their password or role changed,
+// then caches the updated user
+func (us *UserService) saveUser(ctx context.Context, existingUser, user *domain.User) (*domain.User, error) {
+	credentialsChanged := user.Password != "" ||
+		(user.Role != "" && user.Role != existingUser.Role)
+
+	err := us.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := us.repo.UpdateUser(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		return us.audit.Record(ctx, auditdomain.ActionUpdate, auditdomain.EntityUser, user.ID, existingUser, user)
+	})
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrConflictingData) || errors.Is(err, cmdomain.ErrInvalidRole) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	if credentialsChanged {
+		err = us.auth.RevokeUserTokens(ctx, user.ID)
+		if err != nil {
+			return nil, cmdomain.ErrInternal
+		}
+	}
+
 	cacheKey := cmutil.GenerateCacheKey("user", user.ID)
 
 	err = us.cache.Delete(ctx, cacheKey)
@@ -197,9 +381,35 @@ func (us *UserService) UpdateUser(ctx context.Context, user *domain.User) (*doma
 	return user, nil
 }
 
-// DeleteUser deletes a user by ID
-func (us *UserService) DeleteUser(ctx context.Context, id uint64) error {
-	_, err := us.repo.GetUserByID(ctx, id)
+// DeleteUser soft deletes a user by ID and revokes their tokens. The grantor can only delete users
+// whose role they could give themselves, and the last admin cannot be deleted
+func (us *UserService) DeleteUser(ctx context.Context, grantor *adomain.TokenPayload, id uint64) error {
+	existingUser, err := us.repo.GetUserByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return err
+		}
+		return cmdomain.ErrInternal
+	}
+
+	err = us.authorizeRole(ctx, grantor, existingUser.Role)
+	if err != nil {
+		return err
+	}
+
+	err = us.keepAdmin(ctx, existingUser, "")
+	if err != nil {
+		return err
+	}
+
+	err = us.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := us.repo.DeleteUser(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return us.audit.Record(ctx, auditdomain.ActionDelete, auditdomain.EntityUser, id, existingUser, nil)
+	})
 	if err != nil {
 		if errors.Is(err, cmdomain.ErrDataNotFound) {
 			return err
@@ -219,5 +429,179 @@ func (us *UserService) DeleteUser(ctx context.Context, id uint64) error {
 		return cmdomain.ErrInternal
 	}
 
-	return us.repo.DeleteUser(ctx, id)
+	err = us.auth.RevokeUserTokens(ctx, id)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// RestoreUser restores a deleted user. The grantor can only restore users whose role they could give themselves
+func (us *UserService) RestoreUser(ctx context.Context, grantor *adomain.TokenPayload, id uint64) (*domain.User, error) {
+	existingUser, err := us.repo.GetUserByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = us.authorizeRole(ctx, grantor, existingUser.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *domain.User
+	err = us.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = us.repo.RestoreUser(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return us.audit.Record(ctx, auditdomain.ActionRestore, auditdomain.EntityUser, id, nil, user)
+	})
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) || errors.Is(err, cmdomain.ErrConflictingData) {
+			return nil, err
+		}
+		return nil, cmdomain.ErrInternal
+	}
+
+	err = us.cache.DeleteByPrefix(ctx, "users:*")
+	if err != nil {
+		return nil, cmdomain.ErrInternal
+	}
+
+	return user, nil
+}
+
+// SetPIN hashes and sets the PIN a user enters to approve the sensitive actions of other users
+func (us *UserService) SetPIN(ctx context.Context, id uint64, password, pin string) error {
+	user, err := us.repo.GetUserByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return err
+		}
+		return cmdomain.ErrInternal
+	}
+
+	err = cmutil.ComparePassword(password, user.Password)
+	if err != nil {
+		return cmdomain.ErrInvalidCredentials
+	}
+
+	hashedPIN, err := cmutil.HashPassword(pin)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	err = us.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		updatedUser, err := us.repo.UpdateUser(ctx, &domain.User{
+			ID:  id,
+			PIN: hashedPIN,
+		})
+		if err != nil {
+			return err
+		}
+
+		return us.audit.Record(ctx, auditdomain.ActionUpdate, auditdomain.EntityUser, id, user, updatedUser)
+	})
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("user", id)
+
+	err = us.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// ChangePassword sets a new password for a user after checking their old one,
+// and revokes every session of the user
+func (us *UserService) ChangePassword(ctx context.Context, id uint64, oldPassword, newPassword string) error {
+	user, err := us.repo.GetUserByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, cmdomain.ErrDataNotFound) {
+			return err
+		}
+		return cmdomain.ErrInternal
+	}
+
+	err = cmutil.ComparePassword(oldPassword, user.Password)
+	if err != nil {
+		return cmdomain.ErrInvalidCredentials
+	}
+
+	hashedPassword, err := cmutil.HashPassword(newPassword)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	err = us.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		updatedUser, err := us.repo.UpdateUser(ctx, &domain.User{
+			ID:       id,
+			Password: hashedPassword,
+		})
+		if err != nil {
+			return err
+		}
+
+		return us.audit.Record(ctx, auditdomain.ActionUpdate, auditdomain.EntityUser, id, user, updatedUser)
+	})
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	cacheKey := cmutil.GenerateCacheKey("user", id)
+
+	err = us.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	err = us.auth.RevokeUserTokens(ctx, id)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	return nil
+}
+
+// keepAdmin checks that a user losing the admin role, to another role or by being deleted with an empty role,
+// is not the last admin, who is needed to manage the service
+func (us *UserService) keepAdmin(ctx context.Context, existingUser *domain.User, role domain.UserRole) error {
+	if existingUser.Role != domain.Admin || role == domain.Admin {
+		return nil
+	}
+
+	count, err := us.repo.CountUsersByRole(ctx, domain.Admin)
+	if err != nil {
+		return cmdomain.ErrInternal
+	}
+
+	if count <= 1 {
+		return cmdomain.ErrLastAdmin
+	}
+
+	return nil
+}
+
+// authorizeRole checks that the grantor is allowed every permission of a role, so they can give it to a user
+func (us *UserService) authorizeRole(ctx context.Context, grantor *adomain.TokenPayload, role domain.UserRole) error {
+	canGrant, err := us.roles.CanGrantRole(ctx, grantor, role)
+	if err != nil {
+		return err
+	}
+
+	if !canGrant {
+		return cmdomain.ErrRoleNotGrantable
+	}
+
+	return nil
 }